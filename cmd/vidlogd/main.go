@@ -4,11 +4,11 @@ import (
 	"fmt"
 	"os"
 
-	"github.com/mamuzad/vidlogd/internal/app"
+	"github.com/mamuzad/vidlogd/internal/cli"
 )
 
 func main() {
-	if err := app.Run(); err != nil {
+	if err := cli.Run(os.Args[1:]); err != nil {
 		fmt.Printf("Error: %v\n", err)
 		os.Exit(1)
 	}
@@ -0,0 +1,87 @@
+// Package analytics computes the log statistics shown on the dashboard and
+// in reports. Everything here is a pure function of the videos passed in and,
+// where "now" matters, an injected Clock.
+package analytics
+
+import (
+	"time"
+
+	"github.com/mamuzad/vidlogd/internal/models"
+)
+
+// Clock returns the current time; tests pass a fixed one
+type Clock func() time.Time
+
+// SystemClock is the wall clock
+var SystemClock Clock = time.Now
+
+// Ratings lists the rating buckets, lowest first
+var Ratings = []float64{0.5, 1.0, 1.5, 2.0, 2.5, 3.0, 3.5, 4.0, 4.5, 5.0}
+
+// Summary holds the headline numbers for a set of videos
+type Summary struct {
+	Total     int
+	Rated     int
+	Rewatches int
+	AvgRating float64 // over rated videos, 0 when none are rated
+	Ratings   []RatingBucket
+	Channels  []ChannelStats // most logged first
+	Months    []MonthCount   // most recent first, only months with videos
+}
+
+// RatingBucket counts the videos with one rating
+type RatingBucket struct {
+	Rating float64
+	Count  int
+}
+
+// Summarize aggregates videos into a Summary
+func Summarize(videos []models.Video) Summary {
+	s := Summary{
+		Total:    len(videos),
+		Ratings:  RatingDistribution(videos),
+		Channels: Channels(videos),
+		Months:   Months(videos),
+	}
+
+	var sum float64
+	for _, video := range videos {
+		if video.Rating > 0 {
+			sum += video.Rating
+			s.Rated++
+		}
+		if video.Rewatched {
+			s.Rewatches++
+		}
+	}
+	if s.Rated > 0 {
+		s.AvgRating = sum / float64(s.Rated)
+	}
+
+	return s
+}
+
+// RewatchShare is the fraction of videos that were rewatches
+func (s Summary) RewatchShare() float64 {
+	if s.Total == 0 {
+		return 0
+	}
+	return float64(s.Rewatches) / float64(s.Total)
+}
+
+// RatingDistribution counts videos per rating bucket, unrated videos excluded
+func RatingDistribution(videos []models.Video) []RatingBucket {
+	buckets := make([]RatingBucket, len(Ratings))
+	index := make(map[float64]int, len(Ratings))
+	for i, rating := range Ratings {
+		buckets[i].Rating = rating
+		index[rating] = i
+	}
+
+	for _, video := range videos {
+		if i, ok := index[video.Rating]; ok {
+			buckets[i].Count++
+		}
+	}
+	return buckets
+}
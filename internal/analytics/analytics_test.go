@@ -0,0 +1,276 @@
+package analytics
+
+import (
+	"testing"
+	"time"
+
+	"github.com/mamuzad/vidlogd/internal/models"
+)
+
+func at(y int, m time.Month, d, hour int) time.Time {
+	return time.Date(y, m, d, hour, 0, 0, 0, time.Local)
+}
+
+func fixedClock(t time.Time) Clock {
+	return func() time.Time { return t }
+}
+
+func logged(days ...time.Time) []models.Video {
+	videos := make([]models.Video, len(days))
+	for i, day := range days {
+		videos[i] = models.Video{Title: day.Format(time.RFC3339), LogDate: day}
+	}
+	return videos
+}
+
+func TestSummarize(t *testing.T) {
+	videos := []models.Video{
+		{Channel: "Gophers", Rating: 4, LogDate: at(2025, 1, 5, 9), Rewatched: true},
+		{Channel: "Gophers", Rating: 3, LogDate: at(2025, 1, 20, 9)},
+		{Channel: "Crabs", Rating: 5, LogDate: at(2025, 3, 1, 9)},
+		{Channel: "", LogDate: at(2025, 3, 2, 9)},
+		{Channel: "Crabs"},
+	}
+
+	s := Summarize(videos)
+
+	if s.Total != 5 || s.Rated != 3 || s.Rewatches != 1 {
+		t.Fatalf("total %d rated %d rewatches %d", s.Total, s.Rated, s.Rewatches)
+	}
+	if s.AvgRating != 4 {
+		t.Errorf("avg rating = %v", s.AvgRating)
+	}
+	if s.RewatchShare() != 0.2 {
+		t.Errorf("rewatch share = %v", s.RewatchShare())
+	}
+	if len(s.Channels) != 3 || s.Channels[2].Channel != UnknownChannel {
+		t.Errorf("channels = %+v", s.Channels)
+	}
+	if len(s.Months) != 2 || !s.Months[0].Month.Equal(at(2025, 3, 1, 0)) || s.Months[0].Count != 2 {
+		t.Errorf("months = %+v", s.Months)
+	}
+	if Summarize(nil).RewatchShare() != 0 {
+		t.Error("empty summary should have no rewatch share")
+	}
+}
+
+func TestRatingDistribution(t *testing.T) {
+	tests := []struct {
+		name    string
+		ratings []float64
+		want    map[float64]int
+	}{
+		{"empty", nil, map[float64]int{}},
+		{"unrated ignored", []float64{0, 0}, map[float64]int{}},
+		{"half stars", []float64{4.5, 4.5, 1, 5}, map[float64]int{4.5: 2, 1: 1, 5: 1}},
+		{"half a star", []float64{0.5, 3}, map[float64]int{0.5: 1, 3: 1}},
+		{"off scale ignored", []float64{0.25, 5.5, 3}, map[float64]int{3: 1}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var videos []models.Video
+			for _, r := range tt.ratings {
+				videos = append(videos, models.Video{Rating: r})
+			}
+			buckets := RatingDistribution(videos)
+			if len(buckets) != len(Ratings) {
+				t.Fatalf("got %d buckets", len(buckets))
+			}
+			for _, b := range buckets {
+				if b.Count != tt.want[b.Rating] {
+					t.Errorf("rating %v: got %d, want %d", b.Rating, b.Count, tt.want[b.Rating])
+				}
+			}
+		})
+	}
+}
+
+func TestChannels(t *testing.T) {
+	tests := []struct {
+		name   string
+		videos []models.Video
+		want   []ChannelStats
+	}{
+		{"empty", nil, nil},
+		{
+			"count then rating then name",
+			[]models.Video{
+				{Channel: "b", Rating: 3}, {Channel: "a", Rating: 3}, {Channel: "c", Rating: 5},
+				{Channel: "d"}, {Channel: "d"},
+			},
+			[]ChannelStats{
+				{Channel: "d", Count: 2},
+				{Channel: "c", Count: 1, AvgRating: 5, TotalRated: 1},
+				{Channel: "a", Count: 1, AvgRating: 3, TotalRated: 1},
+				{Channel: "b", Count: 1, AvgRating: 3, TotalRated: 1},
+			},
+		},
+		{
+			"average over rated only",
+			[]models.Video{{Channel: "a", Rating: 4}, {Channel: "a"}, {Channel: "a", Rating: 2}},
+			[]ChannelStats{{Channel: "a", Count: 3, AvgRating: 3, TotalRated: 2}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Channels(tt.videos)
+			if len(got) != len(tt.want) {
+				t.Fatalf("got %+v, want %+v", got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Errorf("channel %d: got %+v, want %+v", i, got[i], tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestTopRated(t *testing.T) {
+	channels := []ChannelStats{
+		{Channel: "once", Count: 1, AvgRating: 5, TotalRated: 1},
+		{Channel: "good", Count: 3, AvgRating: 4, TotalRated: 3},
+		{Channel: "also good", Count: 2, AvgRating: 4, TotalRated: 2},
+		{Channel: "unrated", Count: 9},
+	}
+	got := TopRated(channels, 2)
+	if len(got) != 2 || got[0].Channel != "good" || got[1].Channel != "also good" {
+		t.Errorf("got %+v", got)
+	}
+	if got := TopRated(channels, 0); len(got) != 3 || got[0].Channel != "once" {
+		t.Errorf("min 0: got %+v", got)
+	}
+}
+
+func TestChannelDetails(t *testing.T) {
+	videos := []models.Video{
+		{Channel: "Gophers", Rating: 4, LogDate: at(2025, 3, 1, 9), Duration: 600, ChannelID: "UC2"},
+		{Channel: "Gophers", Rating: 2, LogDate: at(2025, 1, 5, 9), Rewatched: true, ChannelID: "UC1"},
+		{Channel: "Gophers", LogDate: at(2025, 2, 1, 9), Duration: 1200},
+		{Channel: "Other", Rating: 5, LogDate: at(2025, 4, 1, 9)},
+	}
+
+	d := ChannelDetails(videos, "Gophers")
+	if d.Count != 3 || d.TotalRated != 2 || d.AvgRating != 3 || d.Rewatches != 1 {
+		t.Errorf("unexpected stats: %+v", d.ChannelStats)
+	}
+	if d.WatchTime != 1800 || d.Untimed != 1 {
+		t.Errorf("watch time %d, untimed %d", d.WatchTime, d.Untimed)
+	}
+	if !d.First.Equal(at(2025, 1, 5, 9)) || !d.Last.Equal(at(2025, 3, 1, 9)) {
+		t.Errorf("first %v, last %v", d.First, d.Last)
+	}
+	if d.ChannelID != "UC2" {
+		t.Errorf("channel ID %q, want the latest", d.ChannelID)
+	}
+	if !d.Videos[0].LogDate.Equal(d.Last) {
+		t.Errorf("expected newest first, got %v", d.Videos[0].LogDate)
+	}
+
+	if d := ChannelDetails(videos, "missing"); d.Count != 0 || len(d.Videos) != 0 {
+		t.Errorf("expected no videos, got %+v", d)
+	}
+}
+
+func TestStreaks(t *testing.T) {
+	now := at(2025, 3, 10, 20)
+
+	tests := []struct {
+		name    string
+		videos  []models.Video
+		current Streak
+		best    Streak
+	}{
+		{"no videos", nil, Streak{}, Streak{}},
+		{"undated only", []models.Video{{Title: "x"}}, Streak{}, Streak{}},
+		{
+			"today",
+			logged(at(2025, 3, 10, 8), at(2025, 3, 10, 9)),
+			Streak{Videos: 2, Days: 1, Start: at(2025, 3, 10, 0), End: at(2025, 3, 10, 0)},
+			Streak{Videos: 2, Days: 1, Start: at(2025, 3, 10, 0), End: at(2025, 3, 10, 0)},
+		},
+		{
+			"current ends yesterday",
+			logged(at(2025, 3, 9, 23), at(2025, 3, 8, 1), at(2025, 3, 5, 1)),
+			Streak{Videos: 2, Days: 2, Start: at(2025, 3, 8, 0), End: at(2025, 3, 9, 0)},
+			Streak{Videos: 2, Days: 2, Start: at(2025, 3, 8, 0), End: at(2025, 3, 9, 0)},
+		},
+		{
+			"broken two days ago",
+			logged(at(2025, 3, 8, 12), at(2025, 2, 1, 12), at(2025, 2, 2, 12), at(2025, 2, 2, 13)),
+			Streak{},
+			Streak{Videos: 3, Days: 2, Start: at(2025, 2, 1, 0), End: at(2025, 2, 2, 0)},
+		},
+		{
+			"unsorted input",
+			logged(at(2025, 1, 3, 12), at(2025, 1, 1, 12), at(2025, 1, 2, 12)),
+			Streak{},
+			Streak{Videos: 3, Days: 3, Start: at(2025, 1, 1, 0), End: at(2025, 1, 3, 0)},
+		},
+		{
+			"ties keep the most recent",
+			logged(at(2025, 1, 1, 12), at(2025, 2, 1, 12)),
+			Streak{},
+			Streak{Videos: 1, Days: 1, Start: at(2025, 2, 1, 0), End: at(2025, 2, 1, 0)},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			current, best := Streaks(tt.videos, fixedClock(now))
+			if !sameStreak(current, tt.current) {
+				t.Errorf("current = %+v, want %+v", current, tt.current)
+			}
+			if !sameStreak(best, tt.best) {
+				t.Errorf("best = %+v, want %+v", best, tt.best)
+			}
+		})
+	}
+}
+
+func TestLongestStreak(t *testing.T) {
+	// three busy days against four quiet ones
+	videos := logged(
+		at(2025, 1, 1, 9), at(2025, 1, 1, 10), at(2025, 1, 2, 9), at(2025, 1, 2, 10), at(2025, 1, 3, 9),
+		at(2025, 2, 1, 9), at(2025, 2, 2, 9), at(2025, 2, 3, 9), at(2025, 2, 4, 9),
+	)
+
+	longest := LongestStreak(videos)
+	if longest.Days != 4 || !longest.Start.Equal(at(2025, 2, 1, 0)) {
+		t.Errorf("longest = %+v", longest)
+	}
+	if _, best := Streaks(videos, fixedClock(at(2025, 6, 1, 0))); best.Videos != 5 || best.Days != 3 {
+		t.Errorf("best = %+v", best)
+	}
+}
+
+func TestBusiestMonth(t *testing.T) {
+	videos := logged(at(2025, 1, 1, 12), at(2025, 2, 1, 12), at(2025, 2, 2, 12), at(2025, 3, 1, 12), at(2025, 3, 2, 12))
+	if got := BusiestMonth(videos); got.Count != 2 || !got.Month.Equal(at(2025, 2, 1, 0)) {
+		t.Errorf("busiest = %+v", got)
+	}
+	if got := BusiestMonth(nil); got.Count != 0 {
+		t.Errorf("empty busiest = %+v", got)
+	}
+}
+
+func TestDayIndex(t *testing.T) {
+	idx := IndexDays(append(logged(at(2025, 5, 1, 0), at(2025, 5, 1, 23), at(2024, 5, 2, 12)), models.Video{}))
+
+	days := idx.Days()
+	if len(days) != 2 || !days[0].Equal(at(2025, 5, 1, 0)) {
+		t.Errorf("days = %v", days)
+	}
+	if len(idx[DayOf(at(2025, 5, 1, 15))]) != 2 {
+		t.Error("expected two videos on 2025-05-01")
+	}
+	if idx.Busiest(2025) != 2 || idx.Busiest(2024) != 1 || idx.Busiest(2023) != 0 {
+		t.Errorf("busiest = %d %d %d", idx.Busiest(2025), idx.Busiest(2024), idx.Busiest(2023))
+	}
+	if n := DaysBetween(at(2025, 3, 1, 0), at(2025, 4, 1, 0)); n != 31 {
+		t.Errorf("days between = %d", n)
+	}
+}
+
+func sameStreak(a, b Streak) bool {
+	return a.Videos == b.Videos && a.Days == b.Days && a.Start.Equal(b.Start) && a.End.Equal(b.End)
+}
@@ -0,0 +1,149 @@
+package analytics
+
+import (
+	"sort"
+	"time"
+
+	"github.com/mamuzad/vidlogd/internal/models"
+)
+
+// UnknownChannel names videos logged without a channel
+const UnknownChannel = "Unknown Channel"
+
+// ChannelStats summarizes one channel
+type ChannelStats struct {
+	Channel    string
+	Count      int
+	AvgRating  float64 // over rated videos, 0 when none are rated
+	TotalRated int
+}
+
+// ChannelName returns the channel videos are grouped under
+func ChannelName(video models.Video) string {
+	if video.Channel == "" {
+		return UnknownChannel
+	}
+	return video.Channel
+}
+
+// Channels groups videos by channel, most logged first, then best rated,
+// then by name
+func Channels(videos []models.Video) []ChannelStats {
+	index := make(map[string]int)
+	var channels []ChannelStats
+	sums := make(map[string]float64)
+
+	for _, video := range videos {
+		name := ChannelName(video)
+		i, ok := index[name]
+		if !ok {
+			i = len(channels)
+			index[name] = i
+			channels = append(channels, ChannelStats{Channel: name})
+		}
+		channels[i].Count++
+		if video.Rating > 0 {
+			channels[i].TotalRated++
+			sums[name] += video.Rating
+		}
+	}
+
+	for i := range channels {
+		if c := &channels[i]; c.TotalRated > 0 {
+			c.AvgRating = sums[c.Channel] / float64(c.TotalRated)
+		}
+	}
+
+	sort.Slice(channels, func(i, j int) bool {
+		a, b := channels[i], channels[j]
+		if a.Count != b.Count {
+			return a.Count > b.Count
+		}
+		if a.AvgRating != b.AvgRating {
+			return a.AvgRating > b.AvgRating
+		}
+		return a.Channel < b.Channel
+	})
+
+	return channels
+}
+
+// TopRated returns channels with at least minRated rated videos, best
+// average first, then more ratings, then by name
+func TopRated(channels []ChannelStats, minRated int) []ChannelStats {
+	var rated []ChannelStats
+	for _, c := range channels {
+		if c.TotalRated >= minRated && c.TotalRated > 0 {
+			rated = append(rated, c)
+		}
+	}
+
+	sort.Slice(rated, func(i, j int) bool {
+		a, b := rated[i], rated[j]
+		if a.AvgRating != b.AvgRating {
+			return a.AvgRating > b.AvgRating
+		}
+		if a.TotalRated != b.TotalRated {
+			return a.TotalRated > b.TotalRated
+		}
+		return a.Channel < b.Channel
+	})
+
+	return rated
+}
+
+// ChannelDetail is one channel's history
+type ChannelDetail struct {
+	ChannelStats
+	Videos    []models.Video // newest first
+	First     time.Time      // first logged video
+	Last      time.Time      // last logged video
+	WatchTime int            // seconds over videos with a known duration
+	Untimed   int            // videos without a duration
+	Rewatches int
+	ChannelID string // YouTube channel ID of the latest video that has one
+}
+
+// ChannelDetails collects the videos grouped under channel
+func ChannelDetails(videos []models.Video, channel string) ChannelDetail {
+	d := ChannelDetail{ChannelStats: ChannelStats{Channel: channel}}
+
+	var sum float64
+	for _, video := range videos {
+		if ChannelName(video) != channel {
+			continue
+		}
+		d.Videos = append(d.Videos, video)
+		d.Count++
+		if video.Rating > 0 {
+			d.TotalRated++
+			sum += video.Rating
+		}
+		if video.Rewatched {
+			d.Rewatches++
+		}
+		if video.Duration > 0 {
+			d.WatchTime += video.Duration
+		} else {
+			d.Untimed++
+		}
+		if video.LogDate.IsZero() {
+			continue
+		}
+		if video.ChannelID != "" && !video.LogDate.Before(d.Last) {
+			d.ChannelID = video.ChannelID
+		}
+		if d.First.IsZero() || video.LogDate.Before(d.First) {
+			d.First = video.LogDate
+		}
+		if video.LogDate.After(d.Last) {
+			d.Last = video.LogDate
+		}
+	}
+	if d.TotalRated > 0 {
+		d.AvgRating = sum / float64(d.TotalRated)
+	}
+
+	sort.SliceStable(d.Videos, func(i, j int) bool { return d.Videos[i].LogDate.After(d.Videos[j].LogDate) })
+	return d
+}
@@ -0,0 +1,116 @@
+package analytics
+
+import (
+	"math"
+	"sort"
+	"time"
+
+	"github.com/mamuzad/vidlogd/internal/models"
+)
+
+// DayIndex groups videos by the local day they were logged. Keys are local
+// midnights as returned by DayOf.
+type DayIndex map[time.Time][]models.Video
+
+// IndexDays builds a DayIndex, skipping videos without a log date
+func IndexDays(videos []models.Video) DayIndex {
+	idx := make(DayIndex)
+	for _, video := range videos {
+		if video.LogDate.IsZero() {
+			continue
+		}
+		day := DayOf(video.LogDate)
+		idx[day] = append(idx[day], video)
+	}
+	return idx
+}
+
+// DayOf truncates t to local midnight
+func DayOf(t time.Time) time.Time {
+	y, m, d := t.In(time.Local).Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, time.Local)
+}
+
+// DaysBetween counts calendar days from a to b, ignoring DST shifts
+func DaysBetween(a, b time.Time) int {
+	return int(math.Round(b.Sub(a).Hours() / 24))
+}
+
+// Days returns the days with videos, most recent first
+func (idx DayIndex) Days() []time.Time {
+	days := make([]time.Time, 0, len(idx))
+	for day := range idx {
+		days = append(days, day)
+	}
+	sort.Slice(days, func(i, j int) bool { return days[i].After(days[j]) })
+	return days
+}
+
+// Busiest returns the most videos logged on a single day of year
+func (idx DayIndex) Busiest(year int) int {
+	most := 0
+	for day, videos := range idx {
+		if day.Year() == year {
+			most = max(most, len(videos))
+		}
+	}
+	return most
+}
+
+// Streak is a run of consecutive days with at least one video
+type Streak struct {
+	Videos int
+	Days   int
+	Start  time.Time // first day, local midnight
+	End    time.Time // last day, local midnight
+}
+
+// runs splits the index into streaks, most recent first
+func (idx DayIndex) runs() []Streak {
+	var runs []Streak
+	for _, day := range idx.Days() {
+		n := len(idx[day])
+		if len(runs) > 0 && runs[len(runs)-1].Start.AddDate(0, 0, -1).Equal(day) {
+			run := &runs[len(runs)-1]
+			run.Videos += n
+			run.Days++
+			run.Start = day
+			continue
+		}
+		runs = append(runs, Streak{Videos: n, Days: 1, Start: day, End: day})
+	}
+	return runs
+}
+
+// Streaks returns the current streak, which must include today or
+// yesterday, and the best streak, the run with the most videos
+func Streaks(videos []models.Video, clock Clock) (current, best Streak) {
+	runs := IndexDays(videos).runs()
+	if len(runs) == 0 {
+		return Streak{}, Streak{}
+	}
+
+	yesterday := DayOf(clock()).AddDate(0, 0, -1)
+	if !runs[0].End.Before(yesterday) {
+		current = runs[0]
+	}
+
+	for _, run := range runs {
+		if run.Videos > best.Videos {
+			best = run
+		}
+	}
+	return current, best
+}
+
+// LongestStreak returns the run covering the most days, preferring more
+// videos and then the most recent run
+func LongestStreak(videos []models.Video) Streak {
+	var longest Streak
+	for _, run := range IndexDays(videos).runs() {
+		if run.Days > longest.Days || run.Days == longest.Days && run.Videos > longest.Videos {
+			longest = run
+		}
+	}
+	return longest
+}
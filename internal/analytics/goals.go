@@ -0,0 +1,121 @@
+package analytics
+
+import (
+	"time"
+
+	"github.com/mamuzad/vidlogd/internal/models"
+)
+
+// GoalPeriod is a goal's progress over one day, week or month
+type GoalPeriod struct {
+	Start time.Time
+	End   time.Time
+	Value float64 // videos, or hours of known duration
+}
+
+// GoalProgress tracks a goal over its most recent periods
+type GoalProgress struct {
+	Goal    models.Goal
+	Periods []GoalPeriod // oldest first, the last is the current period
+}
+
+// GoalGranularity returns the bucket size for a goal period
+func GoalGranularity(period string) Granularity {
+	switch period {
+	case "day":
+		return Day
+	case "week":
+		return Week
+	}
+	return Month
+}
+
+// TrackGoal measures goal over the n periods up to the one holding now.
+// Hours only count videos with a known duration.
+func TrackGoal(videos []models.Video, goal models.Goal, now time.Time, n int) GoalProgress {
+	g := GoalGranularity(goal.Period)
+	p := GoalProgress{Goal: goal, Periods: make([]GoalPeriod, n)}
+	if n == 0 {
+		return p
+	}
+
+	first := g.Add(g.Start(now), 1-n)
+	index := make(map[time.Time]int, n)
+	for i := range p.Periods {
+		start := g.Add(first, i)
+		p.Periods[i] = GoalPeriod{Start: start, End: g.Add(start, 1)}
+		index[start] = i
+	}
+
+	for _, video := range videos {
+		if video.LogDate.IsZero() || !goal.HasTag(video) {
+			continue
+		}
+		i, ok := index[g.Start(video.LogDate)]
+		if !ok {
+			continue
+		}
+		if goal.Metric == models.GoalHours {
+			p.Periods[i].Value += float64(video.Duration) / 3600
+		} else {
+			p.Periods[i].Value++
+		}
+	}
+	return p
+}
+
+// Met reports whether a period reached the target or stayed within the limit
+func (p GoalProgress) Met(period GoalPeriod) bool {
+	if p.Goal.IsLimit() {
+		return period.Value <= p.Goal.Target
+	}
+	return period.Value >= p.Goal.Target
+}
+
+// Current returns the period holding now
+func (p GoalProgress) Current() GoalPeriod {
+	if len(p.Periods) == 0 {
+		return GoalPeriod{}
+	}
+	return p.Periods[len(p.Periods)-1]
+}
+
+// Exceeded reports whether a limit is already broken this period
+func (p GoalProgress) Exceeded() bool {
+	return p.Goal.IsLimit() && len(p.Periods) > 0 && !p.Met(p.Current())
+}
+
+// Share is how far the current period is towards the target, 1 when there
+func (p GoalProgress) Share() float64 {
+	if p.Goal.Target <= 0 {
+		return 0
+	}
+	return p.Current().Value / p.Goal.Target
+}
+
+// Streak counts the periods met in a row. The current period only counts
+// once it is met, so an unfinished target does not break the streak.
+func (p GoalProgress) Streak() int {
+	streak := 0
+	for i := len(p.Periods) - 1; i >= 0; i-- {
+		if !p.Met(p.Periods[i]) {
+			if i == len(p.Periods)-1 && !p.Goal.IsLimit() {
+				continue
+			}
+			break
+		}
+		streak++
+	}
+	return streak
+}
+
+// Hits counts the finished periods that met the goal
+func (p GoalProgress) Hits() int {
+	hits := 0
+	for i := 0; i < len(p.Periods)-1; i++ {
+		if p.Met(p.Periods[i]) {
+			hits++
+		}
+	}
+	return hits
+}
@@ -0,0 +1,64 @@
+package analytics
+
+import (
+	"testing"
+
+	"github.com/mamuzad/vidlogd/internal/models"
+)
+
+func TestTrackGoal(t *testing.T) {
+	// 2025-05-14 is a wednesday
+	now := at(2025, 5, 14, 18)
+	videos := []models.Video{
+		{LogDate: at(2025, 5, 14, 9), Tags: []string{"learning"}, Duration: 3600},
+		{LogDate: at(2025, 5, 12, 20), Tags: []string{"Learning"}, Duration: 1800},
+		{LogDate: at(2025, 5, 11, 20), Tags: []string{"learning"}},
+		{LogDate: at(2025, 5, 6, 8), Tags: []string{"music"}, Duration: 600},
+		{LogDate: at(2025, 4, 1, 8), Tags: []string{"learning"}},
+	}
+
+	weekly := TrackGoal(videos, models.Goal{Kind: models.GoalAtLeast, Target: 2, Metric: models.GoalVideos, Period: "week", Tag: "learning"}, now, 3)
+	want := []float64{0, 1, 2}
+	for i, p := range weekly.Periods {
+		if p.Value != want[i] {
+			t.Errorf("week %d: got %v, want %v", i, p.Value, want[i])
+		}
+	}
+	if !weekly.Periods[2].Start.Equal(at(2025, 5, 12, 0)) {
+		t.Errorf("current week starts %v", weekly.Periods[2].Start)
+	}
+	if weekly.Share() != 1 || weekly.Exceeded() || weekly.Streak() != 1 || weekly.Hits() != 0 {
+		t.Errorf("share %v, exceeded %v, streak %d, hits %d", weekly.Share(), weekly.Exceeded(), weekly.Streak(), weekly.Hits())
+	}
+
+	daily := TrackGoal(videos, models.Goal{Kind: models.GoalAtMost, Target: 0.5, Metric: models.GoalHours, Period: "day"}, now, 3)
+	if got := daily.Current().Value; got != 1 {
+		t.Errorf("hours today = %v, want 1", got)
+	}
+	if !daily.Exceeded() {
+		t.Error("expected the daily limit to be exceeded")
+	}
+	// the two days before stayed within the limit, today broke the streak
+	if daily.Streak() != 0 || daily.Hits() != 2 {
+		t.Errorf("streak %d, hits %d", daily.Streak(), daily.Hits())
+	}
+}
+
+func TestGoalStreak(t *testing.T) {
+	goal := models.Goal{Kind: models.GoalAtLeast, Target: 1, Metric: models.GoalVideos, Period: "day"}
+	now := at(2025, 5, 14, 18)
+
+	// met the three days before today, nothing yet today
+	videos := logged(at(2025, 5, 11, 9), at(2025, 5, 12, 9), at(2025, 5, 13, 9))
+	if got := TrackGoal(videos, goal, now, 5).Streak(); got != 3 {
+		t.Errorf("streak = %d, want 3", got)
+	}
+	// today counts once met
+	videos = append(videos, logged(at(2025, 5, 14, 9))...)
+	if got := TrackGoal(videos, goal, now, 5).Streak(); got != 4 {
+		t.Errorf("streak = %d, want 4", got)
+	}
+	if got := TrackGoal(videos, goal, now, 0); len(got.Periods) != 0 || got.Streak() != 0 {
+		t.Errorf("expected no periods, got %+v", got)
+	}
+}
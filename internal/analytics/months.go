@@ -0,0 +1,48 @@
+package analytics
+
+import (
+	"sort"
+	"time"
+
+	"github.com/mamuzad/vidlogd/internal/models"
+)
+
+// MonthCount is the number of videos logged in a calendar month
+type MonthCount struct {
+	Month time.Time // first day of the month, local time
+	Count int
+}
+
+// MonthOf returns the first day of t's month in local time
+func MonthOf(t time.Time) time.Time {
+	y, m, _ := t.In(time.Local).Date()
+	return time.Date(y, m, 1, 0, 0, 0, 0, time.Local)
+}
+
+// Months counts videos per month, most recent first, skipping empty months
+func Months(videos []models.Video) []MonthCount {
+	counts := make(map[time.Time]int)
+	for _, video := range videos {
+		if !video.LogDate.IsZero() {
+			counts[MonthOf(video.LogDate)]++
+		}
+	}
+
+	months := make([]MonthCount, 0, len(counts))
+	for month, count := range counts {
+		months = append(months, MonthCount{Month: month, Count: count})
+	}
+	sort.Slice(months, func(i, j int) bool { return months[i].Month.After(months[j].Month) })
+	return months
+}
+
+// BusiestMonth returns the month with the most videos, the earliest on ties
+func BusiestMonth(videos []models.Video) MonthCount {
+	var busiest MonthCount
+	for _, month := range Months(videos) {
+		if month.Count >= busiest.Count {
+			busiest = month
+		}
+	}
+	return busiest
+}
@@ -0,0 +1,73 @@
+package analytics
+
+import (
+	"time"
+
+	"github.com/mamuzad/vidlogd/internal/models"
+)
+
+// work hours used by WorkHoursShare, local time
+const (
+	WorkStart = 9
+	WorkEnd   = 17
+)
+
+// TimeSlot counts the videos logged in one hour of the day or one weekday
+type TimeSlot struct {
+	Count     int
+	Rated     int
+	AvgRating float64 // over rated videos, 0 when none are rated
+}
+
+// ByHour buckets videos by the local hour they were logged, skipping videos
+// without a log date
+func ByHour(videos []models.Video) [24]TimeSlot {
+	var slots [24]TimeSlot
+	for _, video := range videos {
+		if !video.LogDate.IsZero() {
+			slots[video.LogDate.In(time.Local).Hour()].add(video)
+		}
+	}
+	return slots
+}
+
+// ByWeekday buckets videos by the local weekday they were logged, indexed
+// by time.Weekday
+func ByWeekday(videos []models.Video) [7]TimeSlot {
+	var slots [7]TimeSlot
+	for _, video := range videos {
+		if !video.LogDate.IsZero() {
+			slots[video.LogDate.In(time.Local).Weekday()].add(video)
+		}
+	}
+	return slots
+}
+
+// WorkHoursShare is the fraction of dated videos logged monday to friday
+// between WorkStart and WorkEnd
+func WorkHoursShare(videos []models.Video) float64 {
+	dated, during := 0, 0
+	for _, video := range videos {
+		if video.LogDate.IsZero() {
+			continue
+		}
+		dated++
+		t := video.LogDate.In(time.Local)
+		weekday := t.Weekday() != time.Saturday && t.Weekday() != time.Sunday
+		if weekday && t.Hour() >= WorkStart && t.Hour() < WorkEnd {
+			during++
+		}
+	}
+	if dated == 0 {
+		return 0
+	}
+	return float64(during) / float64(dated)
+}
+
+func (s *TimeSlot) add(video models.Video) {
+	s.Count++
+	if video.Rating > 0 {
+		s.Rated++
+		s.AvgRating += (video.Rating - s.AvgRating) / float64(s.Rated)
+	}
+}
@@ -0,0 +1,73 @@
+package analytics
+
+import (
+	"testing"
+	"time"
+
+	"github.com/mamuzad/vidlogd/internal/models"
+)
+
+func TestByHour(t *testing.T) {
+	videos := []models.Video{
+		{LogDate: at(2025, 3, 3, 9), Rating: 4},
+		{LogDate: at(2025, 3, 4, 9), Rating: 3},
+		{LogDate: at(2025, 3, 4, 9)},
+		{LogDate: at(2025, 3, 8, 23), Rating: 5},
+		{Rating: 1}, // undated
+	}
+
+	slots := ByHour(videos)
+
+	tests := []struct {
+		hour int
+		want TimeSlot
+	}{
+		{9, TimeSlot{Count: 3, Rated: 2, AvgRating: 3.5}},
+		{23, TimeSlot{Count: 1, Rated: 1, AvgRating: 5}},
+		{0, TimeSlot{}},
+	}
+	for _, tt := range tests {
+		if slots[tt.hour] != tt.want {
+			t.Errorf("hour %d: got %+v, want %+v", tt.hour, slots[tt.hour], tt.want)
+		}
+	}
+}
+
+func TestByWeekday(t *testing.T) {
+	// 2025-03-03 is a monday
+	videos := logged(at(2025, 3, 3, 9), at(2025, 3, 10, 22), at(2025, 3, 9, 12))
+	videos[0].Rating = 2
+
+	slots := ByWeekday(videos)
+
+	if got := slots[time.Monday]; got != (TimeSlot{Count: 2, Rated: 1, AvgRating: 2}) {
+		t.Errorf("monday = %+v", got)
+	}
+	if got := slots[time.Sunday].Count; got != 1 {
+		t.Errorf("sunday = %d", got)
+	}
+	if got := slots[time.Friday].Count; got != 0 {
+		t.Errorf("friday = %d", got)
+	}
+}
+
+func TestWorkHoursShare(t *testing.T) {
+	tests := []struct {
+		name   string
+		videos []models.Video
+		want   float64
+	}{
+		{"empty", nil, 0},
+		{"undated only", []models.Video{{}}, 0},
+		{"all during work", logged(at(2025, 3, 3, 9), at(2025, 3, 7, 16)), 1},
+		{"end of day excluded", logged(at(2025, 3, 3, 17), at(2025, 3, 3, 8)), 0},
+		{"weekend excluded", logged(at(2025, 3, 8, 10), at(2025, 3, 9, 10), at(2025, 3, 10, 10), at(2025, 3, 11, 20)), 0.25},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := WorkHoursShare(tt.videos); got != tt.want {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
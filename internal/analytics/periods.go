@@ -0,0 +1,129 @@
+package analytics
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/mamuzad/vidlogd/internal/models"
+)
+
+// Granularity is the size of the buckets in an activity series
+type Granularity int
+
+const (
+	Day Granularity = iota
+	Week
+	Month
+	Quarter
+	Year
+)
+
+// Granularities lists every granularity, finest first
+var Granularities = []Granularity{Day, Week, Month, Quarter, Year}
+
+func (g Granularity) String() string {
+	switch g {
+	case Day:
+		return "day"
+	case Week:
+		return "week"
+	case Quarter:
+		return "quarter"
+	case Year:
+		return "year"
+	}
+	return "month"
+}
+
+// Start returns the start of the bucket holding t in local time. Weeks start
+// on monday.
+func (g Granularity) Start(t time.Time) time.Time {
+	t = t.In(time.Local)
+	y, m, d := t.Date()
+	switch g {
+	case Day:
+		return time.Date(y, m, d, 0, 0, 0, 0, time.Local)
+	case Week:
+		monday := d - (int(t.Weekday())+6)%7
+		return time.Date(y, m, monday, 0, 0, 0, 0, time.Local)
+	case Quarter:
+		return time.Date(y, m-(m-1)%3, 1, 0, 0, 0, 0, time.Local)
+	case Year:
+		return time.Date(y, time.January, 1, 0, 0, 0, 0, time.Local)
+	}
+	return time.Date(y, m, 1, 0, 0, 0, 0, time.Local)
+}
+
+// Add moves a bucket start n buckets forward, or back when n is negative
+func (g Granularity) Add(start time.Time, n int) time.Time {
+	switch g {
+	case Day:
+		return start.AddDate(0, 0, n)
+	case Week:
+		return start.AddDate(0, 0, 7*n)
+	case Quarter:
+		return start.AddDate(0, 3*n, 0)
+	case Year:
+		return start.AddDate(n, 0, 0)
+	}
+	return start.AddDate(0, n, 0)
+}
+
+// Label names the bucket starting at start in at most five columns
+func (g Granularity) Label(start time.Time) string {
+	switch g {
+	case Day, Week:
+		return start.Format("01-02")
+	case Quarter:
+		return fmt.Sprintf("Q%d %s", (int(start.Month())+2)/3, start.Format("06"))
+	case Year:
+		return start.Format("2006")
+	}
+	return start.Format(models.MonthFormat)
+}
+
+// Bucket counts the videos logged from Start up to, not including, End
+type Bucket struct {
+	Start time.Time
+	End   time.Time
+	Count int
+}
+
+// Buckets returns n buckets ending with the one holding last, oldest first,
+// including buckets without videos
+func Buckets(videos []models.Video, g Granularity, last time.Time, n int) []Bucket {
+	buckets := make([]Bucket, n)
+	first := g.Add(g.Start(last), 1-n)
+	for i := range buckets {
+		start := g.Add(first, i)
+		buckets[i] = Bucket{Start: start, End: g.Add(start, 1)}
+	}
+	if n == 0 {
+		return buckets
+	}
+
+	index := make(map[time.Time]int, n)
+	for i, b := range buckets {
+		index[b.Start] = i
+	}
+	for _, video := range videos {
+		if video.LogDate.IsZero() {
+			continue
+		}
+		if i, ok := index[g.Start(video.LogDate)]; ok {
+			buckets[i].Count++
+		}
+	}
+	return buckets
+}
+
+// Between returns the videos logged from from up to, not including, to
+func Between(videos []models.Video, from, to time.Time) []models.Video {
+	var in []models.Video
+	for _, video := range videos {
+		if !video.LogDate.IsZero() && !video.LogDate.Before(from) && video.LogDate.Before(to) {
+			in = append(in, video)
+		}
+	}
+	return in
+}
@@ -0,0 +1,97 @@
+package analytics
+
+import (
+	"testing"
+	"time"
+)
+
+func TestGranularityStart(t *testing.T) {
+	// 2025-05-14 is a wednesday
+	ts := at(2025, 5, 14, 18)
+
+	tests := []struct {
+		g     Granularity
+		start time.Time
+		next  time.Time
+		label string
+	}{
+		{Day, at(2025, 5, 14, 0), at(2025, 5, 15, 0), "05-14"},
+		{Week, at(2025, 5, 12, 0), at(2025, 5, 19, 0), "05-12"},
+		{Month, at(2025, 5, 1, 0), at(2025, 6, 1, 0), "05/25"},
+		{Quarter, at(2025, 4, 1, 0), at(2025, 7, 1, 0), "Q2 25"},
+		{Year, at(2025, 1, 1, 0), at(2026, 1, 1, 0), "2025"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.g.String(), func(t *testing.T) {
+			start := tt.g.Start(ts)
+			if !start.Equal(tt.start) {
+				t.Errorf("start = %v, want %v", start, tt.start)
+			}
+			if next := tt.g.Add(start, 1); !next.Equal(tt.next) {
+				t.Errorf("next = %v, want %v", next, tt.next)
+			}
+			if got := tt.g.Label(start); got != tt.label {
+				t.Errorf("label = %q, want %q", got, tt.label)
+			}
+		})
+	}
+}
+
+func TestWeekStartsOnMonday(t *testing.T) {
+	// sunday belongs to the week that started six days before
+	if got := Week.Start(at(2025, 5, 18, 23)); !got.Equal(at(2025, 5, 12, 0)) {
+		t.Errorf("sunday week start = %v", got)
+	}
+	// across a month boundary
+	if got := Week.Start(at(2025, 6, 1, 12)); !got.Equal(at(2025, 5, 26, 0)) {
+		t.Errorf("week start = %v", got)
+	}
+}
+
+func TestBuckets(t *testing.T) {
+	videos := logged(at(2024, 12, 31, 23), at(2025, 1, 1, 0), at(2025, 3, 31, 12), at(2025, 4, 1, 0), at(2023, 6, 1, 12))
+
+	tests := []struct {
+		name   string
+		g      Granularity
+		last   time.Time
+		n      int
+		counts []int
+		first  time.Time
+	}{
+		{"quarters", Quarter, at(2025, 5, 1, 0), 3, []int{1, 2, 1}, at(2024, 10, 1, 0)},
+		{"years", Year, at(2025, 1, 1, 0), 3, []int{1, 1, 3}, at(2023, 1, 1, 0)},
+		{"days around new year", Day, at(2025, 1, 1, 12), 2, []int{1, 1}, at(2024, 12, 31, 0)},
+		{"empty window", Week, at(2026, 1, 1, 0), 2, []int{0, 0}, at(2025, 12, 22, 0)},
+		{"no buckets", Month, at(2025, 1, 1, 0), 0, []int{}, time.Time{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			buckets := Buckets(videos, tt.g, tt.last, tt.n)
+			if len(buckets) != len(tt.counts) {
+				t.Fatalf("got %d buckets", len(buckets))
+			}
+			for i, b := range buckets {
+				if b.Count != tt.counts[i] {
+					t.Errorf("bucket %s: got %d, want %d", tt.g.Label(b.Start), b.Count, tt.counts[i])
+				}
+				if i > 0 && !b.Start.Equal(buckets[i-1].End) {
+					t.Errorf("bucket %d does not follow on", i)
+				}
+			}
+			if len(buckets) > 0 && !buckets[0].Start.Equal(tt.first) {
+				t.Errorf("first = %v, want %v", buckets[0].Start, tt.first)
+			}
+		})
+	}
+}
+
+func TestBetween(t *testing.T) {
+	videos := logged(at(2025, 1, 1, 0), at(2025, 1, 31, 23), at(2025, 2, 1, 0))
+	if got := Between(videos, at(2025, 1, 1, 0), at(2025, 2, 1, 0)); len(got) != 2 {
+		t.Errorf("got %d videos, want 2", len(got))
+	}
+	if got := Between(videos, at(2026, 1, 1, 0), at(2027, 1, 1, 0)); len(got) != 0 {
+		t.Errorf("got %d videos, want 0", len(got))
+	}
+}
@@ -0,0 +1,197 @@
+package analytics
+
+import (
+	"sort"
+	"time"
+
+	"github.com/mamuzad/vidlogd/internal/models"
+)
+
+// a channel is declining when its recent ratings average this much lower
+// than its earlier ones, over at least MinTrendRatings ratings
+const (
+	DeclineThreshold = -0.5
+	MinTrendRatings  = 4
+)
+
+// RatingPoint is one rated video and the moving average ending with it
+type RatingPoint struct {
+	Date    time.Time
+	Rating  float64
+	Average float64
+}
+
+// RatingTrend returns the rated, dated videos oldest first with the average
+// of the last window ratings at each point
+func RatingTrend(videos []models.Video, window int) []RatingPoint {
+	rated := ratedByDate(videos)
+	window = max(1, window)
+
+	points := make([]RatingPoint, len(rated))
+	var sum float64
+	for i, video := range rated {
+		sum += video.Rating
+		if i >= window {
+			sum -= rated[i-window].Rating
+		}
+		points[i] = RatingPoint{Date: video.LogDate, Rating: video.Rating, Average: sum / float64(min(i+1, window))}
+	}
+	return points
+}
+
+// RatingStat is the number of rated videos and their average
+type RatingStat struct {
+	Count int
+	Avg   float64
+}
+
+// RewatchRatings compares first watches with rewatches
+type RewatchRatings struct {
+	FirstWatch RatingStat
+	Rewatch    RatingStat
+}
+
+// CompareRewatches averages the ratings of first watches and rewatches
+func CompareRewatches(videos []models.Video) RewatchRatings {
+	var r RewatchRatings
+	for _, video := range videos {
+		if video.Rating <= 0 {
+			continue
+		}
+		stat := &r.FirstWatch
+		if video.Rewatched {
+			stat = &r.Rewatch
+		}
+		stat.Count++
+		stat.Avg += (video.Rating - stat.Avg) / float64(stat.Count)
+	}
+	return r
+}
+
+// MonthRating is the average rating given in a month. Harshness is how far
+// below the overall average it is, so harsher months are positive.
+type MonthRating struct {
+	Month     time.Time
+	Rated     int
+	AvgRating float64
+	Harshness float64
+}
+
+// MonthlyHarshness rates each month with rated videos, oldest first
+func MonthlyHarshness(videos []models.Video) []MonthRating {
+	rated := ratedByDate(videos)
+	if len(rated) == 0 {
+		return nil
+	}
+
+	var months []MonthRating
+	var sum float64
+	for _, video := range rated {
+		sum += video.Rating
+		month := MonthOf(video.LogDate)
+		if len(months) == 0 || !months[len(months)-1].Month.Equal(month) {
+			months = append(months, MonthRating{Month: month})
+		}
+		m := &months[len(months)-1]
+		m.Rated++
+		m.AvgRating += (video.Rating - m.AvgRating) / float64(m.Rated)
+	}
+
+	overall := sum / float64(len(rated))
+	for i := range months {
+		months[i].Harshness = overall - months[i].AvgRating
+	}
+	return months
+}
+
+// ChannelTrend is a channel's ratings in the order they were logged, with
+// the averages of the earlier and the more recent half
+type ChannelTrend struct {
+	Channel string
+	Ratings []float64
+	Earlier float64
+	Recent  float64
+}
+
+// Drift is how much the recent half differs from the earlier half
+func (t ChannelTrend) Drift() float64 {
+	return t.Recent - t.Earlier
+}
+
+// Declining reports whether the channel has enough ratings and they have
+// dropped by at least DeclineThreshold
+func (t ChannelTrend) Declining() bool {
+	return len(t.Ratings) >= MinTrendRatings && t.Drift() <= DeclineThreshold
+}
+
+// ChannelTrends returns the channels with at least minRated dated ratings,
+// most rated first, then by name
+func ChannelTrends(videos []models.Video, minRated int) []ChannelTrend {
+	index := make(map[string]int)
+	var trends []ChannelTrend
+	for _, video := range ratedByDate(videos) {
+		name := ChannelName(video)
+		i, ok := index[name]
+		if !ok {
+			i = len(trends)
+			index[name] = i
+			trends = append(trends, ChannelTrend{Channel: name})
+		}
+		trends[i].Ratings = append(trends[i].Ratings, video.Rating)
+	}
+
+	kept := trends[:0]
+	for _, t := range trends {
+		if len(t.Ratings) < max(1, minRated) {
+			continue
+		}
+		// the middle rating of an odd run belongs to neither half
+		half := len(t.Ratings) / 2
+		t.Earlier = mean(t.Ratings[:max(1, half)])
+		t.Recent = mean(t.Ratings[len(t.Ratings)-max(1, half):])
+		kept = append(kept, t)
+	}
+
+	sort.Slice(kept, func(i, j int) bool {
+		if len(kept[i].Ratings) != len(kept[j].Ratings) {
+			return len(kept[i].Ratings) > len(kept[j].Ratings)
+		}
+		return kept[i].Channel < kept[j].Channel
+	})
+	return kept
+}
+
+// Declining returns the declining trends, steepest drop first
+func Declining(trends []ChannelTrend) []ChannelTrend {
+	var declining []ChannelTrend
+	for _, t := range trends {
+		if t.Declining() {
+			declining = append(declining, t)
+		}
+	}
+	sort.SliceStable(declining, func(i, j int) bool { return declining[i].Drift() < declining[j].Drift() })
+	return declining
+}
+
+// ratedByDate returns the rated videos with a log date, oldest first
+func ratedByDate(videos []models.Video) []models.Video {
+	var rated []models.Video
+	for _, video := range videos {
+		if video.Rating > 0 && !video.LogDate.IsZero() {
+			rated = append(rated, video)
+		}
+	}
+	sort.SliceStable(rated, func(i, j int) bool { return rated[i].LogDate.Before(rated[j].LogDate) })
+	return rated
+}
+
+func mean(values []float64) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+	var sum float64
+	for _, v := range values {
+		sum += v
+	}
+	return sum / float64(len(values))
+}
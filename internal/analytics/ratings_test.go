@@ -0,0 +1,126 @@
+package analytics
+
+import (
+	"testing"
+
+	"github.com/mamuzad/vidlogd/internal/models"
+)
+
+func rated(channel string, ratings ...float64) []models.Video {
+	videos := make([]models.Video, len(ratings))
+	for i, r := range ratings {
+		videos[i] = models.Video{Channel: channel, Rating: r, LogDate: at(2025, 1, 1+i, 12)}
+	}
+	return videos
+}
+
+func TestRatingTrend(t *testing.T) {
+	videos := rated("", 2, 4, 0, 3, 5)
+	videos = append(videos, models.Video{Rating: 1}) // undated
+	videos[0], videos[4] = videos[4], videos[0]      // input order should not matter
+
+	points := RatingTrend(videos, 2)
+
+	want := []float64{2, 3, 3.5, 4}
+	if len(points) != len(want) {
+		t.Fatalf("got %d points, want %d", len(points), len(want))
+	}
+	for i, p := range points {
+		if p.Average != want[i] {
+			t.Errorf("point %d: average %v, want %v", i, p.Average, want[i])
+		}
+	}
+	if points[3].Rating != 5 {
+		t.Errorf("last rating = %v", points[3].Rating)
+	}
+	if got := RatingTrend(videos, 0); got[1].Average != 4 {
+		t.Errorf("window 0 should average a single rating, got %v", got[1].Average)
+	}
+}
+
+func TestCompareRewatches(t *testing.T) {
+	videos := []models.Video{
+		{Rating: 3}, {Rating: 4}, {Rating: 0},
+		{Rating: 5, Rewatched: true}, {Rewatched: true},
+	}
+	got := CompareRewatches(videos)
+	if got.FirstWatch != (RatingStat{Count: 2, Avg: 3.5}) || got.Rewatch != (RatingStat{Count: 1, Avg: 5}) {
+		t.Errorf("got %+v", got)
+	}
+	if got := CompareRewatches(nil); got != (RewatchRatings{}) {
+		t.Errorf("empty = %+v", got)
+	}
+}
+
+func TestMonthlyHarshness(t *testing.T) {
+	videos := []models.Video{
+		{Rating: 2, LogDate: at(2025, 2, 3, 12)},
+		{Rating: 4, LogDate: at(2025, 1, 5, 12)},
+		{Rating: 5, LogDate: at(2025, 1, 20, 12)},
+		{Rating: 3, LogDate: at(2025, 4, 1, 12)},
+		{LogDate: at(2025, 3, 1, 12)},
+	}
+
+	months := MonthlyHarshness(videos)
+
+	tests := []struct {
+		month     int
+		avg       float64
+		harshness float64
+	}{
+		{1, 4.5, -1},
+		{2, 2, 1.5},
+		{4, 3, 0.5},
+	}
+	if len(months) != len(tests) {
+		t.Fatalf("got %+v", months)
+	}
+	for i, tt := range tests {
+		m := months[i]
+		if int(m.Month.Month()) != tt.month || m.AvgRating != tt.avg || m.Harshness != tt.harshness {
+			t.Errorf("month %d: got %+v", tt.month, m)
+		}
+	}
+	if MonthlyHarshness(nil) != nil {
+		t.Error("expected no months without ratings")
+	}
+}
+
+func TestChannelTrends(t *testing.T) {
+	var videos []models.Video
+	videos = append(videos, rated("falling", 5, 5, 4, 3, 3)...)
+	videos = append(videos, rated("steady", 4, 4, 4, 4, 4, 4)...)
+	videos = append(videos, rated("short", 5, 1)...)
+	videos = append(videos, rated("once", 3)...)
+
+	trends := ChannelTrends(videos, 2)
+
+	names := make([]string, len(trends))
+	for i, tr := range trends {
+		names[i] = tr.Channel
+	}
+	if len(trends) != 3 || names[0] != "steady" || names[1] != "falling" || names[2] != "short" {
+		t.Fatalf("trends = %v", names)
+	}
+
+	tests := []struct {
+		trend     ChannelTrend
+		earlier   float64
+		recent    float64
+		declining bool
+	}{
+		{trends[0], 4, 4, false},
+		{trends[1], 5, 3, true},
+		{trends[2], 5, 1, false}, // too few ratings to call
+	}
+	for _, tt := range tests {
+		if tt.trend.Earlier != tt.earlier || tt.trend.Recent != tt.recent || tt.trend.Declining() != tt.declining {
+			t.Errorf("%s: earlier %v recent %v declining %v", tt.trend.Channel, tt.trend.Earlier, tt.trend.Recent, tt.trend.Declining())
+		}
+	}
+
+	declining := Declining(trends)
+	if len(declining) != 1 || declining[0].Channel != "falling" {
+		t.Errorf("declining = %+v", declining)
+	}
+}
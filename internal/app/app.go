@@ -4,8 +4,11 @@ import (
 	"fmt"
 	"reflect"
 
+	"github.com/charmbracelet/bubbles/key"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/mamuzad/vidlogd/internal/models"
+	"github.com/mamuzad/vidlogd/internal/storage"
 	"github.com/mamuzad/vidlogd/internal/ui"
 	"github.com/mamuzad/vidlogd/internal/ui/views"
 )
@@ -21,6 +24,18 @@ type Model struct {
 	logDetails *views.LogDetailsModel
 	settings   *views.SettingsModel
 	stats      *views.StatsModel
+	transfer   *views.TransferModel
+	channel    *views.ChannelModel
+	dedupe     *views.DedupeModel
+	doctor     *views.DoctorModel
+	errorLog   *views.ErrorLogModel
+
+	// status line toasts and session error count
+	notifier ui.Notifier
+	// videos in the active profile, -1 until counted
+	total int
+	// keybindings or theme problem found at startup, reported once running
+	startErr error
 
 	// Terminal dimensions for centering
 	width  int
@@ -28,7 +43,22 @@ type Model struct {
 }
 
 func (m Model) Init() tea.Cmd {
-	return tea.SetWindowTitle("vidlogd")
+	return tea.Batch(tea.SetWindowTitle("vidlogd"), countVideos, ui.ReportError("load settings", m.startErr))
+}
+
+// videoCountMsg carries the number of videos in the active profile
+type videoCountMsg struct {
+	total int
+}
+
+// countVideos recounts the library for the status line, -1 when it cannot
+// be read
+func countVideos() tea.Msg {
+	videos, err := models.LoadVideos()
+	if err != nil {
+		return videoCountMsg{total: -1}
+	}
+	return videoCountMsg{total: len(videos)}
 }
 
 func routeEqual(a, b ui.Route) bool {
@@ -53,6 +83,7 @@ func (m Model) applyRoute(r ui.Route) (Model, tea.Cmd) {
 	case ui.LogListView:
 		if m.logList == nil {
 			ll := views.NewLogListModel()
+			ll.SetSize(m.width, m.height)
 			m.logList = &ll
 		}
 		return m, m.logList.Init()
@@ -104,9 +135,37 @@ func (m Model) applyRoute(r ui.Route) (Model, tea.Cmd) {
 	case ui.StatsView:
 		if m.stats == nil {
 			s := views.NewStatsModel()
+			s.SetSize(m.width, m.height)
 			m.stats = &s
 		}
 		return m, m.stats.Init()
+	case ui.TransferView:
+		if m.transfer == nil {
+			t := views.NewTransferModel()
+			m.transfer = &t
+		}
+		return m, m.transfer.Init()
+	case ui.ChannelView:
+		route, _ := r.State.(ui.ChannelRouteState)
+		if m.channel == nil || m.channel.Route() != route {
+			c := views.NewChannelModel(route)
+			m.channel = &c
+		}
+		return m, m.channel.Init()
+	case ui.DedupeView:
+		// always rescan, the log may have changed since the last visit
+		d := views.NewDedupeModel()
+		m.dedupe = &d
+		return m, m.dedupe.Init()
+	case ui.DoctorView:
+		// rescan, problems may have been fixed in the edit form
+		d := views.NewDoctorModel()
+		m.doctor = &d
+		return m, m.doctor.Init()
+	case ui.ErrorLogView:
+		e := views.NewErrorLogModel()
+		m.errorLog = &e
+		return m, m.errorLog.Init()
 	default:
 		return m, nil
 	}
@@ -135,18 +194,44 @@ func (m Model) back() (Model, tea.Cmd) {
 func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	var cmd tea.Cmd
 
+	if handled, cmd := m.notifier.Update(msg); handled {
+		return m, cmd
+	}
+
 	switch msg := msg.(type) {
 	case tea.WindowSizeMsg:
 		m.width = msg.Width
 		m.height = msg.Height
+		if m.logList != nil {
+			m.logList.SetSize(m.width, m.height)
+		}
+		if m.stats != nil {
+			m.stats.SetSize(m.width, m.height)
+		}
 		return m, nil
 
 	case tea.KeyMsg:
-		switch msg.String() {
-		case "ctrl+c":
+		switch {
+		case msg.String() == "ctrl+c", key.Matches(msg, ui.GlobalKeyMap.Exit):
+			// ctrl+c always quits, even when exit is rebound
 			return m, tea.Quit
+		case key.Matches(msg, ui.GlobalKeyMap.ErrorLog) && m.currentView != ui.ErrorLogView:
+			return m.navigateTo(ui.Route{View: ui.ErrorLogView})
 		}
 
+	case ui.ErrorMsg:
+		return m, m.notifier.Error(msg)
+
+	case ui.NotifyMsg:
+		return m, m.notifier.Notify(msg)
+
+	case ui.LibraryChangedMsg:
+		return m, countVideos
+
+	case videoCountMsg:
+		m.total = msg.total
+		return m, nil
+
 	case ui.ClearFormMsg:
 		// clear the form by creating a new empty one
 		if m.logVideo == nil {
@@ -160,6 +245,26 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case ui.BackMsg:
 		return m.back()
 
+	case ui.ProfileSwitchedMsg:
+		// cached views hold the previous profile's data
+		m.history = []ui.Route{}
+		m.logList = nil
+		m.logDetails = nil
+		lv := views.NewLogVideoModel("")
+		m.logVideo = &lv
+		s := views.NewSettingsModel(0)
+		m.settings = &s
+		st := views.NewStatsModel()
+		st.SetSize(m.width, m.height)
+		m.stats = &st
+		m.transfer = nil
+		m.channel = nil
+		m.dedupe = nil
+		m.doctor = nil
+		m.errorLog = nil
+		m, cmd = m.applyRoute(ui.Route{View: ui.MainMenuView})
+		return m, tea.Batch(cmd, countVideos)
+
 	case ui.NavigateMsg:
 		return m.navigateTo(ui.Route(msg))
 
@@ -190,6 +295,21 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		if m.stats != nil {
 			refresh(m.stats)
 		}
+		if m.transfer != nil {
+			refresh(m.transfer)
+		}
+		if m.channel != nil {
+			refresh(m.channel)
+		}
+		if m.dedupe != nil {
+			refresh(m.dedupe)
+		}
+		if m.doctor != nil {
+			refresh(m.doctor)
+		}
+		if m.errorLog != nil {
+			refresh(m.errorLog)
+		}
 		return m, nil
 	}
 
@@ -206,6 +326,16 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		cmd = updatePtr(&m.settings, msg, func() views.SettingsModel { return views.NewSettingsModel(0) })
 	case ui.StatsView:
 		cmd = updatePtr(&m.stats, msg, views.NewStatsModel)
+	case ui.TransferView:
+		cmd = updatePtr(&m.transfer, msg, views.NewTransferModel)
+	case ui.ChannelView:
+		cmd = updatePtr(&m.channel, msg, func() views.ChannelModel { return views.NewChannelModel(ui.ChannelRouteState{}) })
+	case ui.DedupeView:
+		cmd = updatePtr(&m.dedupe, msg, views.NewDedupeModel)
+	case ui.DoctorView:
+		cmd = updatePtr(&m.doctor, msg, views.NewDoctorModel)
+	case ui.ErrorLogView:
+		cmd = updatePtr(&m.errorLog, msg, views.NewErrorLogModel)
 	}
 
 	return m, cmd
@@ -240,22 +370,75 @@ func (m Model) View() string {
 		if m.stats != nil {
 			content = m.stats.View()
 		}
+	case ui.TransferView:
+		if m.transfer != nil {
+			content = m.transfer.View()
+		}
+	case ui.ChannelView:
+		if m.channel != nil {
+			content = m.channel.View()
+		}
+	case ui.DedupeView:
+		if m.dedupe != nil {
+			content = m.dedupe.View()
+		}
+	case ui.DoctorView:
+		if m.doctor != nil {
+			content = m.doctor.View()
+		}
+	case ui.ErrorLogView:
+		if m.errorLog != nil {
+			content = m.errorLog.View()
+		}
+	}
+
+	// the linear layout is not boxed, so the status line can use the window
+	statusWidth := lipgloss.Width(content)
+	if ui.Linear && m.width > 0 {
+		statusWidth = m.width
 	}
+	content += "\n\n" + m.notifier.View(statusWidth, m.status())
 
 	title := ui.CenterHorizontally(ui.TitleStyle.Render("vidlogd"), lipgloss.Width(content))
 	// wrap content in popup
 	styledContent := ui.PopupStyle.Render(title + "\n" + content)
-	// center the popup
-	if m.width > 0 && m.height > 0 {
+	// center the popup, the linear layout starts at the top left
+	if m.width > 0 && m.height > 0 && !ui.Linear {
 		return ui.CenterBoth("\n\n"+styledContent, m.width, m.height)
 	}
 
 	return styledContent
 }
 
+// status collects the status line for the current view
+func (m Model) status() ui.Status {
+	status := ui.Status{View: m.currentView, Profile: storage.Profile(), Total: m.total}
+
+	switch m.currentView {
+	case ui.LogListView:
+		if m.logList != nil {
+			status.ViewStatus = m.logList.Status()
+		}
+	case ui.StatsView:
+		if m.stats != nil {
+			status.ViewStatus = m.stats.Status()
+		}
+	case ui.LogVideoView:
+		if m.logVideo != nil {
+			status.ViewStatus = m.logVideo.Status()
+		}
+	}
+
+	// a new log's draft survives navigating away
+	if m.logVideo != nil && m.logVideo.VideoID() == "" && m.logVideo.Status().Unsaved {
+		status.Unsaved = true
+	}
+	return status
+}
+
 func Run() error {
 	// load settings first
-	views.LoadAndApplySettings()
+	settingsErr := views.LoadAndApplySettings()
 
 	m := Model{
 		currentView: ui.MainMenuView,
@@ -263,6 +446,8 @@ func Run() error {
 			View: ui.MainMenuView,
 		},
 		history:  []ui.Route{},
+		total:    -1,
+		startErr: settingsErr,
 		mainMenu: func() *views.MainMenuModel { mm := views.NewMainMenuModel(); return &mm }(),
 		logVideo: func() *views.LogVideoModel { lv := views.NewLogVideoModel(""); return &lv }(),
 		settings: func() *views.SettingsModel { s := views.NewSettingsModel(0); return &s }(),
@@ -0,0 +1,111 @@
+package cli
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+
+	"github.com/mamuzad/vidlogd/internal/app"
+	"github.com/mamuzad/vidlogd/internal/storage"
+)
+
+type command struct {
+	name  string
+	usage string
+	run   func(args []string, out io.Writer) error
+}
+
+const dateFormatUsage = "date format: datetime, iso, rfc3339 or a Go layout like 01/02/2006"
+
+// subcommands, in the order shown by usage
+var commands = []command{
+	{name: "export", usage: "export [--csv | --md | --html] [-o file|dir] [--date-format fmt] [--title t] [--from date] [--to date]", run: runExport},
+	{name: "import", usage: "import file.csv [--map col=header,...] [--date-format fmt] [--dry-run]", run: runImport},
+	{name: "takeout", usage: "takeout watch-history.json|.html [--from date] [--to date] [--include-ads] [--exclude-shorts] [--min-repeat n] [--dry-run]", run: runTakeout},
+	{name: "search", usage: "search [--limit n] [--url] [--] query...", run: runSearch},
+	{name: "dedupe", usage: "dedupe [--apply]", run: runDedupe},
+	{name: "doctor", usage: "doctor [--fix | -i]", run: func(args []string, out io.Writer) error { return runDoctor(args, os.Stdin, out) }},
+	{name: "keys", usage: "keys", run: runKeys},
+	{name: "report", usage: "report [--year y | --from date --to date] [--title t] [--html] [-o file]", run: runReport},
+}
+
+// Run parses global flags and runs a subcommand, or the TUI when none is given
+func Run(args []string) error {
+	global := flag.NewFlagSet("vidlogd", flag.ContinueOnError)
+	global.SetOutput(os.Stderr)
+	profile := global.String("profile", os.Getenv("VIDLOGD_PROFILE"), "profile (library) to use")
+	global.Usage = func() { printUsage(global) }
+
+	if err := global.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return nil
+		}
+		return err
+	}
+
+	if err := storage.SetProfile(*profile); err != nil {
+		return err
+	}
+
+	rest := global.Args()
+	if len(rest) == 0 {
+		return app.Run()
+	}
+
+	for _, cmd := range commands {
+		if cmd.name == rest[0] {
+			return cmd.run(rest[1:], os.Stdout)
+		}
+	}
+
+	printUsage(global)
+	return fmt.Errorf("unknown command %q", rest[0])
+}
+
+func printUsage(global *flag.FlagSet) {
+	out := global.Output()
+	fmt.Fprintln(out, "usage: vidlogd [--profile name] [command]")
+	fmt.Fprintln(out, "\ncommands:")
+	for _, cmd := range commands {
+		fmt.Fprintln(out, "  vidlogd "+cmd.usage)
+	}
+	fmt.Fprintln(out, "\nflags:")
+	global.PrintDefaults()
+}
+
+// parseArgs parses flags that may come before or after positional args.
+// Everything after "--" is positional, so terms like "-bar" can be passed.
+func parseArgs(fs *flag.FlagSet, args []string) ([]string, error) {
+	var rest []string
+	if i := slices.Index(args, "--"); i >= 0 {
+		args, rest = args[:i], args[i+1:]
+	}
+
+	var positional []string
+	for {
+		if err := fs.Parse(args); err != nil {
+			return nil, err
+		}
+		args = fs.Args()
+		if len(args) == 0 {
+			return append(positional, rest...), nil
+		}
+		positional = append(positional, args[0])
+		args = args[1:]
+	}
+}
+
+// createOutput opens path for writing, or stdout for "" and "-"
+func createOutput(path string, stdout io.Writer) (io.Writer, func() error, error) {
+	if path == "" || path == "-" {
+		return stdout, func() error { return nil }, nil
+	}
+	f, err := os.Create(path)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create %s: %w", path, err)
+	}
+	return f, f.Close, nil
+}
@@ -0,0 +1,100 @@
+package cli
+
+import (
+	"bytes"
+	"flag"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mamuzad/vidlogd/internal/models"
+	"github.com/mamuzad/vidlogd/internal/ui"
+)
+
+func TestParseArgs(t *testing.T) {
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	limit := fs.Int("limit", 0, "")
+	positional, err := parseArgs(fs, []string{"foo", "--limit", "2", "--", "-bar", "--limit"})
+	if err != nil {
+		t.Fatalf("parseArgs: %v", err)
+	}
+	if *limit != 2 {
+		t.Errorf("expected the flag after a positional to be parsed, got limit %d", *limit)
+	}
+	if want := []string{"foo", "-bar", "--limit"}; !slices.Equal(positional, want) {
+		t.Errorf("got positional %q, want %q", positional, want)
+	}
+}
+
+func TestSearchNegatedTerm(t *testing.T) {
+	t.Setenv("XDG_DATA_HOME", t.TempDir())
+	if err := models.SaveVideos([]models.Video{
+		{ID: "a", Title: "foo bar", Channel: "x"},
+		{ID: "b", Title: "foo baz", Channel: "x"},
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	var out bytes.Buffer
+	if err := runSearch([]string{"--", "foo", "-bar"}, &out); err != nil {
+		t.Fatalf("search -- foo -bar: %v", err)
+	}
+	if got := out.String(); strings.Contains(got, "foo bar") || !strings.Contains(got, "foo baz") || !strings.Contains(got, "1 matches") {
+		t.Errorf("expected only the video without bar, got\n%s", got)
+	}
+}
+
+func TestExportFormat(t *testing.T) {
+	t.Setenv("XDG_DATA_HOME", t.TempDir())
+	if err := models.SaveVideos([]models.Video{{ID: "a", Title: "foo", Channel: "x"}}); err != nil {
+		t.Fatal(err)
+	}
+
+	var out bytes.Buffer
+	if err := runExport([]string{"--csv"}, &out); err != nil {
+		t.Fatalf("export --csv: %v", err)
+	}
+	if !strings.Contains(out.String(), "foo") {
+		t.Errorf("expected the video in the CSV, got\n%s", out.String())
+	}
+	if err := runExport([]string{"--csv", "--md"}, &out); err == nil {
+		t.Error("expected two formats to be rejected")
+	}
+}
+
+func TestKeysDefaults(t *testing.T) {
+	// the views register the keys of each screen, and are linked in here
+	for _, vim := range []bool{false, true} {
+		if conflicts := ui.NewKeyMap(vim).Conflicts(); len(conflicts) > 0 {
+			t.Errorf("vim=%v: unexpected conflicts %v", vim, conflicts)
+		}
+	}
+
+	t.Setenv("XDG_DATA_HOME", t.TempDir())
+	var out bytes.Buffer
+	if err := runKeys(nil, &out); err != nil {
+		t.Fatalf("keys: %v", err)
+	}
+	if !strings.Contains(out.String(), "newGoal") {
+		t.Errorf("expected every action listed, got\n%s", out.String())
+	}
+}
+
+func TestDoctorInteractive(t *testing.T) {
+	t.Setenv("XDG_DATA_HOME", t.TempDir())
+	if err := models.ReplaceVideos([]models.Video{
+		{ID: "a", URL: "https://youtu.be/abc", Title: "a", Channel: "x", LogDate: time.Now(), Rating: 9},
+		{ID: "b", URL: "https://youtu.be/def", Title: "b", Channel: "x", LogDate: time.Now(), Rating: 7},
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	var out bytes.Buffer
+	if err := runDoctor([]string{"-i"}, strings.NewReader("y\nn\n"), &out); err != nil {
+		t.Fatalf("doctor -i: %v", err)
+	}
+	if got := out.String(); strings.Count(got, "[y/N]") != 2 || !strings.Contains(got, "fixed 1 problems") {
+		t.Errorf("expected two prompts and one fix, got\n%s", got)
+	}
+}
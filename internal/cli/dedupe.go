@@ -0,0 +1,61 @@
+package cli
+
+import (
+	"flag"
+	"fmt"
+	"io"
+
+	"github.com/mamuzad/vidlogd/internal/dedupe"
+	"github.com/mamuzad/vidlogd/internal/models"
+)
+
+func runDedupe(args []string, out io.Writer) error {
+	fs := flag.NewFlagSet("dedupe", flag.ContinueOnError)
+	apply := fs.Bool("apply", false, "merge the duplicates instead of only listing them")
+	if _, err := parseArgs(fs, args); err != nil {
+		return err
+	}
+
+	videos, err := models.LoadVideos()
+	if err != nil {
+		return err
+	}
+
+	groups := dedupe.Find(videos)
+	if len(groups) == 0 {
+		fmt.Fprintln(out, "no duplicates found")
+		return nil
+	}
+
+	removed := 0
+	for _, g := range groups {
+		merged := g.Merged()
+		fmt.Fprintf(out, "%s (%s) logged %d times\n", merged.Title, merged.Channel, len(g.Videos))
+		for _, video := range g.Videos {
+			marker := "  drop"
+			if video.ID == merged.ID {
+				marker = "  keep"
+			}
+			logDate := "no date   "
+			if !video.LogDate.IsZero() {
+				logDate = video.LogDate.Format(models.ISODateFormat)
+			}
+			fmt.Fprintf(out, "%s  %s  %s\n", marker, logDate, video.ID)
+		}
+		removed += len(g.Videos) - 1
+	}
+
+	fmt.Fprintf(out, "\nduplicates: %d videos, %d extra entries\n", len(groups), removed)
+	if !*apply {
+		fmt.Fprintln(out, "nothing merged, run with --apply to merge")
+		return nil
+	}
+
+	for _, g := range groups {
+		if err := models.MergeVideos(g.Merged(), g.Removed()); err != nil {
+			return err
+		}
+	}
+	fmt.Fprintf(out, "merged %d videos\n", len(groups))
+	return nil
+}
@@ -0,0 +1,72 @@
+package cli
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/mamuzad/vidlogd/internal/doctor"
+)
+
+func runDoctor(args []string, in io.Reader, out io.Writer) error {
+	fs := flag.NewFlagSet("doctor", flag.ContinueOnError)
+	fix := fs.Bool("fix", false, "apply every automatic fix")
+	interactive := fs.Bool("i", false, "ask before each automatic fix")
+	if _, err := parseArgs(fs, args); err != nil {
+		return err
+	}
+
+	issues, err := doctor.Scan()
+	if err != nil {
+		return err
+	}
+	if len(issues) == 0 {
+		fmt.Fprintln(out, "no problems found")
+		return nil
+	}
+
+	fixable := 0
+	for _, issue := range issues {
+		fmt.Fprintf(out, "%-17s %s\n", issue.Kind, issue)
+		if issue.Fixable() {
+			fixable++
+		}
+	}
+	fmt.Fprintf(out, "\n%d problems, %d can be fixed automatically\n", len(issues), fixable)
+
+	var selected []doctor.Issue
+	switch {
+	case *interactive:
+		answers := bufio.NewReader(in)
+		for _, issue := range issues {
+			if !issue.Fixable() {
+				continue
+			}
+			fmt.Fprintf(out, "%s: %s? [y/N] ", issue.Name, issue.Fix)
+			answer, err := answers.ReadString('\n')
+			if strings.EqualFold(strings.TrimSpace(answer), "y") {
+				selected = append(selected, issue)
+			}
+			if err != nil {
+				fmt.Fprintln(out)
+				break
+			}
+		}
+	case *fix:
+		selected = issues
+	default:
+		if fixable > 0 {
+			fmt.Fprintln(out, "run with --fix to apply them, or -i to choose")
+		}
+		return nil
+	}
+
+	fixed, err := doctor.Fix(selected)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(out, "fixed %d problems\n", fixed)
+	return nil
+}
@@ -0,0 +1,39 @@
+package cli
+
+import (
+	"flag"
+	"fmt"
+	"io"
+
+	"github.com/mamuzad/vidlogd/internal/models"
+	"github.com/mamuzad/vidlogd/internal/storage"
+	"github.com/mamuzad/vidlogd/internal/ui"
+)
+
+func runKeys(args []string, out io.Writer) error {
+	fs := flag.NewFlagSet("keys", flag.ContinueOnError)
+	if _, err := parseArgs(fs, args); err != nil {
+		return err
+	}
+
+	path, err := storage.KeybindingsPath()
+	if err != nil {
+		return err
+	}
+	settings := models.LoadSettings()
+	ui.SetAccessibility(settings.Symbols, settings.Layout)
+	km, loadErr := ui.LoadKeyMap(settings.VimMotions)
+	if loadErr != nil {
+		fmt.Fprintf(out, "%v\nthese entries keep their default keys\n\n", loadErr)
+	}
+
+	fmt.Fprintf(out, "bindings from %s\n\n", path)
+	for _, nb := range km.Bindings() {
+		keys := nb.Binding.Help().Key
+		if !nb.Binding.Enabled() || len(nb.Binding.Keys()) == 0 {
+			keys = "(unbound)"
+		}
+		fmt.Fprintf(out, "  %-14s %-14s %s\n", nb.Name, keys, nb.Binding.Help().Desc)
+	}
+	return loadErr
+}
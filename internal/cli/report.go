@@ -0,0 +1,50 @@
+package cli
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/mamuzad/vidlogd/internal/models"
+	"github.com/mamuzad/vidlogd/internal/report"
+)
+
+func runReport(args []string, out io.Writer) error {
+	fs := flag.NewFlagSet("report", flag.ContinueOnError)
+	year := fs.Int("year", time.Now().Year(), "calendar year to review")
+	from := fs.String("from", "", "review from this date instead of a year (YYYY-MM-DD)")
+	to := fs.String("to", "", "review up to and including this date (YYYY-MM-DD)")
+	title := fs.String("title", "", "report title")
+	asHTML := fs.Bool("html", false, "write an HTML page instead of Markdown")
+	output := fs.String("o", "", "output file (default stdout)")
+	if _, err := parseArgs(fs, args); err != nil {
+		return err
+	}
+
+	opts := report.Year(*year)
+	if *from != "" || *to != "" {
+		opts = report.Options{}
+		var err error
+		if opts.From, err = parseDayFlag("from", *from); err != nil {
+			return err
+		}
+		if opts.To, err = parseDayFlag("to", *to); err != nil {
+			return err
+		}
+	}
+	opts.Title = *title
+
+	videos, err := models.LoadMergedVideos()
+	if err != nil {
+		return err
+	}
+	r := report.New(videos, opts)
+
+	write := report.WriteMarkdown
+	if *asHTML {
+		write = report.WriteHTML
+	}
+	return writeOutput(*output, out, func(w io.Writer) error { return write(w, r) },
+		fmt.Sprintf("wrote %s", r.Title))
+}
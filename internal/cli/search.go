@@ -0,0 +1,73 @@
+package cli
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/mamuzad/vidlogd/internal/models"
+	"github.com/mamuzad/vidlogd/internal/query"
+	"github.com/mamuzad/vidlogd/internal/ui"
+)
+
+func runSearch(args []string, out io.Writer) error {
+	fs := flag.NewFlagSet("search", flag.ContinueOnError)
+	limit := fs.Int("limit", 0, "show at most this many results (0 for all)")
+	showURL := fs.Bool("url", false, "print each video's URL")
+	positional, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) == 0 {
+		return fmt.Errorf("usage: vidlogd search [flags] [--] query...")
+	}
+
+	// the shell strips quotes, so re-quote words that contained spaces
+	for i, arg := range positional {
+		if strings.ContainsAny(arg, " \t") && !strings.Contains(arg, `"`) {
+			if name, value, ok := strings.Cut(arg, ":"); ok && !strings.Contains(name, " ") {
+				positional[i] = name + `:"` + value + `"`
+			} else {
+				positional[i] = `"` + arg + `"`
+			}
+		}
+	}
+
+	q, err := query.Parse(strings.Join(positional, " "))
+	if err != nil {
+		return err
+	}
+
+	videos, err := models.LoadMergedVideos()
+	if err != nil {
+		return err
+	}
+
+	settings := models.LoadSettings()
+	ui.SetAccessibility(settings.Symbols, settings.Layout)
+
+	results := query.Search(videos, q)
+	if *limit > 0 && len(results) > *limit {
+		results = results[:*limit]
+	}
+
+	for _, result := range results {
+		video := result.Video
+		logDate := "no date   "
+		if !video.LogDate.IsZero() {
+			logDate = video.LogDate.Format(models.ISODateFormat)
+		}
+		rating := strings.Repeat(" ", 5)
+		if video.Rating > 0 {
+			rating = fmt.Sprintf("%-5s", ui.Stars(video.Rating))
+		}
+		fmt.Fprintf(out, "%s  %s  %s (%s)\n", logDate, rating, video.Title, video.Channel)
+		if *showURL && video.URL != "" {
+			fmt.Fprintf(out, "%s  %s\n", strings.Repeat(" ", 17), video.URL)
+		}
+	}
+
+	fmt.Fprintf(out, "%d matches\n", len(results))
+	return nil
+}
@@ -0,0 +1,242 @@
+package cli
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/mamuzad/vidlogd/internal/journal"
+	"github.com/mamuzad/vidlogd/internal/models"
+	"github.com/mamuzad/vidlogd/internal/transfer"
+)
+
+func runExport(args []string, out io.Writer) error {
+	fs := flag.NewFlagSet("export", flag.ContinueOnError)
+	asCSV := fs.Bool("csv", false, "export as CSV (default)")
+	asMarkdown := fs.Bool("md", false, "export a Markdown journal")
+	asHTML := fs.Bool("html", false, "export a static HTML journal site into -o")
+	output := fs.String("o", "", "output file, or directory for --html (default stdout)")
+	dateFormat := fs.String("date-format", "datetime", dateFormatUsage)
+	title := fs.String("title", "", "journal title")
+	from := fs.String("from", "", "journal: only videos logged on or after this date (YYYY-MM-DD)")
+	to := fs.String("to", "", "journal: only videos logged on or before this date (YYYY-MM-DD)")
+	if _, err := parseArgs(fs, args); err != nil {
+		return err
+	}
+	formats := 0
+	for _, set := range []bool{*asCSV, *asMarkdown, *asHTML} {
+		if set {
+			formats++
+		}
+	}
+	if formats > 1 {
+		return fmt.Errorf("pick one of --csv, --md and --html")
+	}
+
+	videos, err := models.LoadVideos()
+	if err != nil {
+		return err
+	}
+	models.SortVideosByLogDate(videos)
+
+	if *asMarkdown || *asHTML {
+		opts := journal.Options{Title: *title}
+		if opts.From, err = parseDayFlag("from", *from); err != nil {
+			return err
+		}
+		if opts.To, err = parseDayFlag("to", *to); err != nil {
+			return err
+		}
+		j := journal.New(videos, opts)
+
+		if *asHTML {
+			if *output == "" || *output == "-" {
+				return fmt.Errorf("--html needs an output directory, e.g. -o site")
+			}
+			if err := journal.WriteSite(*output, j); err != nil {
+				return err
+			}
+			fmt.Fprintf(out, "wrote journal site for %d videos to %s\n", len(j.Videos), *output)
+			return nil
+		}
+
+		return writeOutput(*output, out, func(w io.Writer) error { return journal.WriteMarkdown(w, j) },
+			fmt.Sprintf("wrote journal for %d videos", len(j.Videos)))
+	}
+
+	return writeOutput(*output, out, func(w io.Writer) error { return transfer.ExportCSV(w, videos, *dateFormat) },
+		fmt.Sprintf("exported %d videos", len(videos)))
+}
+
+// writeOutput runs write against the output file or stdout and reports
+// the summary when writing to a file
+func writeOutput(path string, stdout io.Writer, write func(io.Writer) error, summary string) error {
+	w, closeOutput, err := createOutput(path, stdout)
+	if err != nil {
+		return err
+	}
+	if err := write(w); err != nil {
+		closeOutput()
+		return err
+	}
+	if err := closeOutput(); err != nil {
+		return err
+	}
+
+	if path != "" && path != "-" {
+		fmt.Fprintf(stdout, "%s to %s\n", summary, path)
+	}
+	return nil
+}
+
+func runImport(args []string, out io.Writer) error {
+	fs := flag.NewFlagSet("import", flag.ContinueOnError)
+	mapSpec := fs.String("map", "", "column mapping overrides, e.g. url=Link,log_date=Watched")
+	dateFormat := fs.String("date-format", "datetime", dateFormatUsage)
+	dryRun := fs.Bool("dry-run", false, "validate without saving")
+	positional, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) != 1 {
+		return fmt.Errorf("usage: vidlogd import file.csv [flags]")
+	}
+
+	f, err := os.Open(positional[0])
+	if err != nil {
+		return fmt.Errorf("failed to open %s: %w", positional[0], err)
+	}
+	defer f.Close()
+
+	header, rows, err := transfer.ReadCSV(f)
+	if err != nil {
+		return err
+	}
+
+	mapping, err := transfer.ParseMapping(*mapSpec, header, transfer.GuessMapping(header))
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintln(out, "column mapping:")
+	for _, column := range transfer.ImportColumns {
+		if index, ok := mapping[column]; ok {
+			fmt.Fprintf(out, "  %-13s <- %s\n", column, header[index])
+		} else {
+			fmt.Fprintf(out, "  %-13s (unmapped)\n", column)
+		}
+	}
+
+	existing, err := models.LoadVideos()
+	if err != nil {
+		return err
+	}
+
+	result := transfer.PlanImport(rows, existing, transfer.ImportOptions{
+		Mapping:    mapping,
+		DateFormat: *dateFormat,
+	})
+
+	for _, row := range result.Rows {
+		switch {
+		case len(row.Errors) > 0:
+			for _, e := range row.Errors {
+				fmt.Fprintf(out, "line %d: %s\n", row.Line, e)
+			}
+		case row.Duplicate:
+			fmt.Fprintf(out, "line %d: skipped duplicate %q\n", row.Line, row.Video.Title)
+		}
+	}
+
+	fmt.Fprintf(out, "\n%d valid, %d invalid, %d duplicates\n", result.Valid, result.Invalid, result.Duplicates)
+
+	if *dryRun {
+		fmt.Fprintln(out, "dry run: nothing saved")
+		return nil
+	}
+
+	videos := result.Videos()
+	if err := models.SaveVideos(videos); err != nil {
+		return err
+	}
+	fmt.Fprintf(out, "imported %d videos\n", len(videos))
+	return nil
+}
+
+func runTakeout(args []string, out io.Writer) error {
+	fs := flag.NewFlagSet("takeout", flag.ContinueOnError)
+	from := fs.String("from", "", "only watches on or after this date (YYYY-MM-DD)")
+	to := fs.String("to", "", "only watches on or before this date (YYYY-MM-DD)")
+	includeAds := fs.Bool("include-ads", false, "keep entries served as Google Ads")
+	excludeShorts := fs.Bool("exclude-shorts", false, "skip YouTube Shorts")
+	minRepeat := fs.Int("min-repeat", 1, "only videos watched at least this many times")
+	dryRun := fs.Bool("dry-run", false, "show what would be imported without saving")
+	positional, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) != 1 {
+		return fmt.Errorf("usage: vidlogd takeout watch-history.json|.html [flags]")
+	}
+
+	filter := transfer.TakeoutFilter{
+		ExcludeAds:    !*includeAds,
+		ExcludeShorts: *excludeShorts,
+		MinRepeat:     *minRepeat,
+	}
+	if filter.From, err = parseDayFlag("from", *from); err != nil {
+		return err
+	}
+	if filter.To, err = parseDayFlag("to", *to); err != nil {
+		return err
+	}
+
+	f, err := os.Open(positional[0])
+	if err != nil {
+		return fmt.Errorf("failed to open %s: %w", positional[0], err)
+	}
+	defer f.Close()
+
+	entries, err := transfer.ParseTakeout(f)
+	if err != nil {
+		return err
+	}
+
+	existing, err := models.LoadVideos()
+	if err != nil {
+		return err
+	}
+
+	result := transfer.PlanTakeout(entries, existing, filter)
+
+	fmt.Fprintf(out, "%d watches in history\n", result.Watches)
+	fmt.Fprintf(out, "  skipped: %d ads, %d shorts, %d out of range, %d below repeat count, %d already logged, %d incomplete\n",
+		result.Ads, result.Shorts, result.OutOfRange, result.BelowRepeat, result.AlreadyLogged, result.Invalid)
+
+	if *dryRun {
+		for _, video := range result.Videos {
+			fmt.Fprintf(out, "  %s  %s (%s)\n", video.LogDate.Format(models.DateTimeFormat), video.Title, video.Channel)
+		}
+		fmt.Fprintf(out, "dry run: %d videos would be imported\n", len(result.Videos))
+		return nil
+	}
+
+	if err := models.SaveVideos(result.Videos); err != nil {
+		return err
+	}
+	fmt.Fprintf(out, "imported %d videos\n", len(result.Videos))
+	return nil
+}
+
+func parseDayFlag(name, value string) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+	t, err := time.ParseInLocation(models.ISODateFormat, value, time.Local)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid --%s date %q, expected YYYY-MM-DD", name, value)
+	}
+	return t, nil
+}
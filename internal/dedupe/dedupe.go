@@ -0,0 +1,182 @@
+// Package dedupe finds videos that were logged more than once as a first
+// watch and merges them into one entry, keeping the richest data.
+// Entries marked as rewatches are legitimate repeats and are left alone.
+package dedupe
+
+import (
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/mamuzad/vidlogd/internal/models"
+	"github.com/mamuzad/vidlogd/internal/services"
+)
+
+// Key identifies the video an entry is about: its YouTube video ID, or the
+// trimmed URL when no ID can be extracted
+func Key(video models.Video) string {
+	if id := services.ExtractVideoID(video.URL); id != "" {
+		return id
+	}
+	return strings.TrimSpace(video.URL)
+}
+
+// Group is a video logged more than once as a first watch
+type Group struct {
+	Key    string
+	Videos []models.Video // oldest first
+}
+
+// Merged returns the entry the group merges into
+func (g Group) Merged() models.Video {
+	return Merge(g.Videos)
+}
+
+// Removed returns the IDs of the entries dropped by the merge
+func (g Group) Removed() []string {
+	keep := g.Merged().ID
+	var ids []string
+	for _, video := range g.Videos {
+		if video.ID != keep {
+			ids = append(ids, video.ID)
+		}
+	}
+	return ids
+}
+
+// Find returns the duplicate groups in videos, most recently logged first
+func Find(videos []models.Video) []Group {
+	byKey := make(map[string][]models.Video)
+	var keys []string
+	for _, video := range videos {
+		key := Key(video)
+		if key == "" || video.Rewatched {
+			continue
+		}
+		if _, ok := byKey[key]; !ok {
+			keys = append(keys, key)
+		}
+		byKey[key] = append(byKey[key], video)
+	}
+
+	var groups []Group
+	for _, key := range keys {
+		entries := byKey[key]
+		if len(entries) < 2 {
+			continue
+		}
+		sort.SliceStable(entries, func(i, j int) bool {
+			return entries[i].LogDate.Before(entries[j].LogDate)
+		})
+		groups = append(groups, Group{Key: key, Videos: entries})
+	}
+
+	sort.SliceStable(groups, func(i, j int) bool {
+		return latest(groups[i]).After(latest(groups[j]))
+	})
+	return groups
+}
+
+// Existing returns the most recently logged entry for the same video as
+// url, skipping the entry with ID exclude
+func Existing(videos []models.Video, url, exclude string) (models.Video, bool) {
+	key := Key(models.Video{URL: url})
+	if key == "" {
+		return models.Video{}, false
+	}
+
+	var found models.Video
+	ok := false
+	for _, video := range videos {
+		if video.ID == exclude || Key(video) != key {
+			continue
+		}
+		if !ok || video.LogDate.After(found.LogDate) {
+			found, ok = video, true
+		}
+	}
+	return found, ok
+}
+
+// Merge combines entries for one video. The richest entry is kept and its
+// empty fields are filled from the others; tags are combined, the longest
+// review wins and the earliest log date is kept.
+func Merge(videos []models.Video) models.Video {
+	if len(videos) == 0 {
+		return models.Video{}
+	}
+
+	merged := videos[0]
+	for _, video := range videos[1:] {
+		if richness(video) > richness(merged) {
+			merged = video
+		}
+	}
+	merged.Tags = append([]string(nil), merged.Tags...)
+
+	for _, video := range videos {
+		if merged.URL == "" {
+			merged.URL = video.URL
+		}
+		if merged.Title == "" {
+			merged.Title = video.Title
+		}
+		if merged.Channel == "" {
+			merged.Channel = video.Channel
+		}
+		if merged.ChannelID == "" {
+			merged.ChannelID = video.ChannelID
+		}
+		if merged.ReleaseDate == "" {
+			merged.ReleaseDate = video.ReleaseDate
+		}
+		if merged.Rating == 0 {
+			merged.Rating = video.Rating
+		}
+		if merged.Duration == 0 {
+			merged.Duration = video.Duration
+		}
+		if merged.Collection == "" {
+			merged.Collection = video.Collection
+		}
+		if len(strings.TrimSpace(video.Review)) > len(strings.TrimSpace(merged.Review)) {
+			merged.Review = video.Review
+		}
+		merged.Tags = append(merged.Tags, video.Tags...)
+		if !video.LogDate.IsZero() && (merged.LogDate.IsZero() || video.LogDate.Before(merged.LogDate)) {
+			merged.LogDate = video.LogDate
+		}
+		if !video.CreatedAt.IsZero() && (merged.CreatedAt.IsZero() || video.CreatedAt.Before(merged.CreatedAt)) {
+			merged.CreatedAt = video.CreatedAt
+		}
+	}
+	merged.Tags = models.NormalizeTags(merged.Tags)
+
+	return merged
+}
+
+// richness counts the fields an entry has filled in
+func richness(video models.Video) int {
+	filled := 0
+	for _, ok := range []bool{
+		video.Title != "",
+		video.Channel != "",
+		video.ChannelID != "",
+		video.ReleaseDate != "",
+		video.Rating > 0,
+		strings.TrimSpace(video.Review) != "",
+		len(video.Tags) > 0,
+		video.Duration > 0,
+		video.Collection != "",
+	} {
+		if ok {
+			filled++
+		}
+	}
+	return filled
+}
+
+// latest returns the most recent log date in the group
+func latest(g Group) time.Time {
+	return g.Videos[len(g.Videos)-1].LogDate
+}
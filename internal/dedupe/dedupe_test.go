@@ -0,0 +1,92 @@
+package dedupe
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/mamuzad/vidlogd/internal/models"
+)
+
+func day(d int) time.Time {
+	return time.Date(2025, 3, d, 20, 0, 0, 0, time.UTC)
+}
+
+func TestFind(t *testing.T) {
+	videos := []models.Video{
+		{ID: "1", URL: "https://www.youtube.com/watch?v=abc", LogDate: day(1)},
+		{ID: "2", URL: "https://youtu.be/abc", LogDate: day(5)},
+		{ID: "3", URL: "https://www.youtube.com/watch?v=abc", LogDate: day(9), Rewatched: true},
+		{ID: "4", URL: "https://www.youtube.com/watch?v=xyz", LogDate: day(2)},
+		{ID: "5", URL: "https://www.youtube.com/watch?v=xyz&t=30", LogDate: day(8)},
+		{ID: "6", URL: "https://www.youtube.com/watch?v=once", LogDate: day(3)},
+		{ID: "7", URL: "https://example.com/talk ", LogDate: day(4)},
+		{ID: "8", URL: "https://example.com/talk", LogDate: day(6)},
+		{ID: "9", LogDate: day(7)},
+		{ID: "10", LogDate: day(7)},
+	}
+
+	groups := Find(videos)
+	var got [][]string
+	for _, g := range groups {
+		var ids []string
+		for _, v := range g.Videos {
+			ids = append(ids, v.ID)
+		}
+		got = append(got, ids)
+	}
+	want := [][]string{{"4", "5"}, {"7", "8"}, {"1", "2"}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Find = %v, want %v", got, want)
+	}
+}
+
+func TestMerge(t *testing.T) {
+	group := Group{Videos: []models.Video{
+		{ID: "old", URL: "https://youtu.be/abc", Title: "Talk", LogDate: day(1), CreatedAt: day(1), Tags: []string{"go"}},
+		{ID: "rich", URL: "https://youtu.be/abc", Title: "Talk", Channel: "GopherCon", Rating: 4.5,
+			Review: "good", Tags: []string{"Talks", "go"}, LogDate: day(5), CreatedAt: day(5)},
+		{ID: "new", URL: "https://youtu.be/abc", Review: "a longer review", Duration: 600, LogDate: day(9)},
+	}}
+
+	merged := group.Merged()
+	if merged.ID != "rich" || merged.Channel != "GopherCon" || merged.Rating != 4.5 {
+		t.Errorf("expected the richest entry to be kept: %+v", merged)
+	}
+	if merged.Review != "a longer review" || merged.Duration != 600 {
+		t.Errorf("expected gaps filled from other entries: %+v", merged)
+	}
+	if !merged.LogDate.Equal(day(1)) || !merged.CreatedAt.Equal(day(1)) {
+		t.Errorf("expected the earliest dates, got %v / %v", merged.LogDate, merged.CreatedAt)
+	}
+	if !reflect.DeepEqual(merged.Tags, []string{"talks", "go"}) {
+		t.Errorf("tags = %v", merged.Tags)
+	}
+	if removed := group.Removed(); !reflect.DeepEqual(removed, []string{"old", "new"}) {
+		t.Errorf("Removed = %v", removed)
+	}
+	if !reflect.DeepEqual(group.Videos[1].Tags, []string{"Talks", "go"}) {
+		t.Error("Merge should not change the group's entries")
+	}
+}
+
+func TestExisting(t *testing.T) {
+	videos := []models.Video{
+		{ID: "1", URL: "https://www.youtube.com/watch?v=abc", LogDate: day(1)},
+		{ID: "2", URL: "https://youtu.be/abc?si=share", LogDate: day(4)},
+		{ID: "3", URL: "https://www.youtube.com/watch?v=xyz", LogDate: day(9)},
+	}
+
+	if v, ok := Existing(videos, "https://m.youtube.com/watch?v=abc", ""); !ok || v.ID != "2" {
+		t.Errorf("expected the latest entry, got %+v, %v", v, ok)
+	}
+	if v, ok := Existing(videos, "https://youtu.be/abc", "2"); !ok || v.ID != "1" {
+		t.Errorf("expected the edited entry to be skipped, got %+v, %v", v, ok)
+	}
+	if _, ok := Existing(videos, "https://youtu.be/new", ""); ok {
+		t.Error("expected no match for a new video")
+	}
+	if _, ok := Existing(videos, "", ""); ok {
+		t.Error("expected no match for an empty url")
+	}
+}
@@ -0,0 +1,299 @@
+// Package doctor scans the library for entries and files that the rest of
+// vidlogd cannot handle cleanly, and repairs the ones that can be fixed
+// without asking the user.
+package doctor
+
+import (
+	"fmt"
+	"math"
+	neturl "net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/mamuzad/vidlogd/internal/models"
+	"github.com/mamuzad/vidlogd/internal/storage"
+)
+
+// Kind names a type of problem
+type Kind string
+
+// problem kinds, in the order they are checked
+const (
+	BadURL           Kind = "url"
+	MissingLogDate   Kind = "log date"
+	BadReleaseDate   Kind = "release date"
+	BadRating        Kind = "rating"
+	MissingTitle     Kind = "title"
+	MissingChannel   Kind = "channel"
+	DuplicateID      Kind = "duplicate id"
+	ReleaseAfterLog  Kind = "release after log"
+	LeftoverTempFile Kind = "temp file"
+)
+
+// releaseLayouts are the other date layouts release dates are recovered from
+var releaseLayouts = []string{
+	time.RFC3339,
+	"2006-01-02 15:04:05",
+	"2006/01/02",
+	"2006-1-2",
+	"01/02/2006",
+	"Jan 2, 2006",
+	"January 2, 2006",
+	"2 Jan 2006",
+}
+
+// Issue is one problem found in the library
+type Issue struct {
+	Kind    Kind
+	Index   int    // position in videos.json, -1 for files
+	VideoID string // empty for files
+	Name    string // video title or file name
+	Detail  string // what is wrong
+	Fix     string // what the automatic fix does, empty when the user has to fix it
+	Path    string // leftover file, for LeftoverTempFile
+}
+
+// Fixable reports whether the issue can be repaired automatically
+func (i Issue) Fixable() bool {
+	return i.Fix != ""
+}
+
+func (i Issue) String() string {
+	s := fmt.Sprintf("%s: %s", i.Name, i.Detail)
+	if i.Fixable() {
+		s += " (fix: " + i.Fix + ")"
+	}
+	return s
+}
+
+// Check returns the problems in videos, in log order
+func Check(videos []models.Video) []Issue {
+	var issues []Issue
+	seen := make(map[string]bool)
+
+	for i, video := range videos {
+		add := func(kind Kind, detail, fix string) {
+			issues = append(issues, Issue{Kind: kind, Index: i, VideoID: video.ID, Name: videoName(video), Detail: detail, Fix: fix})
+		}
+
+		if !validURL(video.URL) {
+			fix := ""
+			if fixed := fixURL(video.URL); fixed != "" {
+				fix = "use " + fixed
+			}
+			add(BadURL, fmt.Sprintf("malformed url %q", video.URL), fix)
+		}
+		if video.LogDate.IsZero() {
+			fix := ""
+			if !video.CreatedAt.IsZero() {
+				fix = "use the date it was added, " + video.CreatedAt.Format(models.ISODateFormat)
+			}
+			add(MissingLogDate, "no log date", fix)
+		}
+		if video.ReleaseDate != "" && !models.IsValidDate(video.ReleaseDate) {
+			fix := "clear it"
+			if fixed := fixReleaseDate(video.ReleaseDate); fixed != "" {
+				fix = "use " + fixed
+			}
+			add(BadReleaseDate, fmt.Sprintf("unreadable release date %q", video.ReleaseDate), fix)
+		}
+		if !models.IsValidRating(video.Rating) {
+			add(BadRating, fmt.Sprintf("rating %g is not 0-5 in half stars", video.Rating), fmt.Sprintf("use %g", fixRating(video.Rating)))
+		}
+		if strings.TrimSpace(video.Title) == "" {
+			add(MissingTitle, "no title", "")
+		}
+		if strings.TrimSpace(video.Channel) == "" {
+			add(MissingChannel, "no channel", "")
+		}
+		if video.ID == "" || seen[video.ID] {
+			add(DuplicateID, fmt.Sprintf("id %q is missing or used twice", video.ID), "give it a new id")
+		}
+		seen[video.ID] = true
+
+		if release, err := time.ParseInLocation(models.ISODateFormat, video.ReleaseDate, video.LogDate.Location()); err == nil && !video.LogDate.IsZero() {
+			logDay := time.Date(video.LogDate.Year(), video.LogDate.Month(), video.LogDate.Day(), 0, 0, 0, 0, video.LogDate.Location())
+			if release.After(logDay) {
+				add(ReleaseAfterLog, fmt.Sprintf("released %s, after it was logged on %s", video.ReleaseDate, video.LogDate.Format(models.ISODateFormat)), "")
+			}
+		}
+	}
+
+	return issues
+}
+
+// tempFileAge is how old a temp file has to be to count as left over.
+// Saves take well under a second, so a newer one may belong to a save
+// still running in another vidlogd.
+const tempFileAge = time.Hour
+
+// TempFiles returns the leftover temporary files from interrupted writes
+// in dir
+func TempFiles(dir string) ([]Issue, error) {
+	paths, err := filepath.Glob(filepath.Join(dir, "tmp-*.json*"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list temp files: %w", err)
+	}
+
+	issues := make([]Issue, 0, len(paths))
+	for _, path := range paths {
+		info, err := os.Stat(path)
+		if err != nil || time.Since(info.ModTime()) < tempFileAge {
+			// gone already, or possibly still being written
+			continue
+		}
+		issues = append(issues, Issue{
+			Kind:   LeftoverTempFile,
+			Index:  -1,
+			Name:   filepath.Base(path),
+			Detail: "left over from an interrupted save",
+			Fix:    "delete it",
+			Path:   path,
+		})
+	}
+	return issues, nil
+}
+
+// Scan checks the active profile's videos and data directory
+func Scan() ([]Issue, error) {
+	videos, err := models.LoadVideos()
+	if err != nil {
+		return nil, err
+	}
+	dir, err := storage.DataDir()
+	if err != nil {
+		return nil, err
+	}
+
+	files, err := TempFiles(dir)
+	if err != nil {
+		return nil, err
+	}
+	return append(Check(videos), files...), nil
+}
+
+// Repair returns a copy of videos with the fixable issues among issues
+// applied. Issues must come from Check on the same videos.
+func Repair(videos []models.Video, issues []Issue) []models.Video {
+	repaired := make([]models.Video, len(videos))
+	copy(repaired, videos)
+
+	for _, issue := range issues {
+		if !issue.Fixable() || issue.Index < 0 || issue.Index >= len(repaired) {
+			continue
+		}
+		video := &repaired[issue.Index]
+		switch issue.Kind {
+		case BadURL:
+			video.URL = fixURL(video.URL)
+		case MissingLogDate:
+			video.LogDate = video.CreatedAt
+		case BadReleaseDate:
+			video.ReleaseDate = fixReleaseDate(video.ReleaseDate)
+		case BadRating:
+			video.Rating = fixRating(video.Rating)
+		case DuplicateID:
+			video.ID = models.NewVideoID()
+		}
+	}
+	return repaired
+}
+
+// Fix repairs the given issues in the active profile and returns how many
+// were fixed. Issues are checked again first, so ones that no longer
+// apply are skipped.
+func Fix(issues []Issue) (int, error) {
+	current, err := Scan()
+	if err != nil {
+		return 0, err
+	}
+
+	var selected []Issue
+	for _, issue := range current {
+		if issue.Fixable() && contains(issues, issue) {
+			selected = append(selected, issue)
+		}
+	}
+
+	fixedVideos := 0
+	for _, issue := range selected {
+		if issue.Kind == LeftoverTempFile {
+			if err := os.Remove(issue.Path); err != nil && !os.IsNotExist(err) {
+				return 0, fmt.Errorf("failed to remove %s: %w", issue.Name, err)
+			}
+		} else {
+			fixedVideos++
+		}
+	}
+
+	if fixedVideos > 0 {
+		videos, err := models.LoadVideos()
+		if err != nil {
+			return 0, err
+		}
+		if err := models.ReplaceVideos(Repair(videos, selected)); err != nil {
+			return 0, err
+		}
+	}
+
+	return len(selected), nil
+}
+
+func contains(issues []Issue, issue Issue) bool {
+	for _, i := range issues {
+		if i.Kind == issue.Kind && i.Index == issue.Index && i.VideoID == issue.VideoID && i.Path == issue.Path {
+			return true
+		}
+	}
+	return false
+}
+
+func videoName(video models.Video) string {
+	if title := strings.TrimSpace(video.Title); title != "" {
+		return title
+	}
+	if video.ID != "" {
+		return "video " + video.ID
+	}
+	return "untitled video"
+}
+
+// validURL reports whether s is an absolute http(s) URL
+func validURL(s string) bool {
+	u, err := neturl.Parse(s)
+	if err != nil {
+		return false
+	}
+	return (u.Scheme == "http" || u.Scheme == "https") && u.Host != "" && !strings.ContainsAny(s, " \t\n")
+}
+
+// fixURL trims s and adds a missing scheme, returning "" when that does not
+// give a valid URL
+func fixURL(s string) string {
+	s = strings.TrimSpace(s)
+	if s != "" && !strings.Contains(s, "://") {
+		s = "https://" + strings.TrimPrefix(s, "//")
+	}
+	if !validURL(s) {
+		return ""
+	}
+	return s
+}
+
+// fixReleaseDate reads s in another common layout, "" when none match
+func fixReleaseDate(s string) string {
+	s = strings.TrimSpace(s)
+	for _, layout := range append([]string{models.ISODateFormat}, releaseLayouts...) {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t.Format(models.ISODateFormat)
+		}
+	}
+	return ""
+}
+
+// fixRating clamps a rating to 0-5 and rounds it to half stars
+func fixRating(rating float64) float64 {
+	return math.Max(0, math.Min(5, math.Round(rating*2)/2))
+}
@@ -0,0 +1,133 @@
+package doctor
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/mamuzad/vidlogd/internal/models"
+	"github.com/mamuzad/vidlogd/internal/storage"
+)
+
+func kinds(issues []Issue) map[Kind]int {
+	counts := make(map[Kind]int)
+	for _, issue := range issues {
+		counts[issue.Kind]++
+	}
+	return counts
+}
+
+func TestCheck(t *testing.T) {
+	logged := time.Date(2025, 3, 1, 20, 0, 0, 0, time.UTC)
+	good := models.Video{ID: "ok", URL: "https://youtu.be/abc", Title: "t", Channel: "c", ReleaseDate: "2025-02-01", LogDate: logged, Rating: 4.5}
+
+	videos := []models.Video{
+		good,
+		{ID: "a", URL: "youtu.be/abc", Title: "t", Channel: "c", LogDate: logged},
+		{ID: "b", URL: "not a url", Title: "t", Channel: "c", LogDate: logged},
+		{ID: "c", URL: good.URL, Title: "t", Channel: "c", CreatedAt: logged},
+		{ID: "d", URL: good.URL, Title: "t", Channel: "c", ReleaseDate: "03/02/2025", LogDate: logged, Rating: 7},
+		{ID: "e", URL: good.URL, LogDate: logged, Rating: 3.3},
+		{ID: "ok", URL: good.URL, Title: "t", Channel: "c", ReleaseDate: "2025-03-02", LogDate: logged},
+	}
+
+	issues := Check(videos)
+	want := map[Kind]int{
+		BadURL:          2,
+		MissingLogDate:  1,
+		BadReleaseDate:  1,
+		BadRating:       2,
+		MissingTitle:    1,
+		MissingChannel:  1,
+		DuplicateID:     1,
+		ReleaseAfterLog: 1,
+	}
+	got := kinds(issues)
+	for kind, n := range want {
+		if got[kind] != n {
+			t.Errorf("%s: got %d issues, want %d", kind, got[kind], n)
+		}
+	}
+	for _, issue := range issues {
+		if issue.Index == 0 {
+			t.Errorf("unexpected issue with a valid video: %v", issue)
+		}
+		if issue.Kind == BadURL && issue.VideoID == "b" && issue.Fixable() {
+			t.Errorf("expected %q to need the user", issue.Detail)
+		}
+	}
+}
+
+func TestRepair(t *testing.T) {
+	created := time.Date(2025, 3, 1, 20, 0, 0, 0, time.UTC)
+	videos := []models.Video{
+		{ID: "a", URL: " youtu.be/abc ", Title: "t", Channel: "c", CreatedAt: created, ReleaseDate: "Feb 3, 2025", Rating: 5.2},
+		{ID: "a", URL: "https://youtu.be/abc", Title: "t", Channel: "c", LogDate: created, ReleaseDate: "someday", Rating: -1},
+	}
+
+	repaired := Repair(videos, Check(videos))
+	if a := repaired[0]; a.URL != "https://youtu.be/abc" || !a.LogDate.Equal(created) || a.ReleaseDate != "2025-02-03" || a.Rating != 5 {
+		t.Errorf("first video not repaired: %+v", a)
+	}
+	if b := repaired[1]; b.ID == "a" || b.ID == "" || b.ReleaseDate != "" || b.Rating != 0 {
+		t.Errorf("second video not repaired: %+v", b)
+	}
+	if videos[0].Rating != 5.2 {
+		t.Error("Repair should not change its input")
+	}
+	if issues := Check(repaired); len(issues) != 0 {
+		t.Errorf("expected no issues after repair, got %v", issues)
+	}
+}
+
+func TestFix(t *testing.T) {
+	t.Setenv("XDG_DATA_HOME", t.TempDir())
+
+	if err := models.ReplaceVideos([]models.Video{
+		{ID: "a", URL: "https://youtu.be/abc", Title: "", Channel: "c", LogDate: time.Now(), Rating: 9},
+	}); err != nil {
+		t.Fatalf("ReplaceVideos: %v", err)
+	}
+	dir, err := storage.DataDir()
+	if err != nil {
+		t.Fatalf("DataDir: %v", err)
+	}
+	leftover := filepath.Join(dir, "tmp-videos.json123")
+	if err := os.WriteFile(leftover, []byte("[]"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	old := time.Now().Add(-2 * tempFileAge)
+	if err := os.Chtimes(leftover, old, old); err != nil {
+		t.Fatal(err)
+	}
+	// a save in progress elsewhere
+	writing := filepath.Join(dir, "tmp-videos.json456")
+	if err := os.WriteFile(writing, []byte("["), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	issues, err := Scan()
+	if err != nil {
+		t.Fatalf("Scan: %v", err)
+	}
+	if got := kinds(issues); got[BadRating] != 1 || got[MissingTitle] != 1 || got[LeftoverTempFile] != 1 {
+		t.Fatalf("unexpected issues: %v", issues)
+	}
+
+	fixed, err := Fix(issues)
+	if err != nil || fixed != 2 {
+		t.Fatalf("Fix = %d, %v; want 2", fixed, err)
+	}
+	if _, err := os.Stat(leftover); !os.IsNotExist(err) {
+		t.Error("expected the temp file to be removed")
+	}
+	if _, err := os.Stat(writing); err != nil {
+		t.Errorf("expected the new temp file to be kept, got %v", err)
+	}
+
+	issues, _ = Scan()
+	if len(issues) != 1 || issues[0].Kind != MissingTitle {
+		t.Errorf("expected only the missing title left, got %v", issues)
+	}
+}
@@ -0,0 +1,123 @@
+package journal
+
+import (
+	"bytes"
+	"fmt"
+	"html/template"
+	"path/filepath"
+	"time"
+
+	"github.com/mamuzad/vidlogd/internal/markup"
+	"github.com/mamuzad/vidlogd/internal/models"
+	"github.com/mamuzad/vidlogd/internal/storage"
+)
+
+const siteTemplates = `
+{{define "head"}}<!doctype html>
+<html lang="en"><head><meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<title>{{.}}</title>
+<style>
+{{style "52rem"}}
+svg{color:#b71c1c;max-width:100%;height:auto}
+blockquote{margin:.25rem 0 .75rem;padding-left:.75rem;border-left:3px solid #ddd;color:#444;white-space:pre-wrap}
+ul.videos{list-style:none;padding:0}ul.videos li{margin-bottom:.75rem}
+</style></head><body>{{end}}
+
+{{define "foot"}}<p class="meta">generated by vidlogd</p></body></html>{{end}}
+
+{{define "video"}}<li><strong>{{if .URL}}<a href="{{.URL}}">{{title .}}</a>{{else}}{{title .}}{{end}}</strong>
+<div class="meta">{{.Channel}}{{if gt .Rating 0.0}} · <span class="stars">{{stars .Rating}}</span>{{end}}{{if .Rewatched}} · rewatched{{end}}{{if not .LogDate.IsZero}} · {{date .LogDate}}{{end}}</div>
+{{if .Review}}<blockquote>{{.Review}}</blockquote>{{end}}</li>{{end}}
+
+{{define "index"}}{{template "head" .Title}}
+<h1>{{.Title}}</h1>
+<p class="meta">{{.Summary}}</p>
+{{.RatingChart}}
+{{.MonthChart}}
+<h2>Months</h2>
+<ul>{{range .Months}}<li><a href="months/{{.Key}}.html">{{.Label}}</a> <span class="meta">({{len .Videos}})</span></li>{{end}}</ul>
+<h2>Channels</h2>
+<ul>{{range .Channels}}<li><a href="channels/{{.Slug}}.html">{{.Name}}</a> <span class="meta">({{len .Videos}})</span></li>{{end}}</ul>
+{{template "foot"}}{{end}}
+
+{{define "page"}}{{template "head" .Title}}
+<p><a href="../index.html">← {{.Back}}</a></p>
+<h1>{{.Title}}</h1>
+<p class="meta">{{.Summary}}</p>
+<ul class="videos">{{range .Videos}}{{template "video" .}}{{end}}</ul>
+{{template "foot"}}{{end}}
+`
+
+var siteTemplate = template.Must(template.New("site").Funcs(markup.Funcs()).Funcs(template.FuncMap{
+	"date": func(t time.Time) string { return t.Format(models.ISODateFormat) },
+}).Parse(siteTemplates))
+
+type indexPage struct {
+	Title       string
+	Summary     string
+	RatingChart template.HTML
+	MonthChart  template.HTML
+	Months      []Month
+	Channels    []Channel
+}
+
+type listPage struct {
+	Title   string
+	Back    string
+	Summary string
+	Videos  []models.Video
+}
+
+// WriteSite writes a static site into dir: an index with charts, one page
+// per month and one page per channel
+func WriteSite(dir string, j Journal) error {
+	ratingLabels, ratingValues := j.RatingCounts()
+	monthLabels, monthValues := j.MonthlyCounts()
+
+	index := indexPage{
+		Title:       j.Title,
+		Summary:     summaryLine(j),
+		RatingChart: template.HTML(BarChartSVG("Ratings", ratingLabels, ratingValues)),
+		MonthChart:  template.HTML(BarChartSVG("Videos per month", monthLabels, monthValues)),
+		Months:      j.Months,
+		Channels:    j.Channels,
+	}
+	if err := writePage(filepath.Join(dir, "index.html"), "index", index); err != nil {
+		return err
+	}
+
+	for _, month := range j.Months {
+		page := listPage{
+			Title:   month.Label,
+			Back:    j.Title,
+			Summary: fmt.Sprintf("%d videos", len(month.Videos)),
+			Videos:  month.Videos,
+		}
+		if err := writePage(filepath.Join(dir, "months", month.Key+".html"), "page", page); err != nil {
+			return err
+		}
+	}
+
+	for _, channel := range j.Channels {
+		page := listPage{
+			Title:   channel.Name,
+			Back:    j.Title,
+			Summary: channelSummary(channel),
+			Videos:  channel.Videos,
+		}
+		if err := writePage(filepath.Join(dir, "channels", channel.Slug+".html"), "page", page); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func writePage(path, name string, data any) error {
+	var buf bytes.Buffer
+	if err := siteTemplate.ExecuteTemplate(&buf, name, data); err != nil {
+		return fmt.Errorf("rendering %s: %w", filepath.Base(path), err)
+	}
+	return storage.WriteFileAtomic(path, buf.Bytes(), 0o644)
+}
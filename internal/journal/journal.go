@@ -0,0 +1,177 @@
+package journal
+
+import (
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/mamuzad/vidlogd/internal/models"
+)
+
+// Options selects which videos go into a journal
+type Options struct {
+	Title string
+	From  time.Time // inclusive, zero for no limit
+	To    time.Time // inclusive day, zero for no limit
+}
+
+// Journal is the log grouped for publishing
+type Journal struct {
+	Title       string
+	Videos      []models.Video // most recent first
+	Months      []Month        // most recent first
+	Channels    []Channel      // most logged first
+	AvgRating   float64
+	RatingCount map[float64]int // 1.0 to 5.0 in 0.5 steps
+}
+
+// Month groups the videos logged in one calendar month
+type Month struct {
+	Key    string // YYYY-MM, also used for file names
+	Label  string // January 2025
+	Videos []models.Video
+}
+
+// Channel groups the videos logged from one channel
+type Channel struct {
+	Name      string
+	Slug      string // file name safe
+	Videos    []models.Video
+	AvgRating float64
+}
+
+// Ratings lists the rating buckets shown in charts
+var Ratings = []float64{0.5, 1.0, 1.5, 2.0, 2.5, 3.0, 3.5, 4.0, 4.5, 5.0}
+
+// New groups videos in the requested range into months and channels
+func New(videos []models.Video, opts Options) Journal {
+	j := Journal{
+		Title:       opts.Title,
+		RatingCount: make(map[float64]int),
+	}
+	if j.Title == "" {
+		j.Title = "video journal"
+	}
+
+	for _, video := range videos {
+		if !opts.From.IsZero() && video.LogDate.Before(opts.From) {
+			continue
+		}
+		if !opts.To.IsZero() && !video.LogDate.Before(opts.To.AddDate(0, 0, 1)) {
+			continue
+		}
+		j.Videos = append(j.Videos, video)
+	}
+	models.SortVideosByLogDate(j.Videos)
+
+	monthIndex := make(map[string]int)
+	channelIndex := make(map[string]int)
+	var ratingSum float64
+	var rated int
+
+	for _, video := range j.Videos {
+		if video.Rating > 0 {
+			ratingSum += video.Rating
+			rated++
+			j.RatingCount[video.Rating]++
+		}
+
+		if !video.LogDate.IsZero() {
+			key := video.LogDate.Format("2006-01")
+			i, ok := monthIndex[key]
+			if !ok {
+				i = len(j.Months)
+				monthIndex[key] = i
+				j.Months = append(j.Months, Month{Key: key, Label: video.LogDate.Format("January 2006")})
+			}
+			j.Months[i].Videos = append(j.Months[i].Videos, video)
+		}
+
+		name := video.Channel
+		if name == "" {
+			name = "Unknown Channel"
+		}
+		i, ok := channelIndex[name]
+		if !ok {
+			i = len(j.Channels)
+			channelIndex[name] = i
+			j.Channels = append(j.Channels, Channel{Name: name})
+		}
+		j.Channels[i].Videos = append(j.Channels[i].Videos, video)
+	}
+
+	if rated > 0 {
+		j.AvgRating = ratingSum / float64(rated)
+	}
+
+	sort.Slice(j.Months, func(a, b int) bool { return j.Months[a].Key > j.Months[b].Key })
+
+	slugs := make(map[string]int)
+	for i := range j.Channels {
+		c := &j.Channels[i]
+		c.AvgRating = averageRating(c.Videos)
+		c.Slug = uniqueSlug(c.Name, slugs)
+	}
+	sort.SliceStable(j.Channels, func(a, b int) bool {
+		if len(j.Channels[a].Videos) == len(j.Channels[b].Videos) {
+			return j.Channels[a].Name < j.Channels[b].Name
+		}
+		return len(j.Channels[a].Videos) > len(j.Channels[b].Videos)
+	})
+
+	return j
+}
+
+// MonthlyCounts returns labels and counts oldest first, for charts
+func (j Journal) MonthlyCounts() ([]string, []int) {
+	labels := make([]string, len(j.Months))
+	values := make([]int, len(j.Months))
+	for i, month := range j.Months {
+		at := len(j.Months) - 1 - i
+		labels[at] = month.Key
+		values[at] = len(month.Videos)
+	}
+	return labels, values
+}
+
+// RatingCounts returns labels and counts for the rating distribution
+func (j Journal) RatingCounts() ([]string, []int) {
+	labels := make([]string, len(Ratings))
+	values := make([]int, len(Ratings))
+	for i, rating := range Ratings {
+		labels[i] = strconv.FormatFloat(rating, 'g', -1, 64)
+		values[i] = j.RatingCount[rating]
+	}
+	return labels, values
+}
+
+func averageRating(videos []models.Video) float64 {
+	var sum float64
+	var rated int
+	for _, video := range videos {
+		if video.Rating > 0 {
+			sum += video.Rating
+			rated++
+		}
+	}
+	if rated == 0 {
+		return 0
+	}
+	return sum / float64(rated)
+}
+
+var slugRegex = regexp.MustCompile(`[^a-z0-9]+`)
+
+func uniqueSlug(name string, seen map[string]int) string {
+	slug := strings.Trim(slugRegex.ReplaceAllString(strings.ToLower(name), "-"), "-")
+	if slug == "" {
+		slug = "channel"
+	}
+	seen[slug]++
+	if n := seen[slug]; n > 1 {
+		slug = slug + "-" + strconv.Itoa(n)
+	}
+	return slug
+}
@@ -0,0 +1,100 @@
+package journal
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mamuzad/vidlogd/internal/models"
+)
+
+func testVideos() []models.Video {
+	day := func(y int, m time.Month, d int) time.Time { return time.Date(y, m, d, 12, 0, 0, 0, time.UTC) }
+	return []models.Video{
+		{Title: "intro to <go>", Channel: "Gophers", URL: "https://youtu.be/a", LogDate: day(2025, 1, 5), Rating: 4.5, Review: "great\nwatch"},
+		{Title: "channels", Channel: "Gophers", LogDate: day(2025, 2, 1), Rating: 3},
+		{Title: "rust", Channel: "Crabs", LogDate: day(2025, 2, 10), Rewatched: true},
+		{Title: "old", Channel: "Crabs", LogDate: day(2024, 6, 1), Rating: 5},
+	}
+}
+
+func TestNew_GroupsByMonthAndChannel(t *testing.T) {
+	j := New(testVideos(), Options{From: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)})
+
+	if len(j.Videos) != 3 {
+		t.Fatalf("expected 3 videos in range, got %d", len(j.Videos))
+	}
+	if len(j.Months) != 2 || j.Months[0].Key != "2025-02" || len(j.Months[0].Videos) != 2 {
+		t.Fatalf("unexpected months: %+v", j.Months)
+	}
+	if len(j.Channels) != 2 || j.Channels[0].Name != "Gophers" || j.Channels[0].AvgRating != 3.75 {
+		t.Fatalf("unexpected channels: %+v", j.Channels)
+	}
+	if j.Channels[1].Slug != "crabs" {
+		t.Fatalf("unexpected slug %q", j.Channels[1].Slug)
+	}
+
+	labels, values := j.MonthlyCounts()
+	if strings.Join(labels, ",") != "2025-01,2025-02" || values[0] != 1 || values[1] != 2 {
+		t.Fatalf("unexpected monthly counts: %v %v", labels, values)
+	}
+}
+
+func TestRatingCounts(t *testing.T) {
+	j := New([]models.Video{{Title: "a", Rating: 0.5}, {Title: "b", Rating: 5}, {Title: "c"}}, Options{})
+
+	labels, values := j.RatingCounts()
+	if labels[0] != "0.5" || values[0] != 1 || values[len(values)-1] != 1 {
+		t.Fatalf("unexpected rating counts: %v %v", labels, values)
+	}
+}
+
+func TestWriteMarkdown(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WriteMarkdown(&buf, New(testVideos(), Options{Title: "Q1 digest"})); err != nil {
+		t.Fatalf("WriteMarkdown: %v", err)
+	}
+	out := buf.String()
+
+	for _, want := range []string{
+		"# Q1 digest",
+		"4 videos · average rating 4.2/5 · 2 channels",
+		"<svg",
+		"### January 2025",
+		"[intro to &lt;go>](https://youtu.be/a)",
+		"★★★★⯨",
+		"  > great\n  > watch",
+		"### Crabs",
+		"rewatched",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("markdown missing %q", want)
+		}
+	}
+}
+
+func TestWriteSite(t *testing.T) {
+	dir := t.TempDir()
+	if err := WriteSite(dir, New(testVideos(), Options{})); err != nil {
+		t.Fatalf("WriteSite: %v", err)
+	}
+
+	for _, name := range []string{"index.html", "months/2025-01.html", "months/2024-06.html", "channels/gophers.html", "channels/crabs.html"} {
+		if _, err := os.Stat(filepath.Join(dir, name)); err != nil {
+			t.Errorf("expected %s: %v", name, err)
+		}
+	}
+
+	index, _ := os.ReadFile(filepath.Join(dir, "index.html"))
+	if !strings.Contains(string(index), "<svg") || !strings.Contains(string(index), `href="channels/gophers.html"`) {
+		t.Fatalf("index missing chart or channel link:\n%s", index)
+	}
+
+	page, _ := os.ReadFile(filepath.Join(dir, "months", "2025-01.html"))
+	if !strings.Contains(string(page), "intro to &lt;go&gt;") {
+		t.Fatalf("month page should escape titles:\n%s", page)
+	}
+}
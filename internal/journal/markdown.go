@@ -0,0 +1,91 @@
+package journal
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/mamuzad/vidlogd/internal/markup"
+	"github.com/mamuzad/vidlogd/internal/models"
+)
+
+// WriteMarkdown writes the journal as a single Markdown document.
+// Charts are embedded as inline SVG, which most Markdown previewers render.
+func WriteMarkdown(w io.Writer, j Journal) error {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "# %s\n\n", j.Title)
+	b.WriteString(summaryLine(j) + "\n\n")
+
+	if len(j.Videos) > 0 {
+		ratingLabels, ratingValues := j.RatingCounts()
+		monthLabels, monthValues := j.MonthlyCounts()
+		b.WriteString(BarChartSVG("Ratings", ratingLabels, ratingValues) + "\n\n")
+		b.WriteString(BarChartSVG("Videos per month", monthLabels, monthValues) + "\n\n")
+	}
+
+	b.WriteString("## Months\n\n")
+	for _, month := range j.Months {
+		fmt.Fprintf(&b, "### %s\n\n", month.Label)
+		for _, video := range month.Videos {
+			writeMarkdownVideo(&b, video, true)
+		}
+	}
+
+	b.WriteString("## Channels\n\n")
+	for _, channel := range j.Channels {
+		fmt.Fprintf(&b, "### %s\n\n", markup.EscapeMarkdown(channel.Name))
+		b.WriteString(channelSummary(channel) + "\n\n")
+		for _, video := range channel.Videos {
+			writeMarkdownVideo(&b, video, false)
+		}
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+func writeMarkdownVideo(b *strings.Builder, video models.Video, showChannel bool) {
+	title := markup.EscapeMarkdown(markup.VideoTitle(video))
+	if video.URL != "" {
+		title = fmt.Sprintf("[%s](%s)", title, video.URL)
+	}
+
+	fmt.Fprintf(b, "- **%s**", title)
+	if showChannel {
+		fmt.Fprintf(b, " · %s", markup.EscapeMarkdown(video.Channel))
+	}
+	if video.Rating > 0 {
+		fmt.Fprintf(b, " · %s", models.RatingStars(video.Rating))
+	}
+	if video.Rewatched {
+		b.WriteString(" · rewatched")
+	}
+	if !video.LogDate.IsZero() {
+		fmt.Fprintf(b, " · %s", video.LogDate.Format(models.ISODateFormat))
+	}
+	b.WriteString("\n")
+
+	if review := strings.TrimSpace(video.Review); review != "" {
+		for _, line := range strings.Split(review, "\n") {
+			fmt.Fprintf(b, "  > %s\n", line)
+		}
+	}
+	b.WriteString("\n")
+}
+
+func summaryLine(j Journal) string {
+	parts := []string{fmt.Sprintf("%d videos", len(j.Videos))}
+	if j.AvgRating > 0 {
+		parts = append(parts, fmt.Sprintf("average rating %.1f/5", j.AvgRating))
+	}
+	parts = append(parts, fmt.Sprintf("%d channels", len(j.Channels)))
+	return strings.Join(parts, " · ")
+}
+
+func channelSummary(c Channel) string {
+	if c.AvgRating > 0 {
+		return fmt.Sprintf("%d videos · average rating %.1f/5", len(c.Videos), c.AvgRating)
+	}
+	return fmt.Sprintf("%d videos", len(c.Videos))
+}
@@ -0,0 +1,54 @@
+package journal
+
+import (
+	"fmt"
+	"html"
+	"strings"
+)
+
+const (
+	svgBarWidth  = 36
+	svgBarGap    = 8
+	svgBarHeight = 120
+	svgPadding   = 24
+)
+
+// BarChartSVG renders a vertical bar chart as a standalone inline SVG
+func BarChartSVG(title string, labels []string, values []int) string {
+	maxValue := 0
+	for _, v := range values {
+		maxValue = max(maxValue, v)
+	}
+
+	width := svgPadding*2 + len(values)*(svgBarWidth+svgBarGap)
+	height := svgBarHeight + svgPadding*3
+
+	var b strings.Builder
+	fmt.Fprintf(&b, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %d %d" role="img" aria-label="%s">`,
+		width, height, width, height, html.EscapeString(title))
+	fmt.Fprintf(&b, `<text x="%d" y="16" font-family="sans-serif" font-size="13" font-weight="bold">%s</text>`,
+		svgPadding, html.EscapeString(title))
+
+	baseline := svgPadding + svgBarHeight
+	for i, v := range values {
+		x := svgPadding + i*(svgBarWidth+svgBarGap)
+		h := 0
+		if maxValue > 0 && v > 0 {
+			h = max(1, svgBarHeight*v/maxValue)
+		}
+		fmt.Fprintf(&b, `<rect x="%d" y="%d" width="%d" height="%d" fill="currentColor" opacity="0.8"/>`,
+			x, baseline-h, svgBarWidth, h)
+		fmt.Fprintf(&b, `<text x="%d" y="%d" font-family="sans-serif" font-size="11" text-anchor="middle">%d</text>`,
+			x+svgBarWidth/2, baseline-h-4, v)
+
+		label := ""
+		if i < len(labels) {
+			label = labels[i]
+		}
+		fmt.Fprintf(&b, `<text x="%d" y="%d" font-family="sans-serif" font-size="10" text-anchor="middle">%s</text>`,
+			x+svgBarWidth/2, baseline+14, html.EscapeString(label))
+	}
+
+	b.WriteString(`</svg>`)
+	return b.String()
+}
@@ -0,0 +1,44 @@
+// Package markup holds the Markdown and HTML pieces shared by the journal
+// and the year in review exports, so both render videos the same way.
+package markup
+
+import (
+	"html/template"
+	"strings"
+
+	"github.com/mamuzad/vidlogd/internal/models"
+)
+
+// VideoTitle is a video's title, or "Untitled" when it has none
+func VideoTitle(video models.Video) string {
+	if video.Title == "" {
+		return "Untitled"
+	}
+	return video.Title
+}
+
+var markdownReplacer = strings.NewReplacer(
+	`\`, `\\`, "*", `\*`, "_", `\_`, "[", `\[`, "]", `\]`, "`", "\\`", "<", "&lt;",
+)
+
+// EscapeMarkdown escapes the characters Markdown would read as formatting
+func EscapeMarkdown(s string) string {
+	return markdownReplacer.Replace(s)
+}
+
+// Style is the base stylesheet of the exported pages, maxWidth wide, e.g.
+// "52rem". Pages add their own rules after it.
+func Style(maxWidth string) template.CSS {
+	return template.CSS("body{font-family:system-ui,sans-serif;max-width:" + maxWidth + ";margin:2rem auto;padding:0 1rem;line-height:1.5;color:#222}\n" +
+		"a{color:#b71c1c}.meta{color:#666;font-size:.9rem}.stars{color:#b71c1c;letter-spacing:.1em}")
+}
+
+// Funcs returns the template functions the exported pages share: stars,
+// title and style. Callers may add their own.
+func Funcs() template.FuncMap {
+	return template.FuncMap{
+		"stars": models.RatingStars,
+		"title": VideoTitle,
+		"style": Style,
+	}
+}
@@ -0,0 +1,30 @@
+package markup
+
+import (
+	"bytes"
+	"html/template"
+	"strings"
+	"testing"
+
+	"github.com/mamuzad/vidlogd/internal/models"
+)
+
+func TestEscapeMarkdown(t *testing.T) {
+	if got := EscapeMarkdown("a_b *c* [d] <e>"); got != `a\_b \*c\* \[d\] &lt;e>` {
+		t.Errorf("unexpected escape %q", got)
+	}
+	if got := VideoTitle(models.Video{}); got != "Untitled" {
+		t.Errorf("expected a placeholder title, got %q", got)
+	}
+}
+
+func TestStyle(t *testing.T) {
+	tmpl := template.Must(template.New("page").Funcs(Funcs()).Parse(`<style>{{style "40rem"}}</style>`))
+	var b bytes.Buffer
+	if err := tmpl.Execute(&b, nil); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(b.String(), "max-width:40rem;") || strings.Contains(b.String(), "ZgotmplZ") {
+		t.Errorf("expected the stylesheet verbatim, got %s", b.String())
+	}
+}
@@ -0,0 +1,209 @@
+package models
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"os"
+	"strings"
+
+	"github.com/mamuzad/vidlogd/internal/storage"
+)
+
+// ChannelInfo holds what the user keeps about a channel beyond its videos
+type ChannelInfo struct {
+	Name     string   `json:"name"`
+	URL      string   `json:"url,omitempty"`
+	Notes    string   `json:"notes,omitempty"`
+	Favorite bool     `json:"favorite,omitempty"`
+	Aliases  []string `json:"aliases,omitempty"` // other names merged into this channel
+}
+
+// IsEmpty reports whether there is nothing worth saving
+func (c ChannelInfo) IsEmpty() bool {
+	return c.URL == "" && c.Notes == "" && !c.Favorite && len(c.Aliases) == 0
+}
+
+// Link returns the channel's URL, its YouTube page when the channel ID is
+// known, or a YouTube channel search for its name
+func (c ChannelInfo) Link(channelID string) string {
+	if c.URL != "" {
+		return c.URL
+	}
+	if channelID != "" {
+		return "https://www.youtube.com/channel/" + channelID
+	}
+	return "https://www.youtube.com/results?search_query=" + url.QueryEscape(c.Name) + "&sp=EgIQAg%3D%3D"
+}
+
+// FindChannel returns the info saved for name, or empty info
+func FindChannel(channels []ChannelInfo, name string) ChannelInfo {
+	for _, c := range channels {
+		if strings.EqualFold(c.Name, name) {
+			return c
+		}
+	}
+	return ChannelInfo{Name: name}
+}
+
+// LoadChannels loads the saved channel info, empty when none was saved
+func LoadChannels() ([]ChannelInfo, error) {
+	channelsPath, err := storage.ChannelsPath()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get channels file path: %w", err)
+	}
+
+	data, err := os.ReadFile(channelsPath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return []ChannelInfo{}, nil
+		}
+		return nil, fmt.Errorf("failed to read channels file: %w", err)
+	}
+
+	var channels []ChannelInfo
+	if err := json.Unmarshal(data, &channels); err != nil {
+		return nil, fmt.Errorf("failed to parse channels file: %w", err)
+	}
+
+	return channels, nil
+}
+
+// SaveChannels replaces the saved channel info
+func SaveChannels(channels []ChannelInfo) error {
+	channelsPath, err := storage.ChannelsPath()
+	if err != nil {
+		return fmt.Errorf("failed to get channels file path: %w", err)
+	}
+
+	data, err := json.MarshalIndent(channels, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode channels: %w", err)
+	}
+
+	return storage.WriteFileAtomic(channelsPath, data, 0o644)
+}
+
+// UpsertChannel saves c by name, dropping it once it holds nothing. A name
+// belongs to one channel, so c's aliases are taken from any other channel.
+func UpsertChannel(c ChannelInfo) ([]ChannelInfo, error) {
+	channels, err := LoadChannels()
+	if err != nil {
+		return nil, err
+	}
+
+	kept := channels[:0]
+	for _, existing := range channels {
+		if strings.EqualFold(existing.Name, c.Name) {
+			continue
+		}
+		var aliases []string
+		for _, alias := range existing.Aliases {
+			if !containsFold(c.Aliases, alias) && !strings.EqualFold(alias, c.Name) {
+				aliases = append(aliases, alias)
+			}
+		}
+		existing.Aliases = aliases
+		if !existing.IsEmpty() {
+			kept = append(kept, existing)
+		}
+	}
+	if !c.IsEmpty() {
+		kept = append(kept, c)
+	}
+
+	return kept, SaveChannels(kept)
+}
+
+func containsFold(names []string, name string) bool {
+	for _, n := range names {
+		if strings.EqualFold(n, name) {
+			return true
+		}
+	}
+	return false
+}
+
+// ChannelMap resolves the channel a video belongs to once aliases and
+// YouTube channel IDs are taken into account
+type ChannelMap struct {
+	names map[string]string // lower case alias to channel name
+	ids   map[string]string // channel ID to channel name
+}
+
+// NewChannelMap builds a ChannelMap from the saved channels. Videos sharing
+// a channel ID are grouped under the name of the most recently logged one.
+func NewChannelMap(channels []ChannelInfo, videos []Video) ChannelMap {
+	m := ChannelMap{names: make(map[string]string), ids: make(map[string]string)}
+	for _, c := range channels {
+		for _, alias := range c.Aliases {
+			if !strings.EqualFold(alias, c.Name) {
+				m.names[strings.ToLower(alias)] = c.Name
+			}
+		}
+	}
+
+	latest := make(map[string]Video)
+	for _, video := range videos {
+		if video.ChannelID == "" || video.Channel == "" {
+			continue
+		}
+		if prev, ok := latest[video.ChannelID]; !ok || video.LogDate.After(prev.LogDate) {
+			latest[video.ChannelID] = video
+		}
+	}
+	for id, video := range latest {
+		m.ids[id] = m.name(video.Channel)
+	}
+	return m
+}
+
+// name follows aliases from name, stopping at loops
+func (m ChannelMap) name(name string) string {
+	seen := map[string]bool{}
+	for {
+		key := strings.ToLower(name)
+		next, ok := m.names[key]
+		if !ok || seen[key] {
+			return name
+		}
+		seen[key] = true
+		name = next
+	}
+}
+
+// Resolve returns the name video's channel is grouped under
+func (m ChannelMap) Resolve(video Video) string {
+	if name, ok := m.ids[video.ChannelID]; ok && video.ChannelID != "" {
+		return name
+	}
+	if video.Channel == "" {
+		return ""
+	}
+	return m.name(video.Channel)
+}
+
+// Apply returns copies of videos with their channels resolved
+func (m ChannelMap) Apply(videos []Video) []Video {
+	resolved := make([]Video, len(videos))
+	for i, video := range videos {
+		video.Channel = m.Resolve(video)
+		resolved[i] = video
+	}
+	return resolved
+}
+
+// LoadMergedVideos loads the log with merged channels under one name, for
+// views and reports. Edits should go through LoadVideos so the logged
+// names are kept.
+func LoadMergedVideos() ([]Video, error) {
+	videos, err := LoadVideos()
+	if err != nil {
+		return nil, err
+	}
+	channels, err := LoadChannels()
+	if err != nil {
+		return nil, err
+	}
+	return NewChannelMap(channels, videos).Apply(videos), nil
+}
@@ -0,0 +1,93 @@
+package models
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestChannelInfo_Link(t *testing.T) {
+	if got := (ChannelInfo{Name: "Go Time", URL: "https://youtube.com/@gotime"}).Link("UC1"); got != "https://youtube.com/@gotime" {
+		t.Errorf("got %q", got)
+	}
+	if got := (ChannelInfo{Name: "Go Time"}).Link("UC1"); got != "https://www.youtube.com/channel/UC1" {
+		t.Errorf("got %q", got)
+	}
+	if got := (ChannelInfo{Name: "Go Time"}).Link(""); !strings.Contains(got, "search_query=Go+Time") {
+		t.Errorf("expected a search link, got %q", got)
+	}
+}
+
+func TestChannels_Upsert(t *testing.T) {
+	t.Setenv("XDG_DATA_HOME", t.TempDir())
+
+	if _, err := UpsertChannel(ChannelInfo{Name: "Fireship", Favorite: true}); err != nil {
+		t.Fatalf("UpsertChannel: %v", err)
+	}
+	if _, err := UpsertChannel(ChannelInfo{Name: "Go Time", Notes: "podcast"}); err != nil {
+		t.Fatalf("UpsertChannel: %v", err)
+	}
+
+	channels, err := LoadChannels()
+	if err != nil {
+		t.Fatalf("LoadChannels: %v", err)
+	}
+	if c := FindChannel(channels, "fireship"); !c.Favorite {
+		t.Errorf("expected fireship to be a favorite: %+v", c)
+	}
+	if c := FindChannel(channels, "unknown"); c.Name != "unknown" || !c.IsEmpty() {
+		t.Errorf("expected empty info, got %+v", c)
+	}
+
+	// clearing everything drops the entry
+	channels, err = UpsertChannel(ChannelInfo{Name: "FIRESHIP"})
+	if err != nil {
+		t.Fatalf("UpsertChannel: %v", err)
+	}
+	if len(channels) != 1 || channels[0].Name != "Go Time" {
+		t.Fatalf("unexpected channels: %+v", channels)
+	}
+}
+
+func TestChannelMap(t *testing.T) {
+	videos := []Video{
+		{Channel: "Old Name", ChannelID: "UC1", LogDate: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)},
+		{Channel: "New Name", ChannelID: "UC1", LogDate: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)},
+		{Channel: "fireshp"},
+		{Channel: "Fireship"},
+		{Channel: "loop a"},
+		{},
+	}
+	channels := []ChannelInfo{
+		{Name: "Fireship", Aliases: []string{"Fireshp"}},
+		{Name: "loop a", Aliases: []string{"loop b"}},
+		{Name: "loop b", Aliases: []string{"loop a"}},
+	}
+
+	m := NewChannelMap(channels, videos)
+	want := []string{"New Name", "New Name", "Fireship", "Fireship", "loop a", ""}
+	for i, video := range m.Apply(videos) {
+		if video.Channel != want[i] {
+			t.Errorf("video %d: got %q, want %q", i, video.Channel, want[i])
+		}
+	}
+	if videos[2].Channel != "fireshp" {
+		t.Error("Apply should not change the logged videos")
+	}
+}
+
+func TestUpsertChannel_MovesAliases(t *testing.T) {
+	t.Setenv("XDG_DATA_HOME", t.TempDir())
+
+	if _, err := UpsertChannel(ChannelInfo{Name: "A", Aliases: []string{"typo"}}); err != nil {
+		t.Fatalf("UpsertChannel: %v", err)
+	}
+	channels, err := UpsertChannel(ChannelInfo{Name: "B", Aliases: []string{"TYPO"}})
+	if err != nil {
+		t.Fatalf("UpsertChannel: %v", err)
+	}
+	// A held nothing but the alias and is dropped
+	if len(channels) != 1 || channels[0].Name != "B" {
+		t.Fatalf("unexpected channels: %+v", channels)
+	}
+}
@@ -0,0 +1,88 @@
+package models
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/mamuzad/vidlogd/internal/storage"
+)
+
+// errorLogLimit is how many errors the log keeps
+const errorLogLimit = 200
+
+// ErrorEntry is a failure that was reported to the user
+type ErrorEntry struct {
+	Time    time.Time `json:"time"`
+	Profile string    `json:"profile"`
+	Context string    `json:"context"` // what was being done, e.g. "save video"
+	Message string    `json:"message"`
+}
+
+// LoadErrorLog loads the logged errors, oldest first
+func LoadErrorLog() ([]ErrorEntry, error) {
+	logPath, err := storage.ErrorLogPath()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get error log path: %w", err)
+	}
+
+	data, err := os.ReadFile(logPath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return []ErrorEntry{}, nil
+		}
+		return nil, fmt.Errorf("failed to read error log: %w", err)
+	}
+
+	var entries []ErrorEntry
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return nil, fmt.Errorf("failed to parse error log: %w", err)
+	}
+
+	return entries, nil
+}
+
+// errorLogMu serializes writes, which run as concurrent commands
+var errorLogMu sync.Mutex
+
+// AppendErrorLog adds entry to the log, dropping the oldest entries past
+// the limit
+func AppendErrorLog(entry ErrorEntry) error {
+	errorLogMu.Lock()
+	defer errorLogMu.Unlock()
+
+	entries, err := LoadErrorLog()
+	if err != nil {
+		// a broken log should not hide new errors
+		entries = nil
+	}
+
+	entries = append(entries, entry)
+	if len(entries) > errorLogLimit {
+		entries = entries[len(entries)-errorLogLimit:]
+	}
+	return saveErrorLog(entries)
+}
+
+// ClearErrorLog removes every logged error
+func ClearErrorLog() error {
+	errorLogMu.Lock()
+	defer errorLogMu.Unlock()
+	return saveErrorLog([]ErrorEntry{})
+}
+
+func saveErrorLog(entries []ErrorEntry) error {
+	logPath, err := storage.ErrorLogPath()
+	if err != nil {
+		return fmt.Errorf("failed to get error log path: %w", err)
+	}
+
+	data, err := json.MarshalIndent(entries, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode error log: %w", err)
+	}
+
+	return storage.WriteFileAtomic(logPath, data, 0o644)
+}
@@ -0,0 +1,32 @@
+package models
+
+import (
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestErrorLog(t *testing.T) {
+	t.Setenv("XDG_DATA_HOME", t.TempDir())
+
+	for i := 0; i < errorLogLimit+5; i++ {
+		if err := AppendErrorLog(ErrorEntry{Time: time.Now(), Context: "save video", Message: fmt.Sprint(i)}); err != nil {
+			t.Fatalf("AppendErrorLog: %v", err)
+		}
+	}
+
+	entries, err := LoadErrorLog()
+	if err != nil {
+		t.Fatalf("LoadErrorLog: %v", err)
+	}
+	if len(entries) != errorLogLimit || entries[0].Message != "5" {
+		t.Fatalf("expected the oldest entries to be dropped, got %d starting at %q", len(entries), entries[0].Message)
+	}
+
+	if err := ClearErrorLog(); err != nil {
+		t.Fatalf("ClearErrorLog: %v", err)
+	}
+	if entries, _ := LoadErrorLog(); len(entries) != 0 {
+		t.Fatalf("expected an empty log, got %d entries", len(entries))
+	}
+}
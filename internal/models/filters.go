@@ -0,0 +1,128 @@
+package models
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/mamuzad/vidlogd/internal/storage"
+)
+
+// SavedFilter is a named smart list over the log
+type SavedFilter struct {
+	Name      string  `json:"name"`
+	Query     string  `json:"query,omitempty"` // search text, may use qualifiers
+	Channel   string  `json:"channel,omitempty"`
+	MinRating float64 `json:"min_rating,omitempty"`
+	MaxRating float64 `json:"max_rating,omitempty"`
+	From      string  `json:"from,omitempty"`      // YYYY-MM-DD, inclusive
+	To        string  `json:"to,omitempty"`        // YYYY-MM-DD, inclusive
+	Rewatched string  `json:"rewatched,omitempty"` // "yes", "no" or "" for either
+}
+
+// QueryString returns the filter in search query syntax
+func (f SavedFilter) QueryString() string {
+	var parts []string
+	if q := strings.TrimSpace(f.Query); q != "" {
+		parts = append(parts, q)
+	}
+	if f.Channel != "" {
+		parts = append(parts, `channel:"`+strings.ReplaceAll(f.Channel, `"`, "")+`"`)
+	}
+	if f.MinRating > 0 || f.MaxRating > 0 {
+		parts = append(parts, "rating:"+formatRating(f.MinRating)+".."+formatRating(f.MaxRating))
+	}
+	if f.From != "" || f.To != "" {
+		parts = append(parts, "logged:"+f.From+".."+f.To)
+	}
+	if f.Rewatched != "" {
+		parts = append(parts, "rewatched:"+f.Rewatched)
+	}
+	return strings.Join(parts, " ")
+}
+
+func formatRating(r float64) string {
+	if r == 0 {
+		return ""
+	}
+	return strconv.FormatFloat(r, 'g', -1, 64)
+}
+
+// LoadFilters loads the saved filters, empty when none were saved
+func LoadFilters() ([]SavedFilter, error) {
+	filtersPath, err := storage.FiltersPath()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get filters file path: %w", err)
+	}
+
+	data, err := os.ReadFile(filtersPath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return []SavedFilter{}, nil
+		}
+		return nil, fmt.Errorf("failed to read filters file: %w", err)
+	}
+
+	var filters []SavedFilter
+	if err := json.Unmarshal(data, &filters); err != nil {
+		return nil, fmt.Errorf("failed to parse filters file: %w", err)
+	}
+
+	return filters, nil
+}
+
+// SaveFilters replaces the saved filters
+func SaveFilters(filters []SavedFilter) error {
+	filtersPath, err := storage.FiltersPath()
+	if err != nil {
+		return fmt.Errorf("failed to get filters file path: %w", err)
+	}
+
+	data, err := json.MarshalIndent(filters, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode filters: %w", err)
+	}
+
+	return storage.WriteFileAtomic(filtersPath, data, 0o644)
+}
+
+// UpsertFilter saves f, replacing a filter with the same name
+func UpsertFilter(f SavedFilter) ([]SavedFilter, error) {
+	filters, err := LoadFilters()
+	if err != nil {
+		return nil, err
+	}
+
+	replaced := false
+	for i := range filters {
+		if strings.EqualFold(filters[i].Name, f.Name) {
+			filters[i] = f
+			replaced = true
+			break
+		}
+	}
+	if !replaced {
+		filters = append(filters, f)
+	}
+
+	return filters, SaveFilters(filters)
+}
+
+// DeleteFilter removes the filter with the given name
+func DeleteFilter(name string) ([]SavedFilter, error) {
+	filters, err := LoadFilters()
+	if err != nil {
+		return nil, err
+	}
+
+	kept := filters[:0]
+	for _, f := range filters {
+		if !strings.EqualFold(f.Name, name) {
+			kept = append(kept, f)
+		}
+	}
+
+	return kept, SaveFilters(kept)
+}
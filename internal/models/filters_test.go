@@ -0,0 +1,58 @@
+package models
+
+import "testing"
+
+func TestSavedFilter_QueryString(t *testing.T) {
+	tests := []struct {
+		filter SavedFilter
+		want   string
+	}{
+		{SavedFilter{Name: "all"}, ""},
+		{SavedFilter{Query: " go ", Channel: "Fireship"}, `go channel:"Fireship"`},
+		{SavedFilter{MinRating: 4}, "rating:4.."},
+		{SavedFilter{MinRating: 2.5, MaxRating: 4}, "rating:2.5..4"},
+		{SavedFilter{To: "2025-03-31"}, "logged:..2025-03-31"},
+		{SavedFilter{From: "2025-01-01", To: "2025-03-31", Rewatched: "no"}, "logged:2025-01-01..2025-03-31 rewatched:no"},
+	}
+
+	for _, tt := range tests {
+		if got := tt.filter.QueryString(); got != tt.want {
+			t.Errorf("%+v: got %q, want %q", tt.filter, got, tt.want)
+		}
+	}
+}
+
+func TestFilters_UpsertDelete(t *testing.T) {
+	t.Setenv("XDG_DATA_HOME", t.TempDir())
+
+	filters, err := LoadFilters()
+	if err != nil || len(filters) != 0 {
+		t.Fatalf("expected no filters, got %v, %v", filters, err)
+	}
+
+	if _, err := UpsertFilter(SavedFilter{Name: "Favorites", MinRating: 4.5}); err != nil {
+		t.Fatalf("UpsertFilter: %v", err)
+	}
+	if _, err := UpsertFilter(SavedFilter{Name: "rewatches", Rewatched: "yes"}); err != nil {
+		t.Fatalf("UpsertFilter: %v", err)
+	}
+	if _, err := UpsertFilter(SavedFilter{Name: "favorites", MinRating: 5}); err != nil {
+		t.Fatalf("UpsertFilter: %v", err)
+	}
+
+	filters, err = LoadFilters()
+	if err != nil {
+		t.Fatalf("LoadFilters: %v", err)
+	}
+	if len(filters) != 2 || filters[0].MinRating != 5 || filters[1].Name != "rewatches" {
+		t.Fatalf("unexpected filters after upsert: %+v", filters)
+	}
+
+	filters, err = DeleteFilter("FAVORITES")
+	if err != nil {
+		t.Fatalf("DeleteFilter: %v", err)
+	}
+	if len(filters) != 1 || filters[0].Name != "rewatches" {
+		t.Fatalf("unexpected filters after delete: %+v", filters)
+	}
+}
@@ -0,0 +1,176 @@
+package models
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/mamuzad/vidlogd/internal/storage"
+)
+
+// goal kinds
+const (
+	GoalAtLeast = "at_least" // a target to reach
+	GoalAtMost  = "at_most"  // a limit not to exceed
+)
+
+// goal metrics
+const (
+	GoalVideos = "videos"
+	GoalHours  = "hours"
+)
+
+// GoalPeriods lists the periods a goal can be measured over
+var GoalPeriods = []string{"day", "week", "month"}
+
+// Goal is a viewing target or limit per day, week or month, such as
+// "at least 3 videos tagged learning per week"
+type Goal struct {
+	Name   string  `json:"name"`
+	Kind   string  `json:"kind"`   // GoalAtLeast or GoalAtMost
+	Target float64 `json:"target"` // videos or hours per period
+	Metric string  `json:"metric"` // GoalVideos or GoalHours
+	Period string  `json:"period"` // "day", "week" or "month"
+	Tag    string  `json:"tag,omitempty"`
+}
+
+// IsLimit reports whether the goal is a limit rather than a target
+func (g Goal) IsLimit() bool {
+	return g.Kind == GoalAtMost
+}
+
+// String describes the goal in words
+func (g Goal) String() string {
+	kind := "at least"
+	if g.IsLimit() {
+		kind = "at most"
+	}
+	metric := g.Metric
+	if g.Target == 1 {
+		metric = strings.TrimSuffix(metric, "s")
+	}
+	s := fmt.Sprintf("%s %s %s", kind, strconv.FormatFloat(g.Target, 'g', -1, 64), metric)
+	if g.Tag != "" {
+		s += " tagged " + g.Tag
+	}
+	return s + " per " + g.Period
+}
+
+// Validate checks the goal can be tracked
+func (g Goal) Validate() error {
+	if strings.TrimSpace(g.Name) == "" {
+		return fmt.Errorf("goal needs a name")
+	}
+	if g.Kind != GoalAtLeast && g.Kind != GoalAtMost {
+		return fmt.Errorf("goal must be at least or at most")
+	}
+	if g.Metric != GoalVideos && g.Metric != GoalHours {
+		return fmt.Errorf("goal must count videos or hours")
+	}
+	if g.Target <= 0 {
+		return fmt.Errorf("goal target must be above 0")
+	}
+	for _, p := range GoalPeriods {
+		if g.Period == p {
+			return nil
+		}
+	}
+	return fmt.Errorf("goal period must be day, week or month")
+}
+
+// HasTag reports whether video counts towards the goal
+func (g Goal) HasTag(video Video) bool {
+	if g.Tag == "" {
+		return true
+	}
+	want := strings.TrimPrefix(g.Tag, "#")
+	for _, tag := range video.Tags {
+		if strings.EqualFold(strings.TrimPrefix(tag, "#"), want) {
+			return true
+		}
+	}
+	return false
+}
+
+// LoadGoals loads the viewing goals, empty when none were set
+func LoadGoals() ([]Goal, error) {
+	goalsPath, err := storage.GoalsPath()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get goals file path: %w", err)
+	}
+
+	data, err := os.ReadFile(goalsPath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return []Goal{}, nil
+		}
+		return nil, fmt.Errorf("failed to read goals file: %w", err)
+	}
+
+	var goals []Goal
+	if err := json.Unmarshal(data, &goals); err != nil {
+		return nil, fmt.Errorf("failed to parse goals file: %w", err)
+	}
+
+	return goals, nil
+}
+
+// SaveGoals replaces the viewing goals
+func SaveGoals(goals []Goal) error {
+	goalsPath, err := storage.GoalsPath()
+	if err != nil {
+		return fmt.Errorf("failed to get goals file path: %w", err)
+	}
+
+	data, err := json.MarshalIndent(goals, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode goals: %w", err)
+	}
+
+	return storage.WriteFileAtomic(goalsPath, data, 0o644)
+}
+
+// UpsertGoal saves g, replacing the goal called previous or adding it when
+// previous is empty; names are unique regardless of case
+func UpsertGoal(previous string, g Goal) ([]Goal, error) {
+	goals, err := LoadGoals()
+	if err != nil {
+		return nil, err
+	}
+
+	index := -1
+	for i := range goals {
+		switch {
+		case previous != "" && strings.EqualFold(goals[i].Name, previous):
+			index = i
+		case strings.EqualFold(goals[i].Name, g.Name):
+			return nil, fmt.Errorf("a goal named %q already exists", goals[i].Name)
+		}
+	}
+	if index >= 0 {
+		goals[index] = g
+	} else {
+		goals = append(goals, g)
+	}
+
+	return goals, SaveGoals(goals)
+}
+
+// DeleteGoal removes the goal with the given name
+func DeleteGoal(name string) ([]Goal, error) {
+	goals, err := LoadGoals()
+	if err != nil {
+		return nil, err
+	}
+
+	kept := goals[:0]
+	for _, g := range goals {
+		if !strings.EqualFold(g.Name, name) {
+			kept = append(kept, g)
+		}
+	}
+
+	return kept, SaveGoals(kept)
+}
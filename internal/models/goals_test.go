@@ -0,0 +1,84 @@
+package models
+
+import "testing"
+
+func TestGoal_StringAndValidate(t *testing.T) {
+	tests := []struct {
+		goal  Goal
+		want  string
+		valid bool
+	}{
+		{Goal{Name: "monthly", Kind: GoalAtLeast, Target: 20, Metric: GoalVideos, Period: "month"}, "at least 20 videos per month", true},
+		{Goal{Name: "daily cap", Kind: GoalAtMost, Target: 2, Metric: GoalHours, Period: "day"}, "at most 2 hours per day", true},
+		{Goal{Name: "study", Kind: GoalAtLeast, Target: 3, Metric: GoalVideos, Period: "week", Tag: "learning"}, "at least 3 videos tagged learning per week", true},
+		{Goal{Name: "yearly", Kind: GoalAtLeast, Target: 1.5, Metric: GoalHours, Period: "year"}, "at least 1.5 hours per year", false},
+		{Goal{Name: "zero", Kind: GoalAtMost, Target: 0, Metric: GoalVideos, Period: "day"}, "at most 0 videos per day", false},
+		{Goal{Kind: GoalAtMost, Target: 1, Metric: GoalVideos, Period: "day"}, "at most 1 video per day", false},
+	}
+
+	for _, tt := range tests {
+		if got := tt.goal.String(); got != tt.want {
+			t.Errorf("String() = %q, want %q", got, tt.want)
+		}
+		if err := tt.goal.Validate(); (err == nil) != tt.valid {
+			t.Errorf("%q: Validate() = %v, want valid %v", tt.want, err, tt.valid)
+		}
+	}
+}
+
+func TestGoal_HasTag(t *testing.T) {
+	g := Goal{Tag: "#Learning"}
+	if !g.HasTag(Video{Tags: []string{"go", "learning"}}) {
+		t.Error("expected tags to match ignoring case and #")
+	}
+	if g.HasTag(Video{Tags: []string{"music"}}) {
+		t.Error("expected other tags not to match")
+	}
+	if !(Goal{}).HasTag(Video{}) {
+		t.Error("expected a goal without a tag to match every video")
+	}
+}
+
+func TestGoals_UpsertDelete(t *testing.T) {
+	t.Setenv("XDG_DATA_HOME", t.TempDir())
+
+	goals, err := LoadGoals()
+	if err != nil || len(goals) != 0 {
+		t.Fatalf("expected no goals, got %v, %v", goals, err)
+	}
+
+	if _, err := UpsertGoal("", Goal{Name: "Monthly", Target: 20}); err != nil {
+		t.Fatalf("UpsertGoal: %v", err)
+	}
+	if _, err := UpsertGoal("", Goal{Name: "cap", Target: 2}); err != nil {
+		t.Fatalf("UpsertGoal: %v", err)
+	}
+	// a new goal cannot take an existing name
+	if _, err := UpsertGoal("", Goal{Name: "monthly", Target: 5}); err == nil {
+		t.Fatal("expected a duplicate name to be rejected")
+	}
+	// nor can a renamed one
+	if _, err := UpsertGoal("cap", Goal{Name: "Monthly", Target: 2}); err == nil {
+		t.Fatal("expected a rename onto another goal to be rejected")
+	}
+	// renaming replaces the old entry in place
+	if _, err := UpsertGoal("monthly", Goal{Name: "per month", Target: 25}); err != nil {
+		t.Fatalf("UpsertGoal: %v", err)
+	}
+
+	goals, err = LoadGoals()
+	if err != nil {
+		t.Fatalf("LoadGoals: %v", err)
+	}
+	if len(goals) != 2 || goals[0].Name != "per month" || goals[0].Target != 25 {
+		t.Fatalf("unexpected goals after upsert: %+v", goals)
+	}
+
+	goals, err = DeleteGoal("CAP")
+	if err != nil {
+		t.Fatalf("DeleteGoal: %v", err)
+	}
+	if len(goals) != 1 || goals[0].Name != "per month" {
+		t.Fatalf("unexpected goals after delete: %+v", goals)
+	}
+}
@@ -0,0 +1,35 @@
+package models
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/mamuzad/vidlogd/internal/storage"
+)
+
+// Keybindings maps key map actions, e.g. "delete" or "ratingUp", to the
+// keys that replace their defaults. An empty list unbinds the action.
+type Keybindings map[string][]string
+
+// LoadKeybindings loads the user's keybindings, empty when there is no file
+func LoadKeybindings() (Keybindings, error) {
+	path, err := storage.KeybindingsPath()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get keybindings path: %w", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return Keybindings{}, nil
+		}
+		return nil, fmt.Errorf("failed to read keybindings: %w", err)
+	}
+
+	var bindings Keybindings
+	if err := json.Unmarshal(data, &bindings); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	return bindings, nil
+}
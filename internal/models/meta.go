@@ -0,0 +1,78 @@
+package models
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// FormatDuration formats seconds as m:ss or h:mm:ss, empty when unknown
+func FormatDuration(seconds int) string {
+	if seconds <= 0 {
+		return ""
+	}
+	h, m, s := seconds/3600, seconds/60%60, seconds%60
+	if h > 0 {
+		return fmt.Sprintf("%d:%02d:%02d", h, m, s)
+	}
+	return fmt.Sprintf("%d:%02d", m, s)
+}
+
+var isoDurationRegex = regexp.MustCompile(`^P(?:(\d+)D)?T?(?:(\d+)H)?(?:(\d+)M)?(?:(\d+)S)?$`)
+
+// ParseDuration reads seconds ("754"), clock time ("12:34", "1:02:03")
+// or an ISO 8601 duration as returned by the YouTube API ("PT12M34S")
+func ParseDuration(s string) (int, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return 0, nil
+	}
+
+	if match := isoDurationRegex.FindStringSubmatch(strings.ToUpper(s)); match != nil && s != "P" {
+		seconds := 0
+		for i, unit := range []int{86400, 3600, 60, 1} {
+			if match[i+1] != "" {
+				n, _ := strconv.Atoi(match[i+1])
+				seconds += n * unit
+			}
+		}
+		return seconds, nil
+	}
+
+	parts := strings.Split(s, ":")
+	if len(parts) > 3 {
+		return 0, fmt.Errorf("invalid duration %q", s)
+	}
+	seconds := 0
+	for _, part := range parts {
+		n, err := strconv.Atoi(part)
+		if err != nil || n < 0 {
+			return 0, fmt.Errorf("invalid duration %q", s)
+		}
+		seconds = seconds*60 + n
+	}
+	return seconds, nil
+}
+
+// ParseTags splits a comma or semicolon separated list into clean,
+// de-duplicated tags
+func ParseTags(s string) []string {
+	fields := strings.FieldsFunc(s, func(r rune) bool { return r == ',' || r == ';' })
+	return NormalizeTags(fields)
+}
+
+// NormalizeTags trims and lower cases tags, dropping blanks and duplicates
+func NormalizeTags(tags []string) []string {
+	var out []string
+	seen := make(map[string]bool)
+	for _, tag := range tags {
+		tag = strings.ToLower(strings.TrimSpace(tag))
+		if tag == "" || seen[tag] {
+			continue
+		}
+		seen[tag] = true
+		out = append(out, tag)
+	}
+	return out
+}
@@ -0,0 +1,48 @@
+package models
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseDuration(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    int
+		wantErr bool
+	}{
+		{"", 0, false},
+		{"754", 754, false},
+		{"12:34", 754, false},
+		{"1:02:03", 3723, false},
+		{"PT12M34S", 754, false},
+		{"PT1H", 3600, false},
+		{"P1DT2S", 86402, false},
+		{"pt45s", 45, false},
+		{"1:2:3:4", 0, true},
+		{"abc", 0, true},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseDuration(tt.in)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("ParseDuration(%q) = %d, %v; want %d, err %v", tt.in, got, err, tt.want, tt.wantErr)
+		}
+	}
+}
+
+func TestFormatDuration(t *testing.T) {
+	for seconds, want := range map[int]string{0: "", 45: "0:45", 754: "12:34", 3723: "1:02:03"} {
+		if got := FormatDuration(seconds); got != want {
+			t.Errorf("FormatDuration(%d) = %q, want %q", seconds, got, want)
+		}
+	}
+}
+
+func TestParseTags(t *testing.T) {
+	got := ParseTags(" Go, talks;go ,, Conference ")
+	want := []string{"go", "talks", "conference"}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("ParseTags = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,27 @@
+package models
+
+import (
+	"strconv"
+	"strings"
+)
+
+// RatingStars renders a rating as five stars, using a half star for .5
+func RatingStars(rating float64) string {
+	var stars strings.Builder
+	for i := 1; i <= 5; i++ {
+		starValue := float64(i)
+		if rating >= starValue {
+			stars.WriteString("★") // filled star
+		} else if rating >= starValue-0.5 {
+			stars.WriteString("⯨") // half star
+		} else {
+			stars.WriteString("☆")
+		}
+	}
+	return stars.String()
+}
+
+// RatingText renders a rating as plain text, e.g. "4.5/5"
+func RatingText(rating float64) string {
+	return strconv.FormatFloat(rating, 'f', -1, 64) + "/5"
+}
@@ -0,0 +1,99 @@
+package models
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/mamuzad/vidlogd/internal/storage"
+)
+
+// ThemeFile is a user theme read from the themes directory
+type ThemeFile struct {
+	Name   string               `json:"name"` // defaults to the file name
+	Dark   *ThemePalette        `json:"dark,omitempty"`
+	Light  *ThemePalette        `json:"light,omitempty"`
+	Styles map[string]StyleSpec `json:"styles,omitempty"` // keyed by style, e.g. "popup"
+}
+
+// ThemePalette holds a theme's colors for one terminal background. Empty
+// colors keep the default theme's.
+type ThemePalette struct {
+	Primary     string `json:"primary,omitempty"`
+	PrimaryBg   string `json:"primary_bg,omitempty"`
+	OnPrimary   string `json:"on_primary,omitempty"` // text on primary backgrounds
+	Text        string `json:"text,omitempty"`
+	Muted       string `json:"muted,omitempty"`
+	Danger      string `json:"danger,omitempty"`
+	Chart       string `json:"chart,omitempty"`
+	HeatmapBase string `json:"heatmap_base,omitempty"` // shade of days without videos
+}
+
+// StyleSpec changes one component style. Colors are a palette name such
+// as "primary" or "muted", a hex color or an ANSI color number.
+type StyleSpec struct {
+	Foreground string `json:"foreground,omitempty"`
+	Background string `json:"background,omitempty"`
+	Border     string `json:"border,omitempty"`
+	Bold       *bool  `json:"bold,omitempty"`
+	Faint      *bool  `json:"faint,omitempty"`
+	Italic     *bool  `json:"italic,omitempty"`
+	Underline  *bool  `json:"underline,omitempty"`
+}
+
+// LoadThemes reads every theme file, sorted by name. Files that cannot be
+// read, or that reuse one of the reserved names or the name of another
+// file, are skipped and reported in the error.
+func LoadThemes(reserved ...string) ([]ThemeFile, error) {
+	dir, err := storage.ThemesDir()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get themes directory: %w", err)
+	}
+
+	paths, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list themes: %w", err)
+	}
+
+	// the file each name came from, empty for reserved names
+	taken := make(map[string]string, len(reserved))
+	for _, name := range reserved {
+		taken[name] = ""
+	}
+
+	var themes []ThemeFile
+	var errs []error
+	for _, path := range paths {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to read theme: %w", err))
+			continue
+		}
+
+		var theme ThemeFile
+		if err := json.Unmarshal(data, &theme); err != nil {
+			errs = append(errs, fmt.Errorf("failed to parse %s: %w", filepath.Base(path), err))
+			continue
+		}
+		if strings.TrimSpace(theme.Name) == "" {
+			theme.Name = strings.TrimSuffix(filepath.Base(path), ".json")
+		}
+		if file, ok := taken[theme.Name]; ok {
+			if file == "" {
+				errs = append(errs, fmt.Errorf("%s: %q is the name of a built-in theme", filepath.Base(path), theme.Name))
+			} else {
+				errs = append(errs, fmt.Errorf("%s: %q is already the name of %s", filepath.Base(path), theme.Name, file))
+			}
+			continue
+		}
+		taken[theme.Name] = filepath.Base(path)
+		themes = append(themes, theme)
+	}
+
+	sort.Slice(themes, func(i, j int) bool { return themes[i].Name < themes[j].Name })
+	return themes, errors.Join(errs...)
+}
@@ -0,0 +1,59 @@
+package models
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/mamuzad/vidlogd/internal/storage"
+)
+
+func TestLoadThemes(t *testing.T) {
+	t.Setenv("XDG_DATA_HOME", t.TempDir())
+	dir, err := storage.ThemesDir()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if themes, err := LoadThemes(); err != nil || len(themes) != 0 {
+		t.Fatalf("expected no themes without a themes folder, got %v, %v", themes, err)
+	}
+
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	files := map[string]string{
+		"nord.json":   `{"dark": {"primary": "#88C0D0"}}`,
+		"paper.json":  `{"name": "Paper", "light": {"primary": "#005F87", "text": "#202020"}}`,
+		"broken.json": `{"dark": `,
+	}
+	for name, data := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(data), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	themes, err := LoadThemes()
+	if err == nil {
+		t.Error("expected the broken theme to be reported")
+	}
+	if len(themes) != 2 || themes[0].Name != "Paper" || themes[1].Name != "nord" {
+		t.Fatalf("expected Paper and nord, got %+v", themes)
+	}
+	if themes[1].Dark == nil || themes[1].Dark.Primary != "#88C0D0" {
+		t.Errorf("expected nord's dark palette, got %+v", themes[1].Dark)
+	}
+
+	// names are unique, reserved ones and those of earlier files are taken
+	if err := os.WriteFile(filepath.Join(dir, "sepia.json"), []byte(`{"name": "Paper"}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	themes, err = LoadThemes("nord")
+	if err == nil || !strings.Contains(err.Error(), `nord.json: "nord"`) || !strings.Contains(err.Error(), `sepia.json: "Paper"`) {
+		t.Errorf("expected both clashes reported, got %v", err)
+	}
+	if len(themes) != 1 || themes[0].Name != "Paper" || themes[0].Light == nil {
+		t.Fatalf("expected only the first Paper, got %+v", themes)
+	}
+}
@@ -7,18 +7,27 @@ type Video struct {
 	URL         string    `json:"url"`
 	Title       string    `json:"title"`
 	Channel     string    `json:"channel"`
+	ChannelID   string    `json:"channel_id,omitempty"` // YouTube channel ID, when fetched
 	ReleaseDate string    `json:"release_date"`
 	LogDate     time.Time `json:"log_date"`
 	Rating      float64   `json:"rating"`
 	Rewatched   bool      `json:"rewatched"`
 	Review      string    `json:"review"`
+	Tags        []string  `json:"tags,omitempty"`
+	Duration    int       `json:"duration,omitempty"` // seconds, 0 when unknown
+	Collection  string    `json:"collection,omitempty"`
 	CreatedAt   time.Time `json:"created_at"`
 }
 
 type AppSettings struct {
-	VimMotions bool   `json:"vim_motions"`
-	Theme      string `json:"theme"`
-	APIKey     string `json:"api_key"`
+	VimMotions bool     `json:"vim_motions"`
+	Theme      string   `json:"theme"`
+	Background string   `json:"background,omitempty"` // auto, dark or light
+	Symbols    string   `json:"symbols,omitempty"`    // auto, unicode or ascii
+	Layout     string   `json:"layout,omitempty"`     // auto, centered or linear
+	APIKey     string   `json:"api_key"`
+	LogColumns []string `json:"log_columns,omitempty"` // log list columns, in order
+	LogSort    string   `json:"log_sort,omitempty"`    // column:asc or column:desc
 }
 
 var (
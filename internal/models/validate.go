@@ -0,0 +1,52 @@
+package models
+
+import (
+	"math"
+	"regexp"
+	"time"
+)
+
+var (
+	dateRegex     = regexp.MustCompile(`^\d{4}-\d{2}-\d{2}$`)
+	dateHourRegex = regexp.MustCompile(`^\d{4}-\d{2}-\d{2} \d{1,2}:\d{2} (AM|PM)$`)
+)
+
+// IsValidDateTime validates if the string is a valid date in YYYY-MM-DD HH:MM AM/PM format
+func IsValidDateTime(dateStr string) bool {
+	if dateStr == "" {
+		return false
+	}
+
+	// check format with regex first - supports both 12:34 AM and 1:23 PM formats
+	if !dateHourRegex.MatchString(dateStr) {
+		return false
+	}
+
+	// try to parse the date to ensure it's actually valid
+	_, err := time.Parse(DateTimeFormat, dateStr)
+	return err == nil
+}
+
+// IsValidDate validates if the string is a valid date in YYYY-MM-DD format
+func IsValidDate(dateStr string) bool {
+	if dateStr == "" {
+		return false
+	}
+
+	// check format with regex first
+	if !dateRegex.MatchString(dateStr) {
+		return false
+	}
+
+	// try to parse the date to ensure it's actually valid
+	_, err := time.Parse(ISODateFormat, dateStr)
+	return err == nil
+}
+
+// IsValidRating reports whether rating is between 0 and 5 in 0.5 steps
+func IsValidRating(rating float64) bool {
+	if rating < 0 || rating > 5 {
+		return false
+	}
+	return math.Mod(rating*2, 1) == 0
+}
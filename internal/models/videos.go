@@ -46,7 +46,8 @@ func LoadVideos() ([]Video, error) {
 	return videos, nil
 }
 
-func generateVideoID() string {
+// NewVideoID returns a random ID for a new log entry
+func NewVideoID() string {
 	bytes := make([]byte, 8)
 	rand.Read(bytes)
 	return hex.EncodeToString(bytes)
@@ -60,7 +61,7 @@ func SaveVideo(video Video) error {
 
 	video.CreatedAt = time.Now()
 	if video.ID == "" {
-		video.ID = generateVideoID()
+		video.ID = NewVideoID()
 	}
 
 	videos = append(videos, video)
@@ -68,6 +69,31 @@ func SaveVideo(video Video) error {
 	return saveAll(videos)
 }
 
+// SaveVideos appends several videos in a single write
+func SaveVideos(newVideos []Video) error {
+	if len(newVideos) == 0 {
+		return nil
+	}
+
+	videos, err := LoadVideos()
+	if err != nil {
+		return fmt.Errorf("failed to load existing videos: %w", err)
+	}
+
+	now := time.Now()
+	for _, video := range newVideos {
+		if video.ID == "" {
+			video.ID = NewVideoID()
+		}
+		if video.CreatedAt.IsZero() {
+			video.CreatedAt = now
+		}
+		videos = append(videos, video)
+	}
+
+	return saveAll(videos)
+}
+
 func UpdateVideo(updatedVideo Video) error {
 	videos, err := LoadVideos()
 	if err != nil {
@@ -91,14 +117,14 @@ func UpdateVideo(updatedVideo Video) error {
 }
 
 // CreateVideo creates a new video with the given data
-func CreateVideo(url, title, channel, releaseDate, logDateStr, review string, rewatched bool, rating float64) Video {
+func CreateVideo(url, title, channel, releaseDate, logDateStr, review string, rewatched bool, rating float64) (Video, error) {
 	logDate, err := time.Parse(DateTimeFormat, logDateStr)
 	if err != nil {
-		panic(fmt.Sprintf("failed to parse logDate '%s' with layout '%s': %v", logDateStr, DateTimeFormat, err))
+		return Video{}, fmt.Errorf("log date %q must be YYYY-MM-DD HH:MM AM/PM: %w", logDateStr, err)
 	}
 
 	return Video{
-		ID:          generateVideoID(),
+		ID:          NewVideoID(),
 		URL:         url,
 		Title:       title,
 		Channel:     channel,
@@ -108,7 +134,7 @@ func CreateVideo(url, title, channel, releaseDate, logDateStr, review string, re
 		Rewatched:   rewatched,
 		Rating:      rating,
 		CreatedAt:   time.Now(),
-	}
+	}, nil
 }
 
 func FindVideoByID(id string) (*Video, error) {
@@ -149,6 +175,92 @@ func DeleteVideo(id string) error {
 	return saveAll(filteredVideos)
 }
 
+// UpdateVideos applies update to each video in ids with a single write,
+// returning how many were changed
+func UpdateVideos(ids []string, update func(*Video)) (int, error) {
+	videos, err := LoadVideos()
+	if err != nil {
+		return 0, fmt.Errorf("failed to load existing videos: %w", err)
+	}
+
+	wanted := idSet(ids)
+	updated := 0
+	for i := range videos {
+		if wanted[videos[i].ID] {
+			update(&videos[i])
+			updated++
+		}
+	}
+	if updated == 0 {
+		return 0, nil
+	}
+
+	return updated, saveAll(videos)
+}
+
+// DeleteVideos removes every video in ids with a single write, returning
+// how many were removed
+func DeleteVideos(ids []string) (int, error) {
+	videos, err := LoadVideos()
+	if err != nil {
+		return 0, fmt.Errorf("failed to load existing videos: %w", err)
+	}
+
+	wanted := idSet(ids)
+	kept := videos[:0]
+	for _, video := range videos {
+		if !wanted[video.ID] {
+			kept = append(kept, video)
+		}
+	}
+	deleted := len(videos) - len(kept)
+	if deleted == 0 {
+		return 0, nil
+	}
+
+	return deleted, saveAll(kept)
+}
+
+// MergeVideos replaces the video with merged's ID by merged and removes
+// the videos in remove, with a single write
+func MergeVideos(merged Video, remove []string) error {
+	videos, err := LoadVideos()
+	if err != nil {
+		return fmt.Errorf("failed to load existing videos: %w", err)
+	}
+
+	dropped := idSet(remove)
+	kept := videos[:0]
+	found := false
+	for _, video := range videos {
+		switch {
+		case video.ID == merged.ID:
+			kept = append(kept, merged)
+			found = true
+		case !dropped[video.ID]:
+			kept = append(kept, video)
+		}
+	}
+	if !found {
+		return fmt.Errorf("video with ID %s not found", merged.ID)
+	}
+
+	return saveAll(kept)
+}
+
+func idSet(ids []string) map[string]bool {
+	set := make(map[string]bool, len(ids))
+	for _, id := range ids {
+		set[id] = true
+	}
+	return set
+}
+
+// ReplaceVideos saves videos as the whole log
+func ReplaceVideos(videos []Video) error {
+	return saveAll(videos)
+}
+
 func VideoCount() (int, error) {
 	videos, err := LoadVideos()
 	return len(videos), err
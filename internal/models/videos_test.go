@@ -117,3 +117,56 @@ func TestVideoLifecycle_SaveUpdateDelete(t *testing.T) {
 		t.Error("expected error when deleting non-existent ID, got nil")
 	}
 }
+
+func TestBulkUpdateAndDelete(t *testing.T) {
+	t.Setenv("XDG_DATA_HOME", t.TempDir())
+
+	if err := SaveVideos([]Video{{ID: "a"}, {ID: "b"}, {ID: "c"}}); err != nil {
+		t.Fatalf("SaveVideos: %v", err)
+	}
+
+	updated, err := UpdateVideos([]string{"a", "c", "missing"}, func(v *Video) {
+		v.Rating = 4
+		v.Collection = "talks"
+	})
+	if err != nil || updated != 2 {
+		t.Fatalf("UpdateVideos = %d, %v; want 2", updated, err)
+	}
+
+	for _, id := range []string{"a", "c"} {
+		v, err := FindVideoByID(id)
+		if err != nil || v.Rating != 4 || v.Collection != "talks" {
+			t.Fatalf("video %s not updated: %+v, %v", id, v, err)
+		}
+	}
+
+	deleted, err := DeleteVideos([]string{"a", "b", "missing"})
+	if err != nil || deleted != 2 {
+		t.Fatalf("DeleteVideos = %d, %v; want 2", deleted, err)
+	}
+	if count, _ := VideoCount(); count != 1 {
+		t.Fatalf("expected 1 video left, got %d", count)
+	}
+}
+
+func TestMergeVideos(t *testing.T) {
+	t.Setenv("XDG_DATA_HOME", t.TempDir())
+
+	if err := SaveVideos([]Video{{ID: "a"}, {ID: "b"}, {ID: "c"}}); err != nil {
+		t.Fatalf("SaveVideos: %v", err)
+	}
+
+	if err := MergeVideos(Video{ID: "b", Title: "merged"}, []string{"a"}); err != nil {
+		t.Fatalf("MergeVideos: %v", err)
+	}
+	if count, _ := VideoCount(); count != 2 {
+		t.Fatalf("expected 2 videos left, got %d", count)
+	}
+	if v, err := FindVideoByID("b"); err != nil || v.Title != "merged" {
+		t.Fatalf("video b not replaced: %+v, %v", v, err)
+	}
+
+	if err := MergeVideos(Video{ID: "missing"}, []string{"c"}); err == nil {
+		t.Fatal("expected an error merging into a missing video")
+	}
+}
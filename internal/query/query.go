@@ -0,0 +1,415 @@
+// Package query parses and evaluates the library search language, e.g.
+//
+//	channel:"fireship" rating:>=4 logged:2025-01..2025-03 rewatched:yes "goroutine"
+//
+// Bare words and quoted phrases match the title, channel, review, tags and
+// collection. Qualifiers narrow by field, and a leading '-' negates a term or
+// qualifier.
+package query
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/mamuzad/vidlogd/internal/models"
+	"github.com/sahilm/fuzzy"
+)
+
+// field weights used when ranking text matches
+const (
+	weightTitle   = 3
+	weightChannel = 2
+	weightReview  = 1
+	weightTag     = 1
+	weightFuzzy   = 1
+)
+
+// Query is a parsed search
+type Query struct {
+	Terms   []Term
+	Filters []Filter
+}
+
+// Term is free text matched against one or all text fields
+type Term struct {
+	Text    string // lower case
+	Field   string // "" for any of title, channel, review, tags and collection
+	Negated bool
+}
+
+// Filter is a non-text qualifier such as rating:>=4
+type Filter struct {
+	Field   string
+	Negated bool
+	match   func(models.Video) bool
+}
+
+// Result is a matching video and its rank
+type Result struct {
+	Video models.Video
+	Score int
+}
+
+// text qualifiers and their aliases
+var textFields = map[string]string{
+	"title":      "title",
+	"channel":    "channel",
+	"review":     "review",
+	"notes":      "review",
+	"note":       "review",
+	"tag":        "tag",
+	"tags":       "tag",
+	"collection": "collection",
+}
+
+// Parse parses a query string. An empty string matches everything.
+func Parse(input string) (Query, error) {
+	var q Query
+
+	tokens, err := tokenize(input)
+	if err != nil {
+		return q, err
+	}
+
+	for _, tok := range tokens {
+		negated := false
+		if strings.HasPrefix(tok.text, "-") && len(tok.text) > 1 && !tok.quoted {
+			negated = true
+			tok.text = tok.text[1:]
+		}
+
+		name, value, hasQualifier := strings.Cut(tok.text, ":")
+		if !hasQualifier || tok.quoted || value == "" && !tok.valueQuoted {
+			q.Terms = append(q.Terms, Term{Text: strings.ToLower(tok.text), Negated: negated})
+			continue
+		}
+
+		name = strings.ToLower(name)
+		if field, ok := textFields[name]; ok {
+			q.Terms = append(q.Terms, Term{Text: strings.ToLower(value), Field: field, Negated: negated})
+			continue
+		}
+
+		filter, err := parseFilter(name, value)
+		if err != nil {
+			return Query{}, err
+		}
+		filter.Negated = negated
+		q.Filters = append(q.Filters, filter)
+	}
+
+	return q, nil
+}
+
+// IsEmpty reports whether the query has no terms or filters
+func (q Query) IsEmpty() bool {
+	return len(q.Terms) == 0 && len(q.Filters) == 0
+}
+
+// Highlights returns the positive text terms, for highlighting matches
+func (q Query) Highlights() []string {
+	var terms []string
+	for _, term := range q.Terms {
+		if !term.Negated && term.Text != "" {
+			terms = append(terms, term.Text)
+		}
+	}
+	return terms
+}
+
+// Match reports whether video matches and how well
+func (q Query) Match(video models.Video) (int, bool) {
+	for _, filter := range q.Filters {
+		if filter.match(video) == filter.Negated {
+			return 0, false
+		}
+	}
+
+	score := 0
+	for _, term := range q.Terms {
+		s := term.score(video)
+		if term.Negated {
+			if s > 0 {
+				return 0, false
+			}
+			continue
+		}
+		if s == 0 {
+			return 0, false
+		}
+		score += s
+	}
+
+	return score, true
+}
+
+// Search returns matching videos, best matches first and most recently
+// logged first among equals
+func Search(videos []models.Video, q Query) []Result {
+	var results []Result
+	for _, video := range videos {
+		if score, ok := q.Match(video); ok {
+			results = append(results, Result{Video: video, Score: score})
+		}
+	}
+
+	sort.SliceStable(results, func(i, j int) bool {
+		if results[i].Score == results[j].Score {
+			return results[i].Video.LogDate.After(results[j].Video.LogDate)
+		}
+		return results[i].Score > results[j].Score
+	})
+
+	return results
+}
+
+func (t Term) score(video models.Video) int {
+	fields := []struct {
+		name   string
+		value  string
+		weight int
+	}{
+		{"title", video.Title, weightTitle},
+		{"channel", video.Channel, weightChannel},
+		{"review", video.Review, weightReview},
+		{"tag", strings.Join(video.Tags, " "), weightTag},
+		{"collection", video.Collection, weightTag},
+	}
+
+	score := 0
+	for _, f := range fields {
+		if t.Field != "" && t.Field != f.name {
+			continue
+		}
+		if strings.Contains(strings.ToLower(f.value), t.Text) {
+			score += f.weight
+		}
+	}
+
+	// bare words fall back to fuzzy matching like the old search box
+	if score == 0 && t.Field == "" && !strings.Contains(t.Text, " ") {
+		target := strings.ToLower(video.Title + " " + video.Channel)
+		if len(fuzzy.Find(t.Text, []string{target})) > 0 {
+			score = weightFuzzy
+		}
+	}
+
+	return score
+}
+
+func parseFilter(name, value string) (Filter, error) {
+	filter := Filter{Field: name}
+
+	switch name {
+	case "rating":
+		match, err := parseNumberRange(value)
+		if err != nil {
+			return filter, fmt.Errorf("rating: %w", err)
+		}
+		filter.match = func(v models.Video) bool { return match(v.Rating) }
+	case "logged", "date":
+		match, err := parseDateRange(value)
+		if err != nil {
+			return filter, fmt.Errorf("%s: %w", name, err)
+		}
+		filter.match = func(v models.Video) bool { return !v.LogDate.IsZero() && match(v.LogDate) }
+	case "released", "release":
+		match, err := parseDateRange(value)
+		if err != nil {
+			return filter, fmt.Errorf("%s: %w", name, err)
+		}
+		filter.match = func(v models.Video) bool {
+			t, err := time.ParseInLocation(models.ISODateFormat, v.ReleaseDate, time.Local)
+			return err == nil && match(t)
+		}
+	case "rewatched", "rewatch":
+		want, err := parseBool(value)
+		if err != nil {
+			return filter, fmt.Errorf("%s: %w", name, err)
+		}
+		filter.match = func(v models.Video) bool { return v.Rewatched == want }
+	case "rated":
+		want, err := parseBool(value)
+		if err != nil {
+			return filter, fmt.Errorf("rated: %w", err)
+		}
+		filter.match = func(v models.Video) bool { return (v.Rating > 0) == want }
+	default:
+		return filter, fmt.Errorf("unknown qualifier %q", name)
+	}
+
+	return filter, nil
+}
+
+// parseNumberRange parses 4, >=4, <3.5 or 3..4.5
+func parseNumberRange(value string) (func(float64) bool, error) {
+	if lo, hi, ok := strings.Cut(value, ".."); ok {
+		low, high := -1e9, 1e9
+		var err error
+		if lo != "" {
+			if low, err = strconv.ParseFloat(lo, 64); err != nil {
+				return nil, fmt.Errorf("invalid number %q", lo)
+			}
+		}
+		if hi != "" {
+			if high, err = strconv.ParseFloat(hi, 64); err != nil {
+				return nil, fmt.Errorf("invalid number %q", hi)
+			}
+		}
+		return func(f float64) bool { return f >= low && f <= high }, nil
+	}
+
+	op, rest := splitOperator(value)
+	n, err := strconv.ParseFloat(rest, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid number %q", rest)
+	}
+
+	switch op {
+	case ">=":
+		return func(f float64) bool { return f >= n }, nil
+	case "<=":
+		return func(f float64) bool { return f <= n }, nil
+	case ">":
+		return func(f float64) bool { return f > n }, nil
+	case "<":
+		return func(f float64) bool { return f < n }, nil
+	default:
+		return func(f float64) bool { return f == n }, nil
+	}
+}
+
+// parseDateRange parses a date (2025, 2025-01, 2025-01-15), an operator
+// and date (>=2025-01), or a range (2025-01..2025-03, 2025-01..)
+func parseDateRange(value string) (func(time.Time) bool, error) {
+	if lo, hi, ok := strings.Cut(value, ".."); ok {
+		var from, to time.Time
+		if lo != "" {
+			start, _, err := parsePeriod(lo)
+			if err != nil {
+				return nil, err
+			}
+			from = start
+		}
+		if hi != "" {
+			_, end, err := parsePeriod(hi)
+			if err != nil {
+				return nil, err
+			}
+			to = end
+		}
+		return func(t time.Time) bool {
+			return (from.IsZero() || !t.Before(from)) && (to.IsZero() || t.Before(to))
+		}, nil
+	}
+
+	op, rest := splitOperator(value)
+	start, end, err := parsePeriod(rest)
+	if err != nil {
+		return nil, err
+	}
+
+	switch op {
+	case ">=":
+		return func(t time.Time) bool { return !t.Before(start) }, nil
+	case ">":
+		return func(t time.Time) bool { return !t.Before(end) }, nil
+	case "<=":
+		return func(t time.Time) bool { return t.Before(end) }, nil
+	case "<":
+		return func(t time.Time) bool { return t.Before(start) }, nil
+	default:
+		return func(t time.Time) bool { return !t.Before(start) && t.Before(end) }, nil
+	}
+}
+
+// parsePeriod returns the half-open interval covered by a year, month or day
+func parsePeriod(s string) (time.Time, time.Time, error) {
+	layouts := []struct {
+		layout string
+		next   func(time.Time) time.Time
+	}{
+		{models.ISODateFormat, func(t time.Time) time.Time { return t.AddDate(0, 0, 1) }},
+		{"2006-01", func(t time.Time) time.Time { return t.AddDate(0, 1, 0) }},
+		{"2006", func(t time.Time) time.Time { return t.AddDate(1, 0, 0) }},
+	}
+
+	for _, l := range layouts {
+		if t, err := time.ParseInLocation(l.layout, s, time.Local); err == nil {
+			return t, l.next(t), nil
+		}
+	}
+	return time.Time{}, time.Time{}, fmt.Errorf("invalid date %q, use YYYY, YYYY-MM or YYYY-MM-DD", s)
+}
+
+func splitOperator(value string) (string, string) {
+	for _, op := range []string{">=", "<=", ">", "<", "="} {
+		if strings.HasPrefix(value, op) {
+			return op, strings.TrimPrefix(value, op)
+		}
+	}
+	return "", value
+}
+
+func parseBool(value string) (bool, error) {
+	switch strings.ToLower(value) {
+	case "yes", "y", "true", "1":
+		return true, nil
+	case "no", "n", "false", "0":
+		return false, nil
+	}
+	return false, fmt.Errorf("expected yes or no, got %q", value)
+}
+
+type token struct {
+	text        string
+	quoted      bool // whole token was a quoted phrase
+	valueQuoted bool // qualifier value was quoted, e.g. channel:"a b"
+}
+
+// tokenize splits on whitespace, keeping quoted phrases and quoted
+// qualifier values together
+func tokenize(input string) ([]token, error) {
+	var tokens []token
+	var cur strings.Builder
+	inQuotes := false
+	quotedStart := false
+	valueQuoted := false
+
+	flush := func() {
+		if cur.Len() > 0 || quotedStart || valueQuoted {
+			tokens = append(tokens, token{text: cur.String(), quoted: quotedStart, valueQuoted: valueQuoted})
+		}
+		cur.Reset()
+		quotedStart = false
+		valueQuoted = false
+	}
+
+	for _, r := range input {
+		switch {
+		case r == '"':
+			if !inQuotes {
+				if cur.Len() == 0 {
+					quotedStart = true
+				} else {
+					valueQuoted = true
+				}
+			}
+			inQuotes = !inQuotes
+		case (r == ' ' || r == '\t') && !inQuotes:
+			flush()
+		default:
+			cur.WriteRune(r)
+		}
+	}
+
+	if inQuotes {
+		return nil, fmt.Errorf("unterminated quote")
+	}
+	flush()
+
+	return tokens, nil
+}
@@ -0,0 +1,125 @@
+package query
+
+import (
+	"testing"
+	"time"
+
+	"github.com/mamuzad/vidlogd/internal/models"
+)
+
+func testVideos() []models.Video {
+	at := func(s string) time.Time {
+		t, _ := time.ParseInLocation(models.ISODateFormat, s, time.Local)
+		return t
+	}
+	return []models.Video{
+		{ID: "1", Title: "Go concurrency patterns", Channel: "Fireship", Rating: 4.5, LogDate: at("2025-01-10"), ReleaseDate: "2024-12-01", Review: "great goroutine examples"},
+		{ID: "2", Title: "Rust in 100 seconds", Channel: "Fireship", Rating: 3, LogDate: at("2025-02-20"), ReleaseDate: "2023-05-01", Rewatched: true},
+		{ID: "3", Title: "Cooking pasta", Channel: "Babish", Rating: 5, LogDate: at("2025-04-02"), ReleaseDate: "2025-03-30", Review: "notes on goroutine-free cooking"},
+		{ID: "4", Title: "Unrated talk", Channel: "GopherCon", LogDate: at("2024-11-05"), ReleaseDate: "2024-10-01", Tags: []string{"conference", "go"}, Collection: "Watch later"},
+	}
+}
+
+func ids(results []Result) []string {
+	out := make([]string, len(results))
+	for i, r := range results {
+		out[i] = r.Video.ID
+	}
+	return out
+}
+
+func TestSearch(t *testing.T) {
+	tests := []struct {
+		query string
+		want  []string
+	}{
+		{"", []string{"3", "2", "1", "4"}},
+		{`channel:fireship`, []string{"2", "1"}},
+		{`channel:"fire"`, []string{"2", "1"}},
+		{`rating:>=4`, []string{"3", "1"}},
+		{`rating:<4`, []string{"2", "4"}},
+		{`rating:3..4.5`, []string{"2", "1"}},
+		{`rating:5`, []string{"3"}},
+		{`rated:no`, []string{"4"}},
+		{`logged:2025-01..2025-03`, []string{"2", "1"}},
+		{`logged:2025`, []string{"3", "2", "1"}},
+		{`logged:>=2025-02`, []string{"3", "2"}},
+		{`logged:<2025`, []string{"4"}},
+		{`logged:2025-02-20`, []string{"2"}},
+		{`released:2024`, []string{"1", "4"}},
+		{`rewatched:yes`, []string{"2"}},
+		{`-rewatched:yes channel:fireship`, []string{"1"}},
+		{`"goroutine"`, []string{"3", "1"}},
+		{`notes:goroutine -channel:babish`, []string{"1"}},
+		{`"100 seconds"`, []string{"2"}},
+		{`-fireship`, []string{"3", "4"}},
+		{`title:pasta rating:5`, []string{"3"}},
+		{`tag:conference`, []string{"4"}},
+		{`collection:"watch later"`, []string{"4"}},
+		{`-tag:go`, []string{"3", "2", "1"}},
+	}
+
+	videos := testVideos()
+	for _, tt := range tests {
+		q, err := Parse(tt.query)
+		if err != nil {
+			t.Fatalf("Parse(%q): %v", tt.query, err)
+		}
+		got := ids(Search(videos, q))
+		if len(got) != len(tt.want) {
+			t.Errorf("%q: got %v, want %v", tt.query, got, tt.want)
+			continue
+		}
+		for i := range got {
+			if got[i] != tt.want[i] {
+				t.Errorf("%q: got %v, want %v", tt.query, got, tt.want)
+				break
+			}
+		}
+	}
+}
+
+func TestSearch_RanksTitleAboveReview(t *testing.T) {
+	videos := []models.Video{
+		{ID: "review", Title: "Unrelated", Review: "mentions kubernetes"},
+		{ID: "title", Title: "Kubernetes explained"},
+	}
+	q, _ := Parse("kubernetes")
+	got := ids(Search(videos, q))
+	if len(got) != 2 || got[0] != "title" {
+		t.Fatalf("expected title match first, got %v", got)
+	}
+}
+
+func TestSearch_FuzzyFallback(t *testing.T) {
+	q, _ := Parse("gcncy")
+	got := ids(Search(testVideos(), q))
+	if len(got) != 1 || got[0] != "1" {
+		t.Fatalf("expected fuzzy match on video 1, got %v", got)
+	}
+}
+
+func TestParse_Errors(t *testing.T) {
+	for _, input := range []string{
+		`rating:abc`,
+		`logged:2025-13`,
+		`rewatched:maybe`,
+		`colour:red`,
+		`"unterminated`,
+	} {
+		if _, err := Parse(input); err == nil {
+			t.Errorf("Parse(%q): expected error", input)
+		}
+	}
+}
+
+func TestHighlights(t *testing.T) {
+	q, err := Parse(`channel:"fire ship" rust -go rating:>3`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := q.Highlights()
+	if len(got) != 2 || got[0] != "fire ship" || got[1] != "rust" {
+		t.Fatalf("unexpected highlights %v", got)
+	}
+}
@@ -0,0 +1,195 @@
+// Package report builds a "year in review" style summary of the log for
+// any period, for the stats screen and for Markdown or HTML export.
+package report
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/mamuzad/vidlogd/internal/analytics"
+	"github.com/mamuzad/vidlogd/internal/models"
+)
+
+// how many channels and videos the ranked lists show
+const (
+	TopChannels = 5
+	TopVideos   = 5
+)
+
+// channels need this many rated videos to rank by average rating
+const minRatedForAverage = 2
+
+// Options selects the period a report covers
+type Options struct {
+	Title string
+	From  time.Time // inclusive, zero for no limit
+	To    time.Time // inclusive day, zero for no limit
+}
+
+// Report summarizes the videos logged in a period
+type Report struct {
+	Title string
+	From  time.Time
+	To    time.Time
+
+	Total        int
+	Rewatches    int
+	Rated        int
+	AvgRating    float64
+	WatchSeconds int // total length of the videos with a known duration
+	Timed        int // videos with a known duration
+
+	TopByCount    []Channel // most logged first
+	TopByRating   []Channel // best average first
+	TopVideos     []models.Video
+	LongestStreak analytics.Streak
+	BusiestMonth  Month
+	NewChannels   []string // first logged during the period, in order
+}
+
+// Channel is one channel's share of the period
+type Channel struct {
+	Name      string
+	Count     int
+	Rated     int
+	AvgRating float64
+}
+
+// Month is a calendar month and how many videos were logged in it
+type Month struct {
+	Label string // January 2025
+	Count int
+}
+
+// New builds a report for the period in opts. videos should be the whole
+// history so channels seen before the period are not counted as new.
+func New(videos []models.Video, opts Options) Report {
+	r := Report{Title: opts.Title, From: opts.From, To: opts.To}
+	if r.Title == "" {
+		r.Title = periodTitle(opts.From, opts.To)
+	}
+
+	var period []models.Video
+	firstSeen := make(map[string]time.Time)
+	for _, video := range videos {
+		channel := analytics.ChannelName(video)
+		if first, ok := firstSeen[channel]; !ok || video.LogDate.Before(first) {
+			firstSeen[channel] = video.LogDate
+		}
+		if inPeriod(video.LogDate, opts) {
+			period = append(period, video)
+		}
+	}
+	models.SortVideosByLogDate(period)
+
+	summary := analytics.Summarize(period)
+	r.Total = summary.Total
+	r.Rewatches = summary.Rewatches
+	r.Rated = summary.Rated
+	r.AvgRating = summary.AvgRating
+	for _, video := range period {
+		if video.Duration > 0 {
+			r.WatchSeconds += video.Duration
+			r.Timed++
+		}
+	}
+
+	r.TopByCount, r.TopByRating = rankChannels(summary.Channels)
+	r.TopVideos = topVideos(period)
+	r.LongestStreak = analytics.LongestStreak(period)
+	if busiest := analytics.BusiestMonth(period); busiest.Count > 0 {
+		r.BusiestMonth = Month{Label: busiest.Month.Format("January 2006"), Count: busiest.Count}
+	}
+
+	// period is most recent first, discoveries read better oldest first
+	for i := len(period) - 1; i >= 0; i-- {
+		name := analytics.ChannelName(period[i])
+		if first := firstSeen[name]; first.Equal(period[i].LogDate) && inPeriod(first, opts) {
+			r.NewChannels = append(r.NewChannels, name)
+			firstSeen[name] = time.Time{} // list each channel once
+		}
+	}
+
+	return r
+}
+
+// RewatchShare is the fraction of videos that were rewatches
+func (r Report) RewatchShare() float64 {
+	if r.Total == 0 {
+		return 0
+	}
+	return float64(r.Rewatches) / float64(r.Total)
+}
+
+// Hours is the total watch time of videos with a known duration
+func (r Report) Hours() float64 {
+	return float64(r.WatchSeconds) / 3600
+}
+
+// Period describes the covered dates, e.g. "2025-01-01 to 2025-12-31"
+func (r Report) Period() string {
+	switch {
+	case r.From.IsZero() && r.To.IsZero():
+		return "all time"
+	case r.From.IsZero():
+		return "up to " + r.To.Format(models.ISODateFormat)
+	case r.To.IsZero():
+		return "since " + r.From.Format(models.ISODateFormat)
+	}
+	return r.From.Format(models.ISODateFormat) + " to " + r.To.Format(models.ISODateFormat)
+}
+
+// Year returns the options for a calendar year in the local time zone
+func Year(year int) Options {
+	from := time.Date(year, time.January, 1, 0, 0, 0, 0, time.Local)
+	return Options{From: from, To: from.AddDate(1, 0, -1)}
+}
+
+func periodTitle(from, to time.Time) string {
+	if !from.IsZero() && !to.IsZero() && from.Year() == to.Year() &&
+		from.YearDay() == 1 && to.AddDate(0, 0, 1).Year() == to.Year()+1 {
+		return fmt.Sprintf("%d in review", from.Year())
+	}
+	return "year in review"
+}
+
+func inPeriod(t time.Time, opts Options) bool {
+	if t.IsZero() {
+		return opts.From.IsZero() && opts.To.IsZero()
+	}
+	if !opts.From.IsZero() && t.Before(opts.From) {
+		return false
+	}
+	if !opts.To.IsZero() && !t.Before(opts.To.AddDate(0, 0, 1)) {
+		return false
+	}
+	return true
+}
+
+func rankChannels(stats []analytics.ChannelStats) (byCount, byRating []Channel) {
+	for _, c := range stats[:min(len(stats), TopChannels)] {
+		byCount = append(byCount, channel(c))
+	}
+	rated := analytics.TopRated(stats, minRatedForAverage)
+	for _, c := range rated[:min(len(rated), TopChannels)] {
+		byRating = append(byRating, channel(c))
+	}
+	return byCount, byRating
+}
+
+func channel(c analytics.ChannelStats) Channel {
+	return Channel{Name: c.Channel, Count: c.Count, Rated: c.TotalRated, AvgRating: c.AvgRating}
+}
+
+// topVideos returns the highest rated videos, most recent first among equals
+func topVideos(videos []models.Video) []models.Video {
+	var rated []models.Video
+	for _, video := range videos {
+		if video.Rating > 0 {
+			rated = append(rated, video)
+		}
+	}
+	sort.SliceStable(rated, func(i, j int) bool { return rated[i].Rating > rated[j].Rating })
+	return rated[:min(len(rated), TopVideos)]
+}
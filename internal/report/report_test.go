@@ -0,0 +1,132 @@
+package report
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mamuzad/vidlogd/internal/models"
+)
+
+func day(y int, m time.Month, d int) time.Time {
+	return time.Date(y, m, d, 12, 0, 0, 0, time.Local)
+}
+
+func testVideos() []models.Video {
+	return []models.Video{
+		{Title: "old favourite", Channel: "Gophers", LogDate: day(2024, 12, 30), Rating: 5},
+		{Title: "generics", Channel: "Gophers", LogDate: day(2025, 1, 5), Rating: 4, Duration: 1800},
+		{Title: "channels", Channel: "Gophers", LogDate: day(2025, 1, 6), Rating: 3, Duration: 1800},
+		{Title: "borrowing", Channel: "Crabs", URL: "https://youtu.be/c", LogDate: day(2025, 1, 7), Rating: 5, Rewatched: true},
+		{Title: "lifetimes", Channel: "Crabs", LogDate: day(2025, 3, 1), Rating: 4.5},
+		{Title: "zig", Channel: "Lizards", LogDate: day(2025, 3, 20)},
+		{Title: "next year", Channel: "Owls", LogDate: day(2026, 1, 2), Rating: 1},
+	}
+}
+
+func TestNew_Year(t *testing.T) {
+	r := New(testVideos(), Year(2025))
+
+	if r.Title != "2025 in review" {
+		t.Errorf("title = %q", r.Title)
+	}
+	if r.Total != 5 || r.Rated != 4 || r.Rewatches != 1 {
+		t.Errorf("total %d rated %d rewatches %d", r.Total, r.Rated, r.Rewatches)
+	}
+	if r.AvgRating != 4.125 {
+		t.Errorf("avg rating = %v", r.AvgRating)
+	}
+	if r.Hours() != 1 || r.Timed != 2 {
+		t.Errorf("hours = %v over %d timed", r.Hours(), r.Timed)
+	}
+	if r.RewatchShare() != 0.2 {
+		t.Errorf("rewatch share = %v", r.RewatchShare())
+	}
+	if r.BusiestMonth != (Month{Label: "January 2025", Count: 3}) {
+		t.Errorf("busiest month = %+v", r.BusiestMonth)
+	}
+
+	streak := r.LongestStreak
+	if streak.Days != 3 || streak.Videos != 3 || !streak.Start.Equal(time.Date(2025, 1, 5, 0, 0, 0, 0, time.Local)) {
+		t.Errorf("streak = %+v", streak)
+	}
+
+	// ties on count are listed by name
+	if len(r.TopByCount) != 3 || r.TopByCount[0].Name != "Crabs" || r.TopByCount[1].Name != "Gophers" ||
+		r.TopByCount[2].Count != 1 {
+		t.Errorf("top by count = %+v", r.TopByCount)
+	}
+	// channels with a single rating are left out of the average ranking
+	if len(r.TopByRating) != 2 || r.TopByRating[0].Name != "Crabs" || r.TopByRating[0].AvgRating != 4.75 {
+		t.Errorf("top by rating = %+v", r.TopByRating)
+	}
+	if len(r.TopVideos) != 4 || r.TopVideos[0].Title != "borrowing" || r.TopVideos[1].Title != "lifetimes" {
+		t.Errorf("top videos = %+v", r.TopVideos)
+	}
+	// Gophers were first logged in 2024
+	if strings.Join(r.NewChannels, ",") != "Crabs,Lizards" {
+		t.Errorf("new channels = %v", r.NewChannels)
+	}
+}
+
+func TestNew_Periods(t *testing.T) {
+	tests := []struct {
+		name   string
+		opts   Options
+		total  int
+		period string
+	}{
+		{"all time", Options{}, 7, "all time"},
+		{"from", Options{From: day(2025, 3, 1)}, 3, "since 2025-03-01"},
+		{"to is inclusive", Options{To: time.Date(2025, 1, 6, 0, 0, 0, 0, time.Local)}, 3, "up to 2025-01-06"},
+		{"empty", Year(2023), 0, "2023-01-01 to 2023-12-31"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := New(testVideos(), tt.opts)
+			if r.Total != tt.total {
+				t.Errorf("total = %d, want %d", r.Total, tt.total)
+			}
+			if r.Period() != tt.period {
+				t.Errorf("period = %q, want %q", r.Period(), tt.period)
+			}
+		})
+	}
+}
+
+func TestWriters(t *testing.T) {
+	r := New(testVideos(), Year(2025))
+
+	var md bytes.Buffer
+	if err := WriteMarkdown(&md, r); err != nil {
+		t.Fatalf("WriteMarkdown: %v", err)
+	}
+	var html bytes.Buffer
+	if err := WriteHTML(&html, r); err != nil {
+		t.Fatalf("WriteHTML: %v", err)
+	}
+
+	for name, out := range map[string]string{"markdown": md.String(), "html": html.String()} {
+		for _, want := range []string{
+			"2025 in review",
+			"5 videos logged",
+			"1.0 hours watched (2 of 5 videos with a known length)",
+			"20% rewatches",
+			"longest streak 3 days, 3 videos",
+			"busiest month January 2025 with 3 videos",
+			"Lizards",
+		} {
+			if !strings.Contains(out, want) {
+				t.Errorf("%s output missing %q:\n%s", name, want, out)
+			}
+		}
+	}
+
+	if !strings.Contains(md.String(), "1. [borrowing](https://youtu.be/c) · Crabs · ★★★★★") {
+		t.Errorf("markdown missing linked top video:\n%s", md.String())
+	}
+	if !strings.Contains(html.String(), `<a href="https://youtu.be/c">borrowing</a>`) {
+		t.Errorf("html missing linked top video:\n%s", html.String())
+	}
+}
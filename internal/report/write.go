@@ -0,0 +1,116 @@
+package report
+
+import (
+	"fmt"
+	"html/template"
+	"io"
+	"strings"
+
+	"github.com/mamuzad/vidlogd/internal/markup"
+	"github.com/mamuzad/vidlogd/internal/models"
+)
+
+// Highlights returns the headline numbers as short lines, shared by the
+// Markdown, HTML and terminal renderings
+func (r Report) Highlights() []string {
+	lines := []string{fmt.Sprintf("%d videos logged", r.Total)}
+	if r.Timed > 0 {
+		line := fmt.Sprintf("%.1f hours watched", r.Hours())
+		if r.Timed < r.Total {
+			line += fmt.Sprintf(" (%d of %d videos with a known length)", r.Timed, r.Total)
+		}
+		lines = append(lines, line)
+	}
+	if r.Rated > 0 {
+		lines = append(lines, fmt.Sprintf("average rating %.1f/5 over %d rated videos", r.AvgRating, r.Rated))
+	}
+	lines = append(lines, fmt.Sprintf("%.0f%% rewatches", r.RewatchShare()*100))
+	if r.LongestStreak.Days > 0 {
+		s := r.LongestStreak
+		lines = append(lines, fmt.Sprintf("longest streak %d days, %d videos (%s to %s)",
+			s.Days, s.Videos, s.Start.Format(models.ISODateFormat), s.End.Format(models.ISODateFormat)))
+	}
+	if r.BusiestMonth.Count > 0 {
+		lines = append(lines, fmt.Sprintf("busiest month %s with %d videos", r.BusiestMonth.Label, r.BusiestMonth.Count))
+	}
+	if len(r.NewChannels) > 0 {
+		lines = append(lines, fmt.Sprintf("%d new channels discovered", len(r.NewChannels)))
+	}
+	return lines
+}
+
+// WriteMarkdown writes the report as a Markdown document
+func WriteMarkdown(w io.Writer, r Report) error {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "# %s\n\n", markup.EscapeMarkdown(r.Title))
+	fmt.Fprintf(&b, "_%s_\n\n", r.Period())
+	for _, line := range r.Highlights() {
+		fmt.Fprintf(&b, "- %s\n", line)
+	}
+
+	if len(r.TopByCount) > 0 {
+		b.WriteString("\n## Top channels\n\n")
+		for i, c := range r.TopByCount {
+			fmt.Fprintf(&b, "%d. %s · %d videos\n", i+1, markup.EscapeMarkdown(c.Name), c.Count)
+		}
+	}
+
+	if len(r.TopByRating) > 0 {
+		b.WriteString("\n## Best rated channels\n\n")
+		for i, c := range r.TopByRating {
+			fmt.Fprintf(&b, "%d. %s · %.1f/5 over %d videos\n", i+1, markup.EscapeMarkdown(c.Name), c.AvgRating, c.Rated)
+		}
+	}
+
+	if len(r.TopVideos) > 0 {
+		b.WriteString("\n## Highest rated videos\n\n")
+		for i, video := range r.TopVideos {
+			title := markup.EscapeMarkdown(markup.VideoTitle(video))
+			if video.URL != "" {
+				title = fmt.Sprintf("[%s](%s)", title, video.URL)
+			}
+			fmt.Fprintf(&b, "%d. %s · %s · %s\n", i+1, title, markup.EscapeMarkdown(video.Channel), models.RatingStars(video.Rating))
+		}
+	}
+
+	if len(r.NewChannels) > 0 {
+		b.WriteString("\n## New channels\n\n")
+		for _, name := range r.NewChannels {
+			fmt.Fprintf(&b, "- %s\n", markup.EscapeMarkdown(name))
+		}
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+const reportTemplate = `<!doctype html>
+<html lang="en"><head><meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<title>{{.Title}}</title>
+<style>
+{{style "44rem"}}
+ul.highlights{list-style:none;padding:0;font-size:1.1rem}ul.highlights li{margin:.4rem 0}
+</style></head><body>
+<h1>{{.Title}}</h1>
+<p class="meta">{{.Period}}</p>
+<ul class="highlights">{{range .Highlights}}<li>{{.}}</li>{{end}}</ul>
+{{if .TopByCount}}<h2>Top channels</h2>
+<ol>{{range .TopByCount}}<li>{{.Name}} <span class="meta">{{.Count}} videos</span></li>{{end}}</ol>{{end}}
+{{if .TopByRating}}<h2>Best rated channels</h2>
+<ol>{{range .TopByRating}}<li>{{.Name}} <span class="meta">{{printf "%.1f" .AvgRating}}/5 over {{.Rated}} videos</span></li>{{end}}</ol>{{end}}
+{{if .TopVideos}}<h2>Highest rated videos</h2>
+<ol>{{range .TopVideos}}<li>{{if .URL}}<a href="{{.URL}}">{{title .}}</a>{{else}}{{title .}}{{end}} <span class="meta">{{.Channel}}</span> <span class="stars">{{stars .Rating}}</span></li>{{end}}</ol>{{end}}
+{{if .NewChannels}}<h2>New channels</h2>
+<ul>{{range .NewChannels}}<li>{{.}}</li>{{end}}</ul>{{end}}
+<p class="meta">generated by vidlogd</p>
+</body></html>
+`
+
+var htmlTemplate = template.Must(template.New("report").Funcs(markup.Funcs()).Parse(reportTemplate))
+
+// WriteHTML writes the report as a standalone HTML page
+func WriteHTML(w io.Writer, r Report) error {
+	return htmlTemplate.Execute(w, r)
+}
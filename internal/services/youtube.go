@@ -18,7 +18,9 @@ import (
 type YouTubeMetadata struct {
 	Title       string
 	Creator     string
+	ChannelID   string
 	ReleaseDate string
+	Duration    int // seconds
 }
 
 type MetadataFetchedMsg struct {
@@ -31,8 +33,12 @@ type YouTubeAPIResponse struct {
 		Snippet struct {
 			Title        string `json:"title"`
 			ChannelTitle string `json:"channelTitle"`
+			ChannelID    string `json:"channelId"`
 			PublishedAt  string `json:"publishedAt"`
 		} `json:"snippet"`
+		ContentDetails struct {
+			Duration string `json:"duration"` // ISO 8601, e.g. PT12M34S
+		} `json:"contentDetails"`
 	} `json:"items"`
 }
 
@@ -97,7 +103,8 @@ func IsValidYouTubeURL(urlStr string) bool {
 	return false
 }
 
-func extractVideoID(urlStr string) string {
+// ExtractVideoID returns the YouTube video ID from a watch, embed or youtu.be URL
+func ExtractVideoID(urlStr string) string {
 	u, err := neturl.Parse(urlStr)
 	if err != nil {
 		return ""
@@ -122,7 +129,7 @@ func extractVideoID(urlStr string) string {
 
 // fetch youtube metadata and parse it
 //
-// returns MetadataFetchedMsg containing the Metadata (video title, channel, release date)
+// returns MetadataFetchedMsg containing the Metadata (video title, channel, release date, duration)
 func FetchYouTubeMetadata(urlStr string) tea.Cmd {
 	return func() tea.Msg {
 		youtubeAPIKey := getYouTubeAPIKey()
@@ -135,13 +142,13 @@ func FetchYouTubeMetadata(urlStr string) tea.Cmd {
 			return MetadataFetchedMsg{Error: "invalid YouTube URL"}
 		}
 
-		videoID := extractVideoID(urlStr)
+		videoID := ExtractVideoID(urlStr)
 		if videoID == "" {
 			return MetadataFetchedMsg{Error: "could not extract video ID"}
 		}
 
 		apiURL := fmt.Sprintf(
-			"https://www.googleapis.com/youtube/v3/videos?part=snippet&id=%s&key=%s",
+			"https://www.googleapis.com/youtube/v3/videos?part=snippet,contentDetails&id=%s&key=%s",
 			videoID,
 			youtubeAPIKey,
 		)
@@ -176,6 +183,7 @@ func FetchYouTubeMetadata(urlStr string) tea.Cmd {
 		}
 
 		snippet := apiResponse.Items[0].Snippet
+		duration, _ := models.ParseDuration(apiResponse.Items[0].ContentDetails.Duration)
 
 		publishedDate := ""
 		if snippet.PublishedAt != "" {
@@ -187,7 +195,9 @@ func FetchYouTubeMetadata(urlStr string) tea.Cmd {
 		metadata := YouTubeMetadata{
 			Title:       snippet.Title,
 			Creator:     snippet.ChannelTitle,
+			ChannelID:   snippet.ChannelID,
 			ReleaseDate: publishedDate,
+			Duration:    duration,
 		}
 
 		return MetadataFetchedMsg{Metadata: metadata}
@@ -4,11 +4,74 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"regexp"
 	"runtime"
+	"sort"
 )
 
-// DataDir returns the path to the application's data directory
-func DataDir() (string, error) {
+// DefaultProfile is the profile whose data lives directly in the app directory
+const DefaultProfile = "default"
+
+var (
+	// active profile used to resolve DataDir
+	activeProfile = DefaultProfile
+
+	profileNameRegex = regexp.MustCompile(`^[a-zA-Z0-9_-]{1,32}$`)
+)
+
+// SetProfile selects the profile that DataDir resolves to.
+// An empty name selects the default profile.
+func SetProfile(name string) error {
+	if name == "" {
+		name = DefaultProfile
+	}
+	if !ValidProfileName(name) {
+		return fmt.Errorf("invalid profile name %q: use letters, numbers, '-' or '_'", name)
+	}
+	activeProfile = name
+	return nil
+}
+
+// Profile returns the name of the active profile
+func Profile() string {
+	return activeProfile
+}
+
+// ValidProfileName reports whether name can be used as a profile name
+func ValidProfileName(name string) bool {
+	return profileNameRegex.MatchString(name)
+}
+
+// Profiles returns all existing profiles, default first
+func Profiles() ([]string, error) {
+	root, err := AppDir()
+	if err != nil {
+		return nil, err
+	}
+
+	profiles := []string{DefaultProfile}
+
+	entries, err := os.ReadDir(filepath.Join(root, "profiles"))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return profiles, nil
+		}
+		return nil, fmt.Errorf("failed to read profiles directory: %w", err)
+	}
+
+	var named []string
+	for _, e := range entries {
+		if e.IsDir() && ValidProfileName(e.Name()) && e.Name() != DefaultProfile {
+			named = append(named, e.Name())
+		}
+	}
+	sort.Strings(named)
+
+	return append(profiles, named...), nil
+}
+
+// AppDir returns the root application directory shared by all profiles
+func AppDir() (string, error) {
 	var baseDir string
 
 	// Follow XDG Base Directory Specification on Unix-like systems
@@ -33,13 +96,33 @@ func DataDir() (string, error) {
 	}
 
 	// Create application-specific directory
-	dataDir := filepath.Join(baseDir, "vidlogd")
+	appDir := filepath.Join(baseDir, "vidlogd")
 
 	// Ensure directory exists
-	if err := os.MkdirAll(dataDir, 0755); err != nil {
+	if err := os.MkdirAll(appDir, 0755); err != nil {
 		return "", fmt.Errorf("failed to create data directory: %w", err)
 	}
 
+	return appDir, nil
+}
+
+// DataDir returns the path to the active profile's data directory.
+// The default profile keeps using the app directory so existing logs stay put.
+func DataDir() (string, error) {
+	appDir, err := AppDir()
+	if err != nil {
+		return "", err
+	}
+
+	if activeProfile == DefaultProfile {
+		return appDir, nil
+	}
+
+	dataDir := filepath.Join(appDir, "profiles", activeProfile)
+	if err := os.MkdirAll(dataDir, 0755); err != nil {
+		return "", fmt.Errorf("failed to create profile directory: %w", err)
+	}
+
 	return dataDir, nil
 }
 
@@ -60,3 +143,59 @@ func SettingsPath() (string, error) {
 	}
 	return filepath.Join(dataDir, "settings.json"), nil
 }
+
+// FiltersPath returns the path to the saved filters file
+func FiltersPath() (string, error) {
+	dataDir, err := DataDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dataDir, "filters.json"), nil
+}
+
+// GoalsPath returns the path to the viewing goals file
+func GoalsPath() (string, error) {
+	dataDir, err := DataDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dataDir, "goals.json"), nil
+}
+
+// ChannelsPath returns the path to the channel notes file
+func ChannelsPath() (string, error) {
+	dataDir, err := DataDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dataDir, "channels.json"), nil
+}
+
+// ErrorLogPath returns the path to the error log, shared by all profiles
+func ErrorLogPath() (string, error) {
+	appDir, err := AppDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(appDir, "errors.json"), nil
+}
+
+// KeybindingsPath returns the path to the user's keybindings, shared by all
+// profiles
+func KeybindingsPath() (string, error) {
+	appDir, err := AppDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(appDir, "keybindings.json"), nil
+}
+
+// ThemesDir returns the directory holding the user's theme files, shared by
+// all profiles
+func ThemesDir() (string, error) {
+	appDir, err := AppDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(appDir, "themes"), nil
+}
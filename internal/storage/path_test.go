@@ -0,0 +1,77 @@
+package storage
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestDataDir_ResolvesPerProfile(t *testing.T) {
+	xdg := t.TempDir()
+	t.Setenv("XDG_DATA_HOME", xdg)
+	t.Cleanup(func() { _ = SetProfile("") })
+
+	if err := SetProfile(""); err != nil {
+		t.Fatalf("SetProfile(default): %v", err)
+	}
+	dir, err := DataDir()
+	if err != nil {
+		t.Fatalf("DataDir: %v", err)
+	}
+	if want := filepath.Join(xdg, "vidlogd"); dir != want {
+		t.Fatalf("default profile dir: got %q want %q", dir, want)
+	}
+
+	if err := SetProfile("work"); err != nil {
+		t.Fatalf("SetProfile(work): %v", err)
+	}
+	dir, err = DataDir()
+	if err != nil {
+		t.Fatalf("DataDir: %v", err)
+	}
+	if want := filepath.Join(xdg, "vidlogd", "profiles", "work"); dir != want {
+		t.Fatalf("work profile dir: got %q want %q", dir, want)
+	}
+	if _, err := os.Stat(dir); err != nil {
+		t.Fatalf("expected profile dir to exist: %v", err)
+	}
+
+	videos, _ := VideosPath()
+	if filepath.Dir(videos) != dir {
+		t.Fatalf("videos path %q not inside profile dir %q", videos, dir)
+	}
+}
+
+func TestSetProfile_RejectsInvalidNames(t *testing.T) {
+	t.Cleanup(func() { _ = SetProfile("") })
+
+	for _, name := range []string{"../escape", "with space", "a/b", "."} {
+		if err := SetProfile(name); err == nil {
+			t.Errorf("SetProfile(%q): expected error", name)
+		}
+	}
+	if Profile() != DefaultProfile {
+		t.Fatalf("active profile changed after invalid names: %q", Profile())
+	}
+}
+
+func TestProfiles_ListsDefaultFirst(t *testing.T) {
+	xdg := t.TempDir()
+	t.Setenv("XDG_DATA_HOME", xdg)
+
+	for _, name := range []string{"personal", "kids", "not valid"} {
+		if err := os.MkdirAll(filepath.Join(xdg, "vidlogd", "profiles", name), 0o755); err != nil {
+			t.Fatalf("MkdirAll: %v", err)
+		}
+	}
+
+	got, err := Profiles()
+	if err != nil {
+		t.Fatalf("Profiles: %v", err)
+	}
+	want := []string{DefaultProfile, "kids", "personal"}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("Profiles: got %v want %v", got, want)
+	}
+}
@@ -0,0 +1,408 @@
+package transfer
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/mamuzad/vidlogd/internal/models"
+	"github.com/mamuzad/vidlogd/internal/services"
+)
+
+// CSV column names, one per models.Video field
+const (
+	ColumnID          = "id"
+	ColumnURL         = "url"
+	ColumnTitle       = "title"
+	ColumnChannel     = "channel"
+	ColumnReleaseDate = "release_date"
+	ColumnLogDate     = "log_date"
+	ColumnRating      = "rating"
+	ColumnRewatched   = "rewatched"
+	ColumnReview      = "review"
+	ColumnTags        = "tags"
+	ColumnDuration    = "duration"
+	ColumnCollection  = "collection"
+)
+
+// Columns lists the exported columns in order
+var Columns = []string{
+	ColumnID,
+	ColumnURL,
+	ColumnTitle,
+	ColumnChannel,
+	ColumnReleaseDate,
+	ColumnLogDate,
+	ColumnRating,
+	ColumnRewatched,
+	ColumnReview,
+	ColumnTags,
+	ColumnDuration,
+	ColumnCollection,
+}
+
+// ImportColumns lists the columns read on import, the id is always regenerated
+var ImportColumns = Columns[1:]
+
+// common spreadsheet header names for each column
+var columnAliases = map[string][]string{
+	ColumnURL:         {"url", "link", "video url", "youtube url"},
+	ColumnTitle:       {"title", "video title", "name"},
+	ColumnChannel:     {"channel", "creator", "author", "channel name"},
+	ColumnReleaseDate: {"release date", "released", "published", "publish date", "video release date"},
+	ColumnLogDate:     {"log date", "logged", "date logged", "watched", "watch date", "date"},
+	ColumnRating:      {"rating", "stars", "score"},
+	ColumnRewatched:   {"rewatched", "rewatch"},
+	ColumnReview:      {"review", "notes", "note", "comment", "comments"},
+	ColumnTags:        {"tags", "tag", "labels"},
+	ColumnDuration:    {"duration", "length", "runtime"},
+	ColumnCollection:  {"collection", "playlist", "list"},
+}
+
+// DateFormats maps the names accepted by --date-format to layouts
+var DateFormats = map[string]string{
+	"datetime": models.DateTimeFormat,
+	"iso":      models.ISODateFormat,
+	"rfc3339":  time.RFC3339,
+}
+
+// ResolveDateFormat returns the layout for a named format, or name itself
+// when it is already a Go time layout
+func ResolveDateFormat(name string) string {
+	if name == "" {
+		return models.DateTimeFormat
+	}
+	if layout, ok := DateFormats[strings.ToLower(name)]; ok {
+		return layout
+	}
+	return name
+}
+
+// ExportCSV writes videos as CSV with a header row
+func ExportCSV(w io.Writer, videos []models.Video, dateFormat string) error {
+	layout := ResolveDateFormat(dateFormat)
+
+	cw := csv.NewWriter(w)
+	if err := cw.Write(Columns); err != nil {
+		return fmt.Errorf("failed to write header: %w", err)
+	}
+
+	for _, video := range videos {
+		logDate := ""
+		if !video.LogDate.IsZero() {
+			logDate = video.LogDate.Format(layout)
+		}
+
+		record := []string{
+			video.ID,
+			video.URL,
+			video.Title,
+			video.Channel,
+			video.ReleaseDate,
+			logDate,
+			strconv.FormatFloat(video.Rating, 'f', -1, 64),
+			strconv.FormatBool(video.Rewatched),
+			video.Review,
+			strings.Join(video.Tags, "; "),
+			models.FormatDuration(video.Duration),
+			video.Collection,
+		}
+		if err := cw.Write(record); err != nil {
+			return fmt.Errorf("failed to write video %s: %w", video.ID, err)
+		}
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
+
+// ReadCSV reads a CSV file into its header and data rows
+func ReadCSV(r io.Reader) (header []string, rows [][]string, err error) {
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = -1 // spreadsheets often drop trailing empty cells
+	cr.TrimLeadingSpace = true
+
+	records, err := cr.ReadAll()
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to parse csv: %w", err)
+	}
+	if len(records) == 0 {
+		return nil, nil, fmt.Errorf("csv file is empty")
+	}
+
+	header = records[0]
+	if len(header) > 0 {
+		// strip a UTF-8 BOM left by spreadsheet exports
+		header[0] = strings.TrimPrefix(header[0], "\ufeff")
+	}
+
+	return header, records[1:], nil
+}
+
+// ColumnMapping maps a video column name to its index in the CSV header
+type ColumnMapping map[string]int
+
+// GuessMapping matches header names against the known columns and aliases
+func GuessMapping(header []string) ColumnMapping {
+	mapping := ColumnMapping{}
+	for i, name := range header {
+		normalized := normalizeHeader(name)
+		for _, column := range ImportColumns {
+			if _, taken := mapping[column]; taken {
+				continue
+			}
+			for _, alias := range columnAliases[column] {
+				if normalized == alias {
+					mapping[column] = i
+					break
+				}
+			}
+		}
+	}
+	return mapping
+}
+
+// ParseMapping parses "column=header,column=header" overrides against the
+// CSV header, on top of base
+func ParseMapping(spec string, header []string, base ColumnMapping) (ColumnMapping, error) {
+	mapping := ColumnMapping{}
+	for column, index := range base {
+		mapping[column] = index
+	}
+
+	if strings.TrimSpace(spec) == "" {
+		return mapping, nil
+	}
+
+	for _, pair := range strings.Split(spec, ",") {
+		column, headerName, ok := strings.Cut(pair, "=")
+		if !ok {
+			return nil, fmt.Errorf("invalid mapping %q, expected column=header", pair)
+		}
+		column = strings.TrimSpace(column)
+		headerName = strings.TrimSpace(headerName)
+
+		if !isColumn(column) {
+			return nil, fmt.Errorf("unknown column %q", column)
+		}
+
+		if headerName == "" {
+			delete(mapping, column)
+			continue
+		}
+
+		index := -1
+		for i, name := range header {
+			if strings.EqualFold(strings.TrimSpace(name), headerName) {
+				index = i
+				break
+			}
+		}
+		if index == -1 {
+			return nil, fmt.Errorf("column %q not found in csv header", headerName)
+		}
+		mapping[column] = index
+	}
+
+	return mapping, nil
+}
+
+// ImportOptions controls how CSV rows become videos
+type ImportOptions struct {
+	Mapping    ColumnMapping
+	DateFormat string // name or layout, see ResolveDateFormat
+}
+
+// ImportRow is the outcome of validating one CSV row
+type ImportRow struct {
+	Line      int // line number in the file, header is line 1
+	Video     models.Video
+	Errors    []string
+	Duplicate bool
+}
+
+// ImportResult is the dry-run outcome of an import
+type ImportResult struct {
+	Rows       []ImportRow
+	Valid      int
+	Invalid    int
+	Duplicates int
+}
+
+// Videos returns the rows that are valid and not duplicates
+func (r ImportResult) Videos() []models.Video {
+	var videos []models.Video
+	for _, row := range r.Rows {
+		if len(row.Errors) == 0 && !row.Duplicate {
+			videos = append(videos, row.Video)
+		}
+	}
+	return videos
+}
+
+// PlanImport converts and validates rows without saving anything.
+// Rows whose YouTube video ID is already logged, or appears earlier in the
+// file, are marked as duplicates unless they are flagged as rewatches.
+func PlanImport(rows [][]string, existing []models.Video, opts ImportOptions) ImportResult {
+	seen := make(map[string]bool)
+	for _, video := range existing {
+		if id := services.ExtractVideoID(video.URL); id != "" {
+			seen[id] = true
+		}
+	}
+
+	var result ImportResult
+	for i, record := range rows {
+		row := ImportRow{Line: i + 2}
+		row.Video, row.Errors = videoFromRecord(record, opts)
+
+		if len(row.Errors) > 0 {
+			result.Invalid++
+		} else {
+			videoID := services.ExtractVideoID(row.Video.URL)
+			if seen[videoID] && !row.Video.Rewatched {
+				row.Duplicate = true
+				result.Duplicates++
+			} else {
+				result.Valid++
+			}
+			seen[videoID] = true
+		}
+
+		result.Rows = append(result.Rows, row)
+	}
+
+	return result
+}
+
+func videoFromRecord(record []string, opts ImportOptions) (models.Video, []string) {
+	get := func(column string) string {
+		index, ok := opts.Mapping[column]
+		if !ok || index < 0 || index >= len(record) {
+			return ""
+		}
+		return strings.TrimSpace(record[index])
+	}
+
+	layout := ResolveDateFormat(opts.DateFormat)
+
+	video := models.Video{
+		URL:        get(ColumnURL),
+		Title:      get(ColumnTitle),
+		Channel:    get(ColumnChannel),
+		Review:     get(ColumnReview),
+		Tags:       models.ParseTags(get(ColumnTags)),
+		Collection: get(ColumnCollection),
+	}
+
+	var errs []string
+
+	// release dates are stored as YYYY-MM-DD regardless of the input format
+	releaseDate := get(ColumnReleaseDate)
+	if releaseDate != "" && !models.IsValidDate(releaseDate) {
+		if t, err := time.Parse(layout, releaseDate); err == nil {
+			releaseDate = t.Format(models.ISODateFormat)
+		}
+	}
+	video.ReleaseDate = releaseDate
+
+	logDate := get(ColumnLogDate)
+	if logDate != "" {
+		t, err := time.Parse(layout, logDate)
+		if err != nil {
+			errs = append(errs, fmt.Sprintf("log_date: %q does not match %q", logDate, layout))
+		}
+		video.LogDate = t
+	}
+
+	if ratingStr := get(ColumnRating); ratingStr != "" {
+		rating, err := strconv.ParseFloat(ratingStr, 64)
+		if err != nil {
+			errs = append(errs, fmt.Sprintf("rating: %q is not a number", ratingStr))
+		}
+		video.Rating = rating
+	}
+
+	if rewatched := strings.ToLower(get(ColumnRewatched)); rewatched != "" {
+		switch rewatched {
+		case "true", "yes", "y", "1", "x":
+			video.Rewatched = true
+		case "false", "no", "n", "0":
+		default:
+			errs = append(errs, fmt.Sprintf("rewatched: %q is not yes/no", rewatched))
+		}
+	}
+
+	if duration, err := models.ParseDuration(get(ColumnDuration)); err != nil {
+		errs = append(errs, fmt.Sprintf("duration: %v", err))
+	} else {
+		video.Duration = duration
+	}
+
+	// keep only the first error per field
+	for _, e := range ValidateVideo(video) {
+		field, _, _ := strings.Cut(e, ":")
+		if !hasFieldError(errs, field) {
+			errs = append(errs, e)
+		}
+	}
+
+	return video, errs
+}
+
+func hasFieldError(errs []string, field string) bool {
+	for _, e := range errs {
+		if strings.HasPrefix(e, field+":") {
+			return true
+		}
+	}
+	return false
+}
+
+// ValidateVideo applies the log form's field rules to a video
+func ValidateVideo(video models.Video) []string {
+	var errs []string
+
+	if video.URL == "" {
+		errs = append(errs, "url: field is required")
+	} else if !services.IsValidYouTubeURL(video.URL) {
+		errs = append(errs, "url: invalid youtube url")
+	}
+	if video.Title == "" {
+		errs = append(errs, "title: field is required")
+	}
+	if video.Channel == "" {
+		errs = append(errs, "channel: field is required")
+	}
+	if video.ReleaseDate == "" {
+		errs = append(errs, "release_date: field is required")
+	} else if !models.IsValidDate(video.ReleaseDate) {
+		errs = append(errs, "release_date: invalid date")
+	}
+	if video.LogDate.IsZero() {
+		errs = append(errs, "log_date: field is required")
+	}
+	if !models.IsValidRating(video.Rating) {
+		errs = append(errs, "rating: must be 0-5 in 0.5 steps")
+	}
+
+	return errs
+}
+
+func normalizeHeader(name string) string {
+	name = strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, "\ufeff")))
+	name = strings.NewReplacer("_", " ", "-", " ").Replace(name)
+	return strings.Join(strings.Fields(name), " ")
+}
+
+func isColumn(name string) bool {
+	for _, column := range ImportColumns {
+		if column == name {
+			return true
+		}
+	}
+	return false
+}
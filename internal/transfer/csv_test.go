@@ -0,0 +1,154 @@
+package transfer
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mamuzad/vidlogd/internal/models"
+)
+
+func TestExportCSV_RoundTrip(t *testing.T) {
+	logDate, _ := time.Parse(models.DateTimeFormat, "2025-03-01 9:30 PM")
+	videos := []models.Video{
+		{
+			ID:          "abc",
+			URL:         "https://www.youtube.com/watch?v=dQw4w9WgXcQ",
+			Title:       "a, title with \"quotes\"",
+			Channel:     "chan",
+			ReleaseDate: "2024-12-31",
+			LogDate:     logDate,
+			Rating:      4.5,
+			Rewatched:   true,
+			Review:      "multi\nline",
+			Tags:        []string{"music", "classic"},
+			Duration:    213,
+		},
+	}
+
+	var buf bytes.Buffer
+	if err := ExportCSV(&buf, videos, ""); err != nil {
+		t.Fatalf("ExportCSV: %v", err)
+	}
+
+	header, rows, err := ReadCSV(&buf)
+	if err != nil {
+		t.Fatalf("ReadCSV: %v", err)
+	}
+	if len(rows) != 1 {
+		t.Fatalf("expected 1 row, got %d", len(rows))
+	}
+
+	result := PlanImport(rows, nil, ImportOptions{Mapping: GuessMapping(header)})
+	if result.Valid != 1 {
+		t.Fatalf("expected 1 valid row, got %+v", result.Rows)
+	}
+
+	got := result.Videos()[0]
+	want := videos[0]
+	if got.Title != want.Title || got.Review != want.Review || got.Rating != want.Rating ||
+		!got.LogDate.Equal(want.LogDate) || got.Rewatched != want.Rewatched || got.ReleaseDate != want.ReleaseDate ||
+		strings.Join(got.Tags, ",") != strings.Join(want.Tags, ",") || got.Duration != want.Duration {
+		t.Fatalf("round trip mismatch:\n got %+v\nwant %+v", got, want)
+	}
+}
+
+func TestGuessMapping_Aliases(t *testing.T) {
+	header := []string{"\ufeffLink", "Video Title", "Creator", "Published", "Date Logged", "Stars", "Notes"}
+	mapping := GuessMapping(header)
+
+	want := map[string]int{
+		ColumnURL:         0,
+		ColumnTitle:       1,
+		ColumnChannel:     2,
+		ColumnReleaseDate: 3,
+		ColumnLogDate:     4,
+		ColumnRating:      5,
+		ColumnReview:      6,
+	}
+	for column, index := range want {
+		if got, ok := mapping[column]; !ok || got != index {
+			t.Errorf("%s: got %d (mapped=%v), want %d", column, got, ok, index)
+		}
+	}
+}
+
+func TestParseMapping(t *testing.T) {
+	header := []string{"Watched On", "Link", "Name"}
+
+	mapping, err := ParseMapping("log_date=watched on, url=Link", header, ColumnMapping{ColumnTitle: 2})
+	if err != nil {
+		t.Fatalf("ParseMapping: %v", err)
+	}
+	if mapping[ColumnLogDate] != 0 || mapping[ColumnURL] != 1 || mapping[ColumnTitle] != 2 {
+		t.Fatalf("unexpected mapping: %v", mapping)
+	}
+
+	if _, err := ParseMapping("bogus=Link", header, nil); err == nil {
+		t.Error("expected error for unknown column")
+	}
+	if _, err := ParseMapping("url=Missing", header, nil); err == nil {
+		t.Error("expected error for missing header")
+	}
+}
+
+func TestPlanImport_ValidationAndDuplicates(t *testing.T) {
+	input := strings.Join([]string{
+		"url,title,channel,release_date,log_date,rating,rewatched",
+		"https://youtu.be/aaaaaaaaaaa,ok,chan,2024-01-01,2025-01-02,4,no",
+		"https://youtu.be/bbbbbbbbbbb,logged already,chan,2024-01-01,2025-01-02,3,",
+		"https://youtu.be/bbbbbbbbbbb,rewatch,chan,2024-01-01,2025-01-03,3,yes",
+		"https://youtu.be/aaaaaaaaaaa,twice in file,chan,2024-01-01,2025-01-04,3,",
+		"https://example.com,bad url,chan,2024-01-01,2025-01-02,3,",
+		"https://youtu.be/ccccccccccc,bad rating,chan,2024-01-01,2025-01-02,4.2,",
+		"https://youtu.be/ddddddddddd,bad date,chan,2024-01-01,01/02/2025,4,",
+		"https://youtu.be/eeeeeeeeeee,,,,2025-01-02,,",
+	}, "\n")
+
+	header, rows, err := ReadCSV(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("ReadCSV: %v", err)
+	}
+
+	existing := []models.Video{{URL: "https://www.youtube.com/watch?v=bbbbbbbbbbb"}}
+	result := PlanImport(rows, existing, ImportOptions{Mapping: GuessMapping(header), DateFormat: "iso"})
+
+	tests := []struct {
+		line      int
+		duplicate bool
+		invalid   bool
+	}{
+		{line: 2},
+		{line: 3, duplicate: true},
+		{line: 4},
+		{line: 5, duplicate: true},
+		{line: 6, invalid: true},
+		{line: 7, invalid: true},
+		{line: 8, invalid: true},
+		{line: 9, invalid: true},
+	}
+
+	if len(result.Rows) != len(tests) {
+		t.Fatalf("expected %d rows, got %d", len(tests), len(result.Rows))
+	}
+	for i, tt := range tests {
+		row := result.Rows[i]
+		if row.Line != tt.line {
+			t.Errorf("row %d: line %d, want %d", i, row.Line, tt.line)
+		}
+		if row.Duplicate != tt.duplicate {
+			t.Errorf("line %d: duplicate=%v, want %v", tt.line, row.Duplicate, tt.duplicate)
+		}
+		if (len(row.Errors) > 0) != tt.invalid {
+			t.Errorf("line %d: errors=%v, want invalid=%v", tt.line, row.Errors, tt.invalid)
+		}
+	}
+
+	if result.Valid != 2 || result.Duplicates != 2 || result.Invalid != 4 {
+		t.Fatalf("unexpected totals: valid=%d dup=%d invalid=%d", result.Valid, result.Duplicates, result.Invalid)
+	}
+	if len(result.Videos()) != 2 {
+		t.Fatalf("expected 2 importable videos, got %d", len(result.Videos()))
+	}
+}
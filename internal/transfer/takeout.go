@@ -0,0 +1,307 @@
+package transfer
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"html"
+	"io"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/mamuzad/vidlogd/internal/models"
+	"github.com/mamuzad/vidlogd/internal/services"
+)
+
+// TakeoutEntry is one watch from a Google Takeout watch history
+type TakeoutEntry struct {
+	URL       string
+	VideoID   string
+	Title     string
+	Channel   string
+	WatchedAt time.Time
+	Ad        bool
+	Short     bool
+}
+
+// takeoutJSONEntry mirrors an item in watch-history.json
+type takeoutJSONEntry struct {
+	Title     string `json:"title"`
+	TitleURL  string `json:"titleUrl"`
+	Time      string `json:"time"`
+	Subtitles []struct {
+		Name string `json:"name"`
+	} `json:"subtitles"`
+	Details []struct {
+		Name string `json:"name"`
+	} `json:"details"`
+}
+
+// ParseTakeout reads watch-history.json or watch-history.html.
+// Entries without a video link, such as removed videos, are dropped.
+func ParseTakeout(r io.Reader) ([]TakeoutEntry, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read watch history: %w", err)
+	}
+
+	trimmed := bytes.TrimSpace(bytes.TrimPrefix(data, []byte("\ufeff")))
+	if len(trimmed) == 0 {
+		return nil, fmt.Errorf("watch history is empty")
+	}
+
+	if trimmed[0] == '[' {
+		return parseTakeoutJSON(trimmed)
+	}
+	return parseTakeoutHTML(string(trimmed))
+}
+
+func parseTakeoutJSON(data []byte) ([]TakeoutEntry, error) {
+	var raw []takeoutJSONEntry
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, fmt.Errorf("failed to parse watch history json: %w", err)
+	}
+
+	var entries []TakeoutEntry
+	for _, item := range raw {
+		watchedAt, err := time.Parse(time.RFC3339, item.Time)
+		if err != nil {
+			continue
+		}
+
+		entry := TakeoutEntry{
+			URL:       item.TitleURL,
+			Title:     item.Title,
+			WatchedAt: watchedAt,
+		}
+		if len(item.Subtitles) > 0 {
+			entry.Channel = item.Subtitles[0].Name
+		}
+		for _, detail := range item.Details {
+			if strings.Contains(detail.Name, "Google Ads") {
+				entry.Ad = true
+			}
+		}
+
+		if finishEntry(&entry) {
+			entries = append(entries, entry)
+		}
+	}
+
+	return entries, nil
+}
+
+var (
+	takeoutAnchorRegex = regexp.MustCompile(`(?s)<a href="([^"]*)">(.*?)</a>`)
+	takeoutTagRegex    = regexp.MustCompile(`(?s)<[^>]*>`)
+)
+
+// layouts used for the watch time in html exports
+var takeoutHTMLLayouts = []string{
+	"Jan 2, 2006, 3:04:05 PM MST",
+	"Jan 2, 2006, 15:04:05 MST",
+	"2 Jan 2006, 15:04:05 MST",
+	"Jan 2, 2006, 3:04:05 PM",
+}
+
+func parseTakeoutHTML(doc string) ([]TakeoutEntry, error) {
+	cells := strings.Split(doc, `<div class="outer-cell`)
+	if len(cells) < 2 {
+		return nil, fmt.Errorf("no watch history entries found in html")
+	}
+
+	var entries []TakeoutEntry
+	for _, cell := range cells[1:] {
+		// the first content cell holds the video link, channel link and time
+		start := strings.Index(cell, `<div class="content-cell`)
+		if start == -1 {
+			continue
+		}
+		content := cell[start:]
+		if end := strings.Index(content, "</div>"); end != -1 {
+			content = content[:end]
+		}
+
+		anchors := takeoutAnchorRegex.FindAllStringSubmatch(content, -1)
+		if len(anchors) == 0 {
+			continue
+		}
+
+		entry := TakeoutEntry{
+			URL:   html.UnescapeString(anchors[0][1]),
+			Title: cleanHTMLText(anchors[0][2]),
+			Ad:    strings.Contains(cell, "From Google Ads"),
+		}
+		if len(anchors) > 1 {
+			entry.Channel = cleanHTMLText(anchors[1][2])
+		}
+
+		// the watch time is the last non-empty line after the links
+		last := anchors[len(anchors)-1][0]
+		lines := strings.Split(content[strings.LastIndex(content, last)+len(last):], "<br>")
+		for i := len(lines) - 1; i >= 0 && entry.WatchedAt.IsZero(); i-- {
+			entry.WatchedAt = parseTakeoutTime(cleanHTMLText(lines[i]))
+		}
+		if entry.WatchedAt.IsZero() {
+			continue
+		}
+
+		if finishEntry(&entry) {
+			entries = append(entries, entry)
+		}
+	}
+
+	return entries, nil
+}
+
+func parseTakeoutTime(s string) time.Time {
+	for _, layout := range takeoutHTMLLayouts {
+		if t, err := time.ParseInLocation(layout, s, time.Local); err == nil {
+			return t
+		}
+	}
+	return time.Time{}
+}
+
+func cleanHTMLText(s string) string {
+	s = html.UnescapeString(takeoutTagRegex.ReplaceAllString(s, ""))
+	// newer exports use (narrow) no-break spaces around the time
+	s = strings.NewReplacer("\u202f", " ", "\u00a0", " ").Replace(s)
+	return strings.TrimSpace(s)
+}
+
+// finishEntry normalizes the title and fills the video ID, returning false
+// for entries that do not point at a video
+func finishEntry(entry *TakeoutEntry) bool {
+	if i := strings.Index(entry.URL, "/shorts/"); i != -1 {
+		entry.Short = true
+		id, _, _ := strings.Cut(entry.URL[i+len("/shorts/"):], "?")
+		entry.URL = "https://www.youtube.com/watch?v=" + id
+	}
+
+	entry.VideoID = services.ExtractVideoID(entry.URL)
+	if entry.VideoID == "" {
+		return false
+	}
+
+	entry.Title = strings.TrimSpace(strings.TrimPrefix(entry.Title, "Watched "))
+	if strings.Contains(strings.ToLower(entry.Title), "#shorts") {
+		entry.Short = true
+	}
+
+	return true
+}
+
+// TakeoutFilter narrows which watches become logs
+type TakeoutFilter struct {
+	From          time.Time // inclusive, zero for no limit
+	To            time.Time // inclusive day, zero for no limit
+	ExcludeAds    bool
+	ExcludeShorts bool
+	MinRepeat     int // minimum times a video was watched
+}
+
+// TakeoutResult is the dry-run outcome of a takeout import
+type TakeoutResult struct {
+	Videos        []models.Video
+	Watches       int // entries in the file
+	Ads           int
+	Shorts        int
+	OutOfRange    int
+	BelowRepeat   int
+	AlreadyLogged int
+	Invalid       int // missing a title, channel or valid link
+}
+
+// PlanTakeout groups watches by video and builds one log per video, dated
+// at its most recent watch in range. Videos already logged, and ones that
+// fail the log form's rules, are skipped.
+func PlanTakeout(entries []TakeoutEntry, existing []models.Video, filter TakeoutFilter) TakeoutResult {
+	result := TakeoutResult{Watches: len(entries)}
+
+	logged := make(map[string]bool)
+	for _, video := range existing {
+		if id := services.ExtractVideoID(video.URL); id != "" {
+			logged[id] = true
+		}
+	}
+
+	type group struct {
+		latest TakeoutEntry
+		first  time.Time
+		count  int
+	}
+	groups := make(map[string]*group)
+	var order []string
+
+	for _, entry := range entries {
+		switch {
+		case filter.ExcludeAds && entry.Ad:
+			result.Ads++
+			continue
+		case filter.ExcludeShorts && entry.Short:
+			result.Shorts++
+			continue
+		case !inRange(entry.WatchedAt, filter):
+			result.OutOfRange++
+			continue
+		}
+
+		g, ok := groups[entry.VideoID]
+		if !ok {
+			g = &group{latest: entry, first: entry.WatchedAt}
+			groups[entry.VideoID] = g
+			order = append(order, entry.VideoID)
+		}
+		g.count++
+		if entry.WatchedAt.After(g.latest.WatchedAt) {
+			g.latest = entry
+		}
+		if entry.WatchedAt.Before(g.first) {
+			g.first = entry.WatchedAt
+		}
+	}
+
+	for _, videoID := range order {
+		g := groups[videoID]
+		if g.count < filter.MinRepeat {
+			result.BelowRepeat++
+			continue
+		}
+		if logged[videoID] {
+			result.AlreadyLogged++
+			continue
+		}
+
+		video := models.Video{
+			URL:     "https://www.youtube.com/watch?v=" + videoID,
+			Title:   g.latest.Title,
+			Channel: g.latest.Channel,
+			// takeout has no release date, the first watch is the latest
+			// it can be
+			ReleaseDate: g.first.Local().Format(models.ISODateFormat),
+			LogDate:     g.latest.WatchedAt.Local(),
+			Rewatched:   g.count > 1,
+		}
+		if len(ValidateVideo(video)) > 0 {
+			result.Invalid++
+			continue
+		}
+		result.Videos = append(result.Videos, video)
+	}
+
+	models.SortVideosByLogDate(result.Videos)
+
+	return result
+}
+
+func inRange(t time.Time, filter TakeoutFilter) bool {
+	if !filter.From.IsZero() && t.Before(filter.From) {
+		return false
+	}
+	if !filter.To.IsZero() && !t.Before(filter.To.AddDate(0, 0, 1)) {
+		return false
+	}
+	return true
+}
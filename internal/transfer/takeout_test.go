@@ -0,0 +1,194 @@
+package transfer
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mamuzad/vidlogd/internal/models"
+)
+
+const takeoutJSON = `[
+  {
+    "header": "YouTube",
+    "title": "Watched Go concurrency",
+    "titleUrl": "https://www.youtube.com/watch?v=aaaaaaaaaaa",
+    "subtitles": [{"name": "Fireship", "url": "https://www.youtube.com/channel/UC1"}],
+    "time": "2025-02-03T10:00:00.000Z"
+  },
+  {
+    "header": "YouTube",
+    "title": "Watched Go concurrency",
+    "titleUrl": "https://www.youtube.com/watch?v=aaaaaaaaaaa",
+    "subtitles": [{"name": "Fireship", "url": "https://www.youtube.com/channel/UC1"}],
+    "time": "2025-01-03T10:00:00.000Z"
+  },
+  {
+    "header": "YouTube",
+    "title": "Watched Buy now",
+    "titleUrl": "https://www.youtube.com/watch?v=bbbbbbbbbbb",
+    "subtitles": [{"name": "Shop"}],
+    "time": "2025-01-04T10:00:00.000Z",
+    "details": [{"name": "From Google Ads"}]
+  },
+  {
+    "header": "YouTube",
+    "title": "Watched quick tip #shorts",
+    "titleUrl": "https://www.youtube.com/watch?v=ccccccccccc",
+    "subtitles": [{"name": "Tips"}],
+    "time": "2025-01-05T10:00:00.000Z"
+  },
+  {
+    "header": "YouTube",
+    "title": "Watched a video that has been removed",
+    "time": "2025-01-06T10:00:00.000Z"
+  },
+  {
+    "header": "YouTube",
+    "title": "Watched old talk",
+    "titleUrl": "https://www.youtube.com/watch?v=ddddddddddd",
+    "subtitles": [{"name": "GopherCon"}],
+    "time": "2023-06-01T10:00:00.000Z"
+  }
+]`
+
+const takeoutHTML = `<html><body><div class="mdl-grid">` +
+	`<div class="outer-cell mdl-cell mdl-cell--12-col mdl-shadow--2dp"><div class="mdl-grid">` +
+	`<div class="header-cell mdl-cell mdl-cell--12-col"><p class="mdl-typography--title">YouTube<br></p></div>` +
+	`<div class="content-cell mdl-cell mdl-cell--6-col mdl-typography--body-1">Watched&nbsp;<a href="https://www.youtube.com/watch?v=aaaaaaaaaaa">Rust &amp; Go</a><br>` +
+	`<a href="https://www.youtube.com/channel/UC1">No Boilerplate</a><br>Mar 4, 2024, 9:15:32` + "\u202f" + `PM UTC<br></div>` +
+	`<div class="content-cell mdl-cell mdl-cell--12-col mdl-typography--caption"><b>Products:</b><br>&emsp;YouTube<br>` +
+	`<a href="https://myaccount.google.com/activitycontrols">here</a></div></div></div>` +
+	`<div class="outer-cell mdl-cell mdl-cell--12-col mdl-shadow--2dp"><div class="mdl-grid">` +
+	`<div class="content-cell mdl-cell mdl-cell--6-col mdl-typography--body-1">Watched&nbsp;<a href="https://www.youtube.com/shorts/bbbbbbbbbbb">short</a><br>` +
+	`Mar 5, 2024, 8:00:00 AM UTC<br></div>` +
+	`<div class="content-cell mdl-cell mdl-cell--12-col mdl-typography--caption"><b>Details:</b><br>&emsp;From Google Ads<br></div></div></div>` +
+	`</div></body></html>`
+
+func TestParseTakeout_JSON(t *testing.T) {
+	entries, err := ParseTakeout(strings.NewReader(takeoutJSON))
+	if err != nil {
+		t.Fatalf("ParseTakeout: %v", err)
+	}
+	if len(entries) != 5 {
+		t.Fatalf("expected 5 entries (removed video dropped), got %d", len(entries))
+	}
+
+	first := entries[0]
+	if first.VideoID != "aaaaaaaaaaa" || first.Title != "Go concurrency" || first.Channel != "Fireship" {
+		t.Fatalf("unexpected first entry: %+v", first)
+	}
+	if !entries[1].WatchedAt.Equal(time.Date(2025, 1, 3, 10, 0, 0, 0, time.UTC)) {
+		t.Fatalf("unexpected watch time: %v", entries[1].WatchedAt)
+	}
+	if !entries[2].Ad || !entries[3].Short {
+		t.Fatalf("expected ad and short flags: %+v %+v", entries[2], entries[3])
+	}
+}
+
+func TestParseTakeout_HTML(t *testing.T) {
+	entries, err := ParseTakeout(strings.NewReader(takeoutHTML))
+	if err != nil {
+		t.Fatalf("ParseTakeout: %v", err)
+	}
+	if len(entries) != 2 {
+		t.Fatalf("expected 2 entries, got %d: %+v", len(entries), entries)
+	}
+
+	first := entries[0]
+	if first.Title != "Rust & Go" || first.Channel != "No Boilerplate" || first.VideoID != "aaaaaaaaaaa" {
+		t.Fatalf("unexpected first entry: %+v", first)
+	}
+	if !first.WatchedAt.Equal(time.Date(2024, 3, 4, 21, 15, 32, 0, time.UTC)) {
+		t.Fatalf("unexpected watch time: %v", first.WatchedAt)
+	}
+	if first.Ad || first.Short {
+		t.Fatalf("first entry should not be ad or short: %+v", first)
+	}
+
+	second := entries[1]
+	if !second.Short || !second.Ad || second.VideoID != "bbbbbbbbbbb" {
+		t.Fatalf("expected short ad entry, got %+v", second)
+	}
+}
+
+func TestPlanTakeout_Filters(t *testing.T) {
+	entries, err := ParseTakeout(strings.NewReader(takeoutJSON))
+	if err != nil {
+		t.Fatalf("ParseTakeout: %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		filter   TakeoutFilter
+		existing []models.Video
+		wantIDs  []string
+	}{
+		{
+			name:    "no filters",
+			wantIDs: []string{"aaaaaaaaaaa", "ccccccccccc", "bbbbbbbbbbb", "ddddddddddd"},
+		},
+		{
+			name:    "exclude ads and shorts",
+			filter:  TakeoutFilter{ExcludeAds: true, ExcludeShorts: true},
+			wantIDs: []string{"aaaaaaaaaaa", "ddddddddddd"},
+		},
+		{
+			name:    "date range",
+			filter:  TakeoutFilter{From: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC), To: time.Date(2025, 1, 4, 0, 0, 0, 0, time.UTC)},
+			wantIDs: []string{"bbbbbbbbbbb", "aaaaaaaaaaa"},
+		},
+		{
+			name:    "min repeat",
+			filter:  TakeoutFilter{MinRepeat: 2},
+			wantIDs: []string{"aaaaaaaaaaa"},
+		},
+		{
+			name:     "already logged",
+			existing: []models.Video{{URL: "https://youtu.be/aaaaaaaaaaa"}},
+			wantIDs:  []string{"ccccccccccc", "bbbbbbbbbbb", "ddddddddddd"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := PlanTakeout(entries, tt.existing, tt.filter)
+			var got []string
+			for _, v := range result.Videos {
+				got = append(got, strings.TrimPrefix(v.URL, "https://www.youtube.com/watch?v="))
+			}
+			if strings.Join(got, ",") != strings.Join(tt.wantIDs, ",") {
+				t.Fatalf("got %v, want %v", got, tt.wantIDs)
+			}
+		})
+	}
+
+	// repeated watches collapse into one rewatched log at the latest time
+	result := PlanTakeout(entries, nil, TakeoutFilter{})
+	first := result.Videos[0]
+	if !first.Rewatched || !first.LogDate.Equal(time.Date(2025, 2, 3, 10, 0, 0, 0, time.UTC)) {
+		t.Fatalf("unexpected grouped video: %+v", first)
+	}
+	for _, video := range result.Videos {
+		if errs := ValidateVideo(video); len(errs) > 0 {
+			t.Errorf("planned video %q is not importable: %v", video.Title, errs)
+		}
+	}
+}
+
+func TestPlanTakeout_Invalid(t *testing.T) {
+	watched := time.Date(2025, 1, 3, 10, 0, 0, 0, time.UTC)
+	entries := []TakeoutEntry{
+		{VideoID: "aaaaaaaaaaa", Title: "Rust", Channel: "No Boilerplate", WatchedAt: watched},
+		{VideoID: "aaaaaaaaaaa", Title: "Rust", Channel: "No Boilerplate", WatchedAt: watched.AddDate(0, 1, 0)},
+		{VideoID: "bbbbbbbbbbb", Title: "Buy now", WatchedAt: watched},
+	}
+
+	result := PlanTakeout(entries, nil, TakeoutFilter{})
+	if len(result.Videos) != 1 || result.Invalid != 1 {
+		t.Fatalf("expected the video without a channel to be skipped, got %+v", result)
+	}
+	if got := result.Videos[0].ReleaseDate; got != watched.Local().Format(models.ISODateFormat) {
+		t.Errorf("expected the first watch as release date, got %q", got)
+	}
+}
@@ -0,0 +1,233 @@
+package ui
+
+import (
+	"os"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/help"
+	"github.com/charmbracelet/bubbles/list"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/mamuzad/vidlogd/internal/models"
+	"github.com/mamuzad/vidlogd/internal/ui/chart"
+)
+
+// accessibility modes, set by SetAccessibility
+var (
+	NoColor bool // NO_COLOR is set, focus is shown without color
+	ASCII   bool // plain ASCII symbols instead of Unicode glyphs
+	Linear  bool // left-aligned layout without boxes, for screen readers
+)
+
+// SymbolSet holds the glyphs used outside of charts, which follow ASCII
+// through chart.ASCII
+type SymbolSet struct {
+	Favorite  string
+	Warning   string
+	Check     string
+	Unsaved   string
+	Arrow     string
+	ArrowBack string
+	Ascending string // sort order
+	Falling   string // descending sort, dropping trend
+	Filled    string // heatmap and goal history cells
+	Hollow    string
+	Empty     string // heatmap days without videos
+	Bullet    string
+	Ellipsis  string
+	Separator string
+
+	// Nerd Font icons, left out or spelled plainly in ASCII mode
+	VideoIcon       string
+	FilmIcon        string
+	StarIcon        string
+	StarOutlineIcon string
+	RewatchIcon     string
+	FirstWatchIcon  string
+	ChannelIcon     string
+	SearchIcon      string
+	CalendarIcon    string
+	WeekIcon        string
+	ClockIcon       string
+	ErrorIcon       string
+}
+
+var (
+	unicodeSymbols = SymbolSet{Favorite: "★", Warning: "⚠", Check: "✓", Unsaved: "●", Arrow: "→", ArrowBack: "←",
+		Ascending: "▲", Falling: "▼", Filled: "■", Hollow: "□", Empty: "·", Bullet: "•", Ellipsis: "…", Separator: "·",
+		VideoIcon: "\uf16a", FilmIcon: "\uf03d", StarIcon: "\uf005", StarOutlineIcon: "\uf006", RewatchIcon: "\uf01e",
+		FirstWatchIcon: "\uf135", ChannelIcon: "\uf007", SearchIcon: "\uf002", CalendarIcon: "\uf073", WeekIcon: "\uf133",
+		ClockIcon: "\uf017", ErrorIcon: "\uf071"}
+	asciiSymbols = SymbolSet{Favorite: "*", Warning: "!", Check: "x", Unsaved: "*", Arrow: "->", ArrowBack: "<-",
+		Ascending: "^", Falling: "v", Filled: "#", Hollow: "o", Empty: ".", Bullet: "*", Ellipsis: "...", Separator: "|",
+		SearchIcon: "/", ErrorIcon: "!"}
+)
+
+// Symbols are the glyphs for the current mode
+var Symbols = unicodeSymbols
+
+// Icon returns an icon from Symbols followed by gap, or nothing when the
+// mode has no icon
+func Icon(icon, gap string) string {
+	if icon == "" {
+		return ""
+	}
+	return icon + gap
+}
+
+// SetAccessibility applies the symbols (auto, unicode or ascii) and layout
+// (auto, centered or linear) settings and rebuilds the styles. "auto" or
+// empty picks ASCII for terminals and locales without Unicode and the
+// linear layout for dumb terminals.
+func SetAccessibility(symbols, layout string) {
+	NoColor = os.Getenv("NO_COLOR") != ""
+
+	switch symbols {
+	case "ascii":
+		ASCII = true
+	case "unicode":
+		ASCII = false
+	default:
+		ASCII = !unicodeTerminal()
+	}
+	Symbols = unicodeSymbols
+	if ASCII {
+		Symbols = asciiSymbols
+	}
+	chart.ASCII = ASCII
+
+	switch layout {
+	case "linear":
+		Linear = true
+	case "centered":
+		Linear = false
+	default:
+		Linear = os.Getenv("TERM") == "dumb"
+	}
+
+	initStyles()
+}
+
+// unicodeTerminal guesses whether the terminal can draw Unicode glyphs from
+// TERM and the locale
+func unicodeTerminal() bool {
+	switch os.Getenv("TERM") {
+	case "dumb", "linux", "vt100", "vt220":
+		return false
+	}
+	for _, name := range []string{"LC_ALL", "LC_CTYPE", "LANG"} {
+		if locale := os.Getenv(name); locale != "" {
+			locale = strings.ToLower(locale)
+			return strings.Contains(locale, "utf-8") || strings.Contains(locale, "utf8")
+		}
+	}
+	return true
+}
+
+// markFocus reports whether focus needs a marker besides color
+func markFocus() bool {
+	return NoColor || Linear
+}
+
+// Stars renders a rating as stars, or as "4.5/5" in ASCII mode
+func Stars(rating float64) string {
+	if ASCII {
+		return models.RatingText(rating)
+	}
+	return models.RatingStars(rating)
+}
+
+// Checkbox renders a checked or empty box
+func Checkbox(checked bool) string {
+	if checked {
+		return "[x]"
+	}
+	return "[ ]"
+}
+
+// RenderItem renders a list row, highlighted when selected. Without color
+// or in the linear layout the selected row is marked with ">".
+func RenderItem(text string, selected bool) string {
+	switch {
+	case selected && markFocus():
+		return MenuItemStyle.PaddingLeft(0).Render("> " + text)
+	case selected:
+		return MenuItemStyle.Background(PrimaryColor).Foreground(White).Render(text)
+	}
+	return MenuItemStyle.Render(text)
+}
+
+// RenderRow renders a row that starts with a space, in the table selection
+// style when selected. In the linear layout the space becomes ">".
+func RenderRow(line string, selected bool) string {
+	if !selected {
+		return line
+	}
+	if Linear && strings.HasPrefix(line, " ") {
+		line = ">" + line[1:]
+	}
+	return TableSelectedRowStyle.Render(line)
+}
+
+// Border is the box border for the current mode
+func Border() lipgloss.Border {
+	if ASCII {
+		return lipgloss.ASCIIBorder()
+	}
+	return lipgloss.RoundedBorder()
+}
+
+// focusBorder marks focused fields and buttons when color cannot
+func focusBorder() lipgloss.Border {
+	if ASCII {
+		return lipgloss.Border{Top: "=", Bottom: "=", Left: "#", Right: "#",
+			TopLeft: "#", TopRight: "#", BottomLeft: "#", BottomRight: "#"}
+	}
+	return lipgloss.ThickBorder()
+}
+
+// applyAccessibility adjusts the styles for the active modes. It runs after
+// the theme so focus stays visible whatever the theme sets.
+func applyAccessibility() {
+	if NoColor {
+		// reverse video and heavier borders stand in for the focus colors
+		TableSelectedRowStyle = TableSelectedRowStyle.Reverse(true)
+		FormFieldFocusedStyle = FormFieldFocusedStyle.BorderStyle(focusBorder())
+		ButtonStyleFocused = ButtonStyleFocused.BorderStyle(focusBorder()).Reverse(true)
+	}
+	if Linear {
+		// no popup box or centered title for screen readers to read around
+		PopupStyle = lipgloss.NewStyle()
+		TitleStyle = lipgloss.NewStyle().Foreground(PrimaryColor).Bold(true).Margin(0, 0, 1, 0)
+	}
+}
+
+// NewHelp returns a help view, with ASCII separators in ASCII mode
+func NewHelp() help.Model {
+	h := help.New()
+	if ASCII {
+		h.ShortSeparator = " | "
+		h.FullSeparator = "   "
+		h.Ellipsis = "..."
+	}
+	return h
+}
+
+// SetupList applies the key map and symbols mode to a list's keys, help
+// view and page dots. Views call it again from RefreshStyles when either
+// changes.
+func SetupList(l *list.Model) {
+	l.KeyMap = ListKeyMap()
+	showAll := l.Help.ShowAll
+	l.Help = NewHelp()
+	l.Help.ShowAll = showAll
+	styles := list.DefaultStyles()
+	l.Paginator.ActiveDot = styles.ActivePaginationDot.String()
+	l.Paginator.InactiveDot = styles.InactivePaginationDot.String()
+	if ASCII {
+		spellArrows(&l.KeyMap)
+		l.Paginator.ActiveDot = "*"
+		l.Paginator.InactiveDot = "."
+	}
+	// hides the keys of turned off features, such as filtering
+	l.SetFilteringEnabled(l.FilteringEnabled())
+}
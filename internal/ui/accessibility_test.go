@@ -0,0 +1,102 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestSetAccessibilityAuto(t *testing.T) {
+	t.Cleanup(func() { SetAccessibility("unicode", "centered") })
+
+	tests := []struct {
+		term, lang    string
+		ascii, linear bool
+	}{
+		{"xterm-256color", "en_US.UTF-8", false, false},
+		{"xterm-256color", "en_US.utf8", false, false},
+		{"xterm-256color", "C", true, false},
+		{"linux", "en_US.UTF-8", true, false},
+		{"dumb", "", true, true},
+	}
+	for _, tt := range tests {
+		t.Setenv("TERM", tt.term)
+		t.Setenv("LC_ALL", "")
+		t.Setenv("LC_CTYPE", "")
+		t.Setenv("LANG", tt.lang)
+		SetAccessibility("auto", "")
+		if ASCII != tt.ascii || Linear != tt.linear {
+			t.Errorf("TERM=%s LANG=%s: got ascii=%v linear=%v", tt.term, tt.lang, ASCII, Linear)
+		}
+	}
+
+	// explicit settings win over detection
+	SetAccessibility("unicode", "centered")
+	if ASCII || Linear {
+		t.Error("expected the settings to override a dumb terminal")
+	}
+}
+
+func TestASCIIMode(t *testing.T) {
+	t.Cleanup(func() { SetAccessibility("unicode", "centered") })
+	t.Setenv("NO_COLOR", "")
+
+	SetAccessibility("unicode", "centered")
+	if got := Stars(4.5); got != "★★★★⯨" {
+		t.Errorf("expected stars, got %q", got)
+	}
+
+	SetAccessibility("ascii", "centered")
+	for rating, want := range map[float64]string{4.5: "4.5/5", 3: "3/5", 0: "0/5"} {
+		if got := Stars(rating); got != want {
+			t.Errorf("Stars(%v) = %q, want %q", rating, got, want)
+		}
+	}
+	if Symbols.Warning != "!" || Border().TopLeft != "+" {
+		t.Errorf("expected ascii symbols and borders, got %+v", Symbols)
+	}
+	for _, r := range fmt.Sprint(Symbols) {
+		if r > 0x7F {
+			t.Errorf("unexpected %q in the ascii symbols", r)
+		}
+	}
+	if icon := Icon(Symbols.VideoIcon, " "); icon != "" {
+		t.Errorf("expected no video icon, got %q", icon)
+	}
+
+	km, err := LoadKeyMap(false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if help := km.Up.Help().Key; help != "up/k" {
+		t.Errorf("expected arrows spelled out, got %q", help)
+	}
+}
+
+func TestFocusWithoutColor(t *testing.T) {
+	t.Cleanup(func() { SetAccessibility("unicode", "centered") })
+
+	t.Setenv("NO_COLOR", "")
+	SetAccessibility("unicode", "centered")
+	if strings.Contains(RenderItem("logs", true), ">") {
+		t.Error("expected color alone to mark the selection")
+	}
+
+	t.Setenv("NO_COLOR", "1")
+	SetAccessibility("unicode", "centered")
+	if !NoColor || !strings.Contains(RenderItem("logs", true), "> logs") {
+		t.Errorf("expected a marker without color, got %q", RenderItem("logs", true))
+	}
+	if !TableSelectedRowStyle.GetReverse() {
+		t.Error("expected the selected row in reverse video")
+	}
+
+	t.Setenv("NO_COLOR", "")
+	SetAccessibility("unicode", "linear")
+	if got := RenderRow(" bulk delete ", true); !strings.Contains(got, ">bulk delete") {
+		t.Errorf("expected a marker in the linear layout, got %q", got)
+	}
+	if got := CenterHorizontally("x", 20); got != "x" {
+		t.Errorf("expected no centering in the linear layout, got %q", got)
+	}
+}
@@ -0,0 +1,65 @@
+package chart
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// gutter is the value axis to the left of a plot: the top of the scale on
+// the first row, the bottom on the last and a rule in between
+type gutter struct {
+	top, bottom string
+	width       int
+}
+
+func newGutter(o Options) gutter {
+	g := gutter{top: o.label(o.Max), bottom: o.label(o.Min)}
+	g.width = max(len(g.top), len(g.bottom)) + 2
+	return g
+}
+
+// row renders the gutter for plot row, counted from 1 at the bottom
+func (g gutter) row(row, height int) string {
+	if g.width == 0 {
+		return ""
+	}
+	label := ""
+	switch row {
+	case height:
+		label = g.top
+	case 1:
+		label = g.bottom
+	}
+	tick, labeled := "│", "┤"
+	if ASCII {
+		tick, labeled = "|", "+"
+	}
+	if label != "" {
+		tick = labeled
+	}
+	return strings.Repeat(" ", g.width-2-len(label)) + label + tick + " "
+}
+
+// axis lays out one label per slot, starting indent columns in. Labels may
+// run into the following slots when those are empty, are skipped when they
+// would touch the previous one and are pulled back in when they would pass
+// width.
+func axis(labels []string, slot, indent, width int) string {
+	var b strings.Builder
+	col := 0
+	for i, label := range labels {
+		if label == "" {
+			continue
+		}
+		w := lipgloss.Width(label)
+		start := min(i*slot+indent, width-w)
+		if start < 0 || col > 0 && start <= col {
+			continue
+		}
+		b.WriteString(strings.Repeat(" ", start-col))
+		b.WriteString(label)
+		col = start + w
+	}
+	return b.String()
+}
@@ -0,0 +1,386 @@
+// Package chart draws bar charts, braille line charts and sparklines as
+// plain text. Charts lay themselves out in the width they are given, so views
+// only pass the space left over from their own borders. With ASCII set they
+// are drawn without block, braille or box drawing characters.
+package chart
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Series is a list of values with an optional label for each. ValueLabels,
+// when set, are printed in place of the formatted values.
+type Series struct {
+	Labels      []string
+	Values      []float64
+	ValueLabels []string
+}
+
+// valueLabel returns what to print for value i
+func (s Series) valueLabel(i int, o Options) string {
+	if i < len(s.ValueLabels) {
+		return s.ValueLabels[i]
+	}
+	return o.label(s.Values[i])
+}
+
+// Options controls the size and scale of a chart
+type Options struct {
+	Width  int            // columns for the whole chart, axis included
+	Height int            // rows for the plot area
+	Min    float64        // bottom of the scale
+	Max    float64        // top of the scale, 0 fits the largest value
+	Format string         // value and axis labels, whole numbers by default
+	Style  lipgloss.Style // bars and lines
+}
+
+// defaults for options left empty
+const (
+	defaultWidth  = 54
+	defaultHeight = 8
+
+	// bars narrower than this get a value axis instead of values
+	minValueSlot = 4
+)
+
+// ASCII draws charts with plain ASCII characters
+var ASCII bool
+
+var (
+	vBlocks     = []rune(" ▁▂▃▄▅▆▇█")
+	hBlocks     = []rune(" ▏▎▍▌▋▊▉█")
+	sparkBlocks = []rune("▁▂▃▄▅▆▇█")
+
+	// ASCII has no partial blocks, so eighths round to a few steps
+	asciiVBlocks     = []rune(" ...====#")
+	asciiHBlocks     = []rune(" ---====#")
+	asciiSparkBlocks = []rune("_.:-=+*#")
+)
+
+// blocks returns the glyph table for the current mode
+func blocks(unicode, ascii []rune) []rune {
+	if ASCII {
+		return ascii
+	}
+	return unicode
+}
+
+func (o Options) withDefaults(values []float64) Options {
+	if o.Width <= 0 {
+		o.Width = defaultWidth
+	}
+	if o.Height <= 0 {
+		o.Height = defaultHeight
+	}
+	if o.Format == "" {
+		o.Format = "%.0f"
+	}
+	for _, v := range values {
+		o.Max = max(o.Max, v)
+	}
+	return o
+}
+
+func (o Options) label(v float64) string {
+	return fmt.Sprintf(o.Format, v)
+}
+
+// scale maps v to 0..steps on the chart's scale
+func (o Options) scale(v float64, steps int) int {
+	if o.Max <= o.Min {
+		return 0
+	}
+	return int(math.Round((v - o.Min) / (o.Max - o.Min) * float64(steps)))
+}
+
+// Bars draws vertical bars with labels below and the values underneath.
+// When the bars are too narrow to print values a value axis is drawn on the
+// left instead, and when there are more bars than fit the last ones are kept.
+func Bars(s Series, o Options) string {
+	if len(s.Values) == 0 {
+		return "No data available"
+	}
+	o = o.withDefaults(s.Values)
+
+	// bars are up to eight columns wide with a one column gap
+	var gutter gutter
+	plot := o.Width
+	slot := max(2, min(8, plot/len(s.Values)))
+	showValues := slot >= minValueSlot
+	if !showValues {
+		gutter = newGutter(o)
+		plot -= gutter.width
+		slot = max(2, min(8, plot/len(s.Values)))
+	}
+	if keep := plot / slot; keep < len(s.Values) {
+		s = s.last(keep)
+	}
+	values, labels := s.Values, s.Labels
+	glyphs := blocks(vBlocks, asciiVBlocks)
+
+	var b strings.Builder
+	for row := o.Height; row >= 1; row-- {
+		b.WriteString(gutter.row(row, o.Height))
+		var line strings.Builder
+		for _, v := range values {
+			eighths := o.scale(v, o.Height*8)
+			if v > o.Min && eighths == 0 {
+				eighths = 1 // keep small values visible
+			}
+			fill := max(0, min(8, eighths-(row-1)*8))
+			line.WriteString(strings.Repeat(string(glyphs[fill]), slot-1) + " ")
+		}
+		b.WriteString(o.Style.Render(strings.TrimRight(line.String(), " ")) + "\n")
+	}
+
+	pad := strings.Repeat(" ", gutter.width)
+	b.WriteString(pad + axis(labels, slot, 0, plot))
+
+	if showValues {
+		formatted := make([]string, len(values))
+		for i := range values {
+			label := s.valueLabel(i, o)
+			formatted[i] = strings.Repeat(" ", max(0, slot-1-lipgloss.Width(label))/2) + label
+		}
+		b.WriteString("\n" + pad + axis(formatted, slot, 0, plot))
+	}
+
+	return b.String()
+}
+
+// HBars draws one horizontal bar per value, labels on the left and values on
+// the right
+func HBars(s Series, o Options) string {
+	if len(s.Values) == 0 {
+		return "No data available"
+	}
+	o = o.withDefaults(s.Values)
+
+	labelWidth := 0
+	for _, label := range s.Labels {
+		labelWidth = max(labelWidth, lipgloss.Width(label))
+	}
+	labelWidth = min(labelWidth, o.Width/3)
+
+	valueWidth := 0
+	for i := range s.Values {
+		valueWidth = max(valueWidth, lipgloss.Width(s.valueLabel(i, o)))
+	}
+
+	barWidth := max(1, o.Width-labelWidth-valueWidth-2)
+	glyphs := blocks(hBlocks, asciiHBlocks)
+
+	lines := make([]string, len(s.Values))
+	for i, v := range s.Values {
+		label := ""
+		if i < len(s.Labels) {
+			label = truncate(s.Labels[i], labelWidth)
+		}
+		eighths := o.scale(v, barWidth*8)
+		if v > o.Min && eighths == 0 {
+			eighths = 1
+		}
+		bar := strings.Repeat(string(glyphs[8]), eighths/8)
+		if rest := eighths % 8; rest > 0 {
+			bar += string(glyphs[rest])
+		}
+		value := s.valueLabel(i, o)
+		lines[i] = pad(label, labelWidth) + " " + o.Style.Render(bar) + strings.Repeat(" ", barWidth-lipgloss.Width(bar)) +
+			" " + strings.Repeat(" ", valueWidth-lipgloss.Width(value)) + value
+	}
+	return strings.Join(lines, "\n")
+}
+
+// Line draws values as a braille line with a value axis on the left and
+// labels below. Each cell holds two points across and four down, so a chart
+// plots up to twice its width in values before averaging neighbours. In
+// ASCII mode each cell holds one point, drawn as "*" and joined by "|".
+func Line(s Series, o Options) string {
+	if len(s.Values) == 0 {
+		return "No data available"
+	}
+	if o.Max == 0 && o.Min == 0 {
+		o.Min = s.Values[0]
+		for _, v := range s.Values {
+			o.Min = min(o.Min, v)
+		}
+	}
+	o = o.withDefaults(s.Values)
+
+	gutter := newGutter(o)
+	plot := max(1, o.Width-gutter.width)
+	perX, perY, blank := 2, 4, rune(0x2800)
+	if ASCII {
+		perX, perY, blank = 1, 1, ' '
+	}
+	cols, rows := plot*perX, o.Height*perY
+
+	points := resample(s.Values, cols)
+	xs := make([]int, len(points))
+	for i := range points {
+		if len(points) > 1 {
+			xs[i] = int(math.Round(float64(i) * float64(cols-1) / float64(len(points)-1)))
+		}
+	}
+
+	// y per dot column, interpolated between points, 0 at the bottom
+	ys := make([]int, cols)
+	for i := range ys {
+		ys[i] = -1
+	}
+	for i, p := range points {
+		y := o.scale(p, rows-1)
+		ys[xs[i]] = y
+		if i == 0 {
+			continue
+		}
+		x0, y0 := xs[i-1], o.scale(points[i-1], rows-1)
+		for x := x0 + 1; x < xs[i]; x++ {
+			ys[x] = y0 + int(math.Round(float64((y-y0)*(x-x0))/float64(xs[i]-x0)))
+		}
+	}
+
+	cells := make([][]rune, o.Height)
+	for r := range cells {
+		cells[r] = make([]rune, plot)
+		for c := range cells[r] {
+			cells[r][c] = blank
+		}
+	}
+	prev := -1
+	for x, y := range ys {
+		if y < 0 {
+			continue
+		}
+		// fill the gap to the previous column so steep lines stay joined
+		lo, hi := y, y
+		if prev >= 0 && prev < y {
+			lo = prev + 1
+		} else if prev > y {
+			hi = prev - 1
+		}
+		for dy := lo; dy <= hi; dy++ {
+			setDot(cells, x, rows-1-dy, dy != y)
+		}
+		prev = y
+	}
+
+	var b strings.Builder
+	for r := range cells {
+		b.WriteString(gutter.row(o.Height-r, o.Height))
+		b.WriteString(o.Style.Render(string(cells[r])) + "\n")
+	}
+
+	// labels sit under the point they belong to
+	labels := make([]string, plot)
+	for i, label := range s.Labels {
+		if len(s.Values) == 1 {
+			labels[0] = label
+			break
+		}
+		x := int(math.Round(float64(i) * float64(plot-1) / float64(len(s.Values)-1)))
+		if label != "" {
+			labels[x] = label
+		}
+	}
+	b.WriteString(strings.Repeat(" ", gutter.width) + axis(labels, 1, 0, plot))
+
+	return strings.TrimRight(b.String(), "\n ")
+}
+
+// Sparkline draws values between lo and hi in at most width cells,
+// averaging neighbouring values when there are more than fit
+func Sparkline(values []float64, lo, hi float64, width int) string {
+	if len(values) == 0 || width <= 0 {
+		return ""
+	}
+
+	glyphs := blocks(sparkBlocks, asciiSparkBlocks)
+	var line strings.Builder
+	for _, v := range resample(values, width) {
+		level := 0
+		if hi > lo {
+			level = int(math.Round((v - lo) / (hi - lo) * float64(len(glyphs)-1)))
+		}
+		line.WriteRune(glyphs[max(0, min(level, len(glyphs)-1))])
+	}
+	return line.String()
+}
+
+// braille dot bits by column and row within a cell
+var brailleDots = [2][4]rune{
+	{0x01, 0x02, 0x04, 0x40},
+	{0x08, 0x10, 0x20, 0x80},
+}
+
+// setDot plots the point at dot x, y. Joins fill the gap between steep
+// neighbours, which ASCII cells draw differently from the points.
+func setDot(cells [][]rune, x, y int, join bool) {
+	if ASCII {
+		if y < 0 || y >= len(cells) || x < 0 || x >= len(cells[0]) {
+			return
+		}
+		if !join {
+			cells[y][x] = '*'
+		} else if cells[y][x] == ' ' {
+			cells[y][x] = '|'
+		}
+		return
+	}
+	if y < 0 || y >= len(cells)*4 || x < 0 || x >= len(cells[0])*2 {
+		return
+	}
+	cells[y/4][x/2] |= brailleDots[x%2][y%4]
+}
+
+// resample averages values down to at most n points
+func resample(values []float64, n int) []float64 {
+	if len(values) <= n {
+		return values
+	}
+	out := make([]float64, n)
+	for i := range out {
+		from, to := i*len(values)/n, (i+1)*len(values)/n
+		var sum float64
+		for _, v := range values[from:to] {
+			sum += v
+		}
+		out[i] = sum / float64(to-from)
+	}
+	return out
+}
+
+// last keeps the last n values and their labels
+func (s Series) last(n int) Series {
+	tail := func(labels []string) []string {
+		if len(labels) > n {
+			return labels[len(labels)-n:]
+		}
+		return labels
+	}
+	return Series{Labels: tail(s.Labels), Values: s.Values[len(s.Values)-n:], ValueLabels: tail(s.ValueLabels)}
+}
+
+// pad fills s with spaces to width display columns
+func pad(s string, width int) string {
+	return s + strings.Repeat(" ", max(0, width-lipgloss.Width(s)))
+}
+
+func truncate(s string, width int) string {
+	if lipgloss.Width(s) <= width {
+		return s
+	}
+	ellipsis := "…"
+	if ASCII {
+		ellipsis = "..."
+	}
+	runes := []rune(s)
+	for len(runes) > 0 && lipgloss.Width(string(runes))+len([]rune(ellipsis)) > width {
+		runes = runes[:len(runes)-1]
+	}
+	return string(runes) + ellipsis
+}
@@ -0,0 +1,171 @@
+package chart
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+func widest(s string) int {
+	most := 0
+	for _, line := range strings.Split(s, "\n") {
+		most = max(most, lipgloss.Width(line))
+	}
+	return most
+}
+
+func TestBars(t *testing.T) {
+	s := Series{Labels: []string{"a", "b", "c"}, Values: []float64{1, 2, 4}}
+
+	out := Bars(s, Options{Width: 30, Height: 4})
+	lines := strings.Split(out, "\n")
+
+	// four plot rows, labels and values
+	if len(lines) != 6 {
+		t.Fatalf("got %d lines:\n%s", len(lines), out)
+	}
+	if w := widest(out); w > 30 {
+		t.Errorf("width %d over 30:\n%s", w, out)
+	}
+	// the tallest bar fills the top row, the smallest only the bottom one
+	if strings.Count(lines[0], "█") != 7 || !strings.Contains(lines[3], "███████ ███████ ███████") {
+		t.Errorf("unexpected bars:\n%s", out)
+	}
+	if !strings.Contains(lines[5], "4") {
+		t.Errorf("values row missing:\n%s", out)
+	}
+}
+
+func TestBarsKeepsLastWhenNarrow(t *testing.T) {
+	values := make([]float64, 40)
+	labels := make([]string, 40)
+	for i := range values {
+		values[i] = float64(i)
+	}
+	labels[39] = "z"
+
+	out := Bars(Series{Labels: labels, Values: values}, Options{Width: 24, Height: 2})
+	if w := widest(out); w > 24 {
+		t.Errorf("width %d over 24:\n%s", w, out)
+	}
+	if !strings.Contains(out, "z") {
+		t.Errorf("expected the most recent label to be kept:\n%s", out)
+	}
+	// narrow bars trade the values row for an axis
+	lines := strings.Split(out, "\n")
+	if len(lines) != 3 || !strings.HasPrefix(lines[0], "39┤ ") || !strings.HasPrefix(lines[1], " 0┤ ") {
+		t.Errorf("unexpected chart:\n%s", out)
+	}
+}
+
+func TestHBars(t *testing.T) {
+	s := Series{Labels: []string{"short", "a much longer label"}, Values: []float64{10, 5}}
+
+	out := HBars(s, Options{Width: 40})
+	lines := strings.Split(out, "\n")
+
+	if len(lines) != 2 {
+		t.Fatalf("got %d lines", len(lines))
+	}
+	for _, line := range lines {
+		if w := lipgloss.Width(line); w != 40 {
+			t.Errorf("line width %d, want 40: %q", w, line)
+		}
+	}
+	if !strings.Contains(lines[1], "…") {
+		t.Errorf("long label not truncated: %q", lines[1])
+	}
+	full, half := strings.Count(lines[0], "█"), strings.Count(lines[1], "█")
+	if full == 0 || half > full/2+1 || half < full/2-1 {
+		t.Errorf("bars not proportional: %d and %d", full, half)
+	}
+}
+
+func TestLine(t *testing.T) {
+	out := Line(Series{Labels: []string{"start", "", "end"}, Values: []float64{1, 2, 3}}, Options{Width: 12, Height: 2})
+	lines := strings.Split(out, "\n")
+
+	if len(lines) != 3 {
+		t.Fatalf("got %d lines:\n%s", len(lines), out)
+	}
+	if !strings.HasPrefix(lines[0], "3┤ ") || !strings.HasPrefix(lines[1], "1┤ ") {
+		t.Errorf("axis should fit the data:\n%s", out)
+	}
+	// a rising line starts in the bottom left and ends in the top right
+	plot0, plot1 := []rune(lines[0][len("3┤ "):]), []rune(lines[1][len("1┤ "):])
+	if plot1[0]&0x40 == 0 || plot0[len(plot0)-1]&0x08 == 0 {
+		t.Errorf("unexpected line:\n%s", out)
+	}
+	if !strings.HasPrefix(strings.TrimSpace(lines[2]), "start") || !strings.HasSuffix(lines[2], "end") {
+		t.Errorf("labels = %q", lines[2])
+	}
+	if w := widest(out); w > 12 {
+		t.Errorf("width %d over 12", w)
+	}
+}
+
+func TestSparkline(t *testing.T) {
+	tests := []struct {
+		name   string
+		values []float64
+		lo, hi float64
+		width  int
+		want   string
+	}{
+		{"empty", nil, 0, 1, 10, ""},
+		{"range", []float64{0, 0.5, 1}, 0, 1, 10, "▁▅█"},
+		{"clamped", []float64{-1, 2}, 0, 1, 10, "▁█"},
+		{"flat", []float64{3, 3}, 3, 3, 10, "▁▁"},
+		{"averaged", []float64{0, 0, 1, 1}, 0, 1, 2, "▁█"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Sparkline(tt.values, tt.lo, tt.hi, tt.width); got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAxis(t *testing.T) {
+	tests := []struct {
+		name   string
+		labels []string
+		slot   int
+		width  int
+		want   string
+	}{
+		{"one per slot", []string{"a", "b"}, 3, 10, "a  b"},
+		{"overflow into empty slots", []string{"long", "", "", "x"}, 2, 10, "long  x"},
+		{"touching labels skipped", []string{"long", "", "x"}, 2, 10, "long"},
+		{"skip when crowded", []string{"abc", "def", "ghi"}, 2, 10, "abc ghi"},
+		{"pulled back from the edge", []string{"", "", "end"}, 4, 10, "       end"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := axis(tt.labels, tt.slot, 0, tt.width); got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestASCII(t *testing.T) {
+	ASCII = true
+	defer func() { ASCII = false }()
+
+	s := Series{Labels: []string{"a long label", "b"}, Values: []float64{1, 2.5, 4, 3}}
+	out := Bars(s, Options{Width: 20, Height: 4}) + "\n" +
+		HBars(s, Options{Width: 20}) + "\n" +
+		Line(s, Options{Width: 20, Height: 4}) + "\n" +
+		Sparkline(s.Values, 0, 4, 10)
+	for _, r := range out {
+		if r > 0x7F {
+			t.Fatalf("unexpected %q in ASCII charts:\n%s", r, out)
+		}
+	}
+	if !strings.Contains(out, "#") || !strings.Contains(out, "*") {
+		t.Errorf("expected bars and line points:\n%s", out)
+	}
+}
@@ -9,7 +9,8 @@ import (
 )
 
 type DeleteConfirmMsg struct {
-	TargetID string
+	TargetID  string
+	TargetIDs []string // set when several videos were confirmed at once
 }
 
 type DeleteCancelMsg struct{}
@@ -17,6 +18,7 @@ type DeleteCancelMsg struct{}
 type DeleteModal struct {
 	Visible bool
 	Target  *models.Video
+	Targets []models.Video
 }
 
 func NewDeleteModal() DeleteModal {
@@ -28,9 +30,21 @@ func (m *DeleteModal) Show(target *models.Video) {
 	m.Target = target
 }
 
+// ShowMany asks once before deleting all of targets
+func (m *DeleteModal) ShowMany(targets []models.Video) {
+	if len(targets) == 1 {
+		m.Show(&targets[0])
+		return
+	}
+	m.Visible = true
+	m.Target = nil
+	m.Targets = targets
+}
+
 func (m *DeleteModal) Hide() {
 	m.Visible = false
 	m.Target = nil
+	m.Targets = nil
 }
 
 func (m *DeleteModal) Update(msg tea.KeyMsg) (handled bool, cmd tea.Cmd) {
@@ -40,12 +54,15 @@ func (m *DeleteModal) Update(msg tea.KeyMsg) (handled bool, cmd tea.Cmd) {
 
 	switch {
 	case key.Matches(msg, GlobalKeyMap.Yes):
-		targetID := ""
+		var confirm DeleteConfirmMsg
 		if m.Target != nil {
-			targetID = m.Target.ID
+			confirm.TargetID = m.Target.ID
+		}
+		for _, target := range m.Targets {
+			confirm.TargetIDs = append(confirm.TargetIDs, target.ID)
 		}
 		m.Hide()
-		return true, func() tea.Msg { return DeleteConfirmMsg{TargetID: targetID} }
+		return true, func() tea.Msg { return confirm }
 
 	default:
 		m.Hide()
@@ -55,16 +72,19 @@ func (m *DeleteModal) Update(msg tea.KeyMsg) (handled bool, cmd tea.Cmd) {
 }
 
 func (m DeleteModal) View(width int, py int, px int) string {
-	if !m.Visible || m.Target == nil {
+	if !m.Visible || m.Target == nil && len(m.Targets) == 0 {
 		return ""
 	}
 
-	title := m.Target.Title
+	question := fmt.Sprintf("Delete %d videos?", len(m.Targets))
+	if m.Target != nil {
+		question = fmt.Sprintf("Delete \"%s\"?", m.Target.Title)
+	}
 	yesHelp := GlobalKeyMap.Yes.Help().Key
 	noHelp := GlobalKeyMap.No.Help().Key
 	body := ModalStyle.Padding(py, px).Render(
 		DangerStyle.Render("Confirm delete") + "\n\n" +
-			fmt.Sprintf("Delete \"%s\"?\n\n", title) +
+			question + "\n\n" +
 			DescriptionStyle.Render(fmt.Sprintf("%s: delete   %s: cancel", yesHelp, noHelp)),
 	)
 
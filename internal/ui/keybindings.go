@@ -0,0 +1,231 @@
+package ui
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/mamuzad/vidlogd/internal/models"
+)
+
+// NamedBinding is a key map action with the name used in the keybindings
+// file, e.g. "ratingUp"
+type NamedBinding struct {
+	Name    string
+	Binding key.Binding
+}
+
+// Bindings returns every action in the key map, in declaration order
+func (km KeyMap) Bindings() []NamedBinding {
+	v := reflect.ValueOf(km)
+	bindings := make([]NamedBinding, 0, v.NumField())
+	for i := 0; i < v.NumField(); i++ {
+		if b, ok := v.Field(i).Interface().(key.Binding); ok {
+			bindings = append(bindings, NamedBinding{Name: actionName(v.Type().Field(i).Name), Binding: b})
+		}
+	}
+	return bindings
+}
+
+// Override replaces the keys of the named actions. Names match field names
+// ignoring case, "-" and "_", so "ratingUp" and "rating-up" both work.
+func (km *KeyMap) Override(bindings models.Keybindings) error {
+	fields := make(map[string]int)
+	t := reflect.TypeOf(*km)
+	for i := 0; i < t.NumField(); i++ {
+		fields[normalizeActionName(t.Field(i).Name)] = i
+	}
+
+	names := make([]string, 0, len(bindings))
+	for name := range bindings {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var errs []error
+	v := reflect.ValueOf(km).Elem()
+	for _, name := range names {
+		i, ok := fields[normalizeActionName(name)]
+		if !ok {
+			errs = append(errs, fmt.Errorf("unknown action %q", name))
+			continue
+		}
+		b := v.Field(i).Addr().Interface().(*key.Binding)
+		keys := bindings[name]
+		b.SetKeys(keys...)
+		b.SetHelp(keysHelp(keys), b.Help().Desc)
+		b.SetEnabled(len(keys) > 0)
+	}
+	return errors.Join(errs...)
+}
+
+// actionName turns a KeyMap field name into its keybindings file name
+func actionName(field string) string {
+	return strings.ToLower(field[:1]) + field[1:]
+}
+
+func normalizeActionName(name string) string {
+	return strings.ToLower(strings.NewReplacer("-", "", "_", "").Replace(name))
+}
+
+// keyGlyphs shortens key names in help, as in the default bindings
+var keyGlyphs = map[string]string{
+	"up":    "↑",
+	"down":  "↓",
+	"left":  "←",
+	"right": "→",
+	" ":     "space",
+}
+
+// keysHelp renders keys the way the help views show them, e.g. "↑/e",
+// spelling out arrows in ASCII mode
+func keysHelp(keys []string) string {
+	labels := make([]string, len(keys))
+	for i, k := range keys {
+		labels[i] = k
+		if glyph, ok := keyGlyphs[k]; ok && (!ASCII || k == " ") {
+			labels[i] = glyph
+		}
+	}
+	return strings.Join(labels, "/")
+}
+
+var arrowNames = strings.NewReplacer("↑", "up", "↓", "down", "←", "left", "→", "right")
+
+// spellArrows replaces the arrow glyphs in the help of every binding in
+// keyMap, a pointer to a key map struct, with key names
+func spellArrows(keyMap any) {
+	v := reflect.ValueOf(keyMap).Elem()
+	for i := 0; i < v.NumField(); i++ {
+		if b, ok := v.Field(i).Addr().Interface().(*key.Binding); ok {
+			b.SetHelp(arrowNames.Replace(b.Help().Key), b.Help().Desc)
+		}
+	}
+}
+
+// Relabel returns b with a view-specific description, keeping its keys
+func Relabel(b key.Binding, desc string) key.Binding {
+	b.SetHelp(b.Help().Key, desc)
+	return b
+}
+
+// globalActions are active in every view
+var globalActions = []string{"Exit", "Help", "ErrorLog"}
+
+// keyScope is the set of actions one view listens to at the same time
+type keyScope struct {
+	name    string
+	actions []string
+}
+
+// keyScopes are registered by the views next to their Update, plus the
+// confirm prompt shared by every delete
+var keyScopes = []keyScope{
+	{"confirm", []string{"Yes", "No"}},
+}
+
+// RegisterKeyScope adds the KeyMap fields a view listens to at the same
+// time, so keybindings that collide in it are reported. Back and Cancel are
+// left out where a view treats them as one action.
+func RegisterKeyScope(name string, actions ...string) {
+	keyScopes = append(keyScopes, keyScope{name: name, actions: actions})
+}
+
+// Conflict is a key bound to more than one action in the same view
+type Conflict struct {
+	Scope   string
+	Key     string
+	Actions []string
+}
+
+func (c Conflict) Error() string {
+	return fmt.Sprintf("%q is bound to both %s in %s", keysHelp([]string{c.Key}), strings.Join(c.Actions, " and "), c.Scope)
+}
+
+// Conflicts returns the keys bound to more than one action within a view
+func (km KeyMap) Conflicts() []Conflict {
+	byName := make(map[string]key.Binding)
+	for _, nb := range km.Bindings() {
+		byName[nb.Name] = nb.Binding
+	}
+
+	var conflicts []Conflict
+	for _, scope := range keyScopes {
+		actions := make(map[string][]string)
+		var keys []string
+		for _, field := range append(append([]string(nil), globalActions...), scope.actions...) {
+			name := actionName(field)
+			b := byName[name]
+			if !b.Enabled() {
+				continue
+			}
+			for _, k := range b.Keys() {
+				switch {
+				case len(actions[k]) == 0:
+					keys = append(keys, k)
+				case slices.Contains(actions[k], name):
+					// listed twice for the same action
+					continue
+				}
+				actions[k] = append(actions[k], name)
+			}
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			if len(actions[k]) > 1 {
+				conflicts = append(conflicts, Conflict{Scope: scope.name, Key: k, Actions: actions[k]})
+			}
+		}
+	}
+	return conflicts
+}
+
+// LoadKeyMap builds the key map with the user's keybindings applied.
+// Unknown actions and bindings that cause conflicts are left at their
+// defaults and returned in the error; the other bindings still apply.
+func LoadKeyMap(useVim bool) (KeyMap, error) {
+	km := NewKeyMap(useVim)
+	if ASCII {
+		spellArrows(&km)
+	}
+	bindings, err := models.LoadKeybindings()
+	if err != nil {
+		return km, err
+	}
+
+	var errs []error
+	custom := km
+	if err := custom.Override(bindings); err != nil {
+		// unknown actions, the known ones are applied
+		errs = append(errs, err)
+	}
+	for conflicts := custom.Conflicts(); len(conflicts) > 0; conflicts = custom.Conflicts() {
+		// drop the user's bindings for the actions in each conflict, then
+		// check again since dropping one can uncover another
+		dropped := false
+		for _, c := range conflicts {
+			errs = append(errs, c)
+			for _, action := range c.Actions {
+				for name := range bindings {
+					if normalizeActionName(name) == normalizeActionName(action) {
+						delete(bindings, name)
+						dropped = true
+					}
+				}
+			}
+		}
+		if !dropped {
+			break
+		}
+		custom = km
+		_ = custom.Override(bindings) // unknown actions are reported above
+	}
+	if len(errs) > 0 {
+		return custom, fmt.Errorf("keybindings: %w", errors.Join(errs...))
+	}
+	return custom, nil
+}
@@ -0,0 +1,168 @@
+package ui
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/mamuzad/vidlogd/internal/models"
+	"github.com/mamuzad/vidlogd/internal/storage"
+)
+
+func TestDefaultKeyMapsHaveNoConflicts(t *testing.T) {
+	for _, vim := range []bool{false, true} {
+		if conflicts := NewKeyMap(vim).Conflicts(); len(conflicts) > 0 {
+			t.Errorf("vim=%v: unexpected conflicts %v", vim, conflicts)
+		}
+	}
+}
+
+func TestOverride(t *testing.T) {
+	km := NewKeyMap(false)
+	err := km.Override(models.Keybindings{
+		"up":        {"up", "e"},
+		"rating-up": {"right"},
+		"Delete":    {},
+	})
+	if err != nil {
+		t.Fatalf("Override: %v", err)
+	}
+
+	if keys := strings.Join(km.Up.Keys(), ","); keys != "up,e" {
+		t.Errorf("expected up to be rebound, got %s", keys)
+	}
+	if help := km.Up.Help(); help.Key != "↑/e" || help.Desc != "up" {
+		t.Errorf("expected help to show the new keys, got %+v", help)
+	}
+	if help := km.RatingUp.Help().Key; help != "→" {
+		t.Errorf("expected rating-up to match RatingUp, got %q", help)
+	}
+	if km.Delete.Enabled() {
+		t.Error("expected an empty list to unbind delete")
+	}
+	if keys := strings.Join(km.Down.Keys(), ","); keys != "down,j" {
+		t.Errorf("expected down to keep its defaults, got %s", keys)
+	}
+}
+
+func TestOverrideUnknownAction(t *testing.T) {
+	km := NewKeyMap(false)
+	if err := km.Override(models.Keybindings{"teleport": {"t"}}); err == nil || !strings.Contains(err.Error(), "teleport") {
+		t.Fatalf("expected an unknown action error, got %v", err)
+	}
+}
+
+// registerKeyScope adds a scope for one test, since the views that
+// register theirs are not part of these tests
+func registerKeyScope(t *testing.T, name string, actions ...string) {
+	saved := keyScopes
+	RegisterKeyScope(name, actions...)
+	t.Cleanup(func() { keyScopes = saved })
+}
+
+func TestConflicts(t *testing.T) {
+	registerKeyScope(t, "goals", "Up", "Down", "Select", "Edit", "Delete", "NewGoal", "Back")
+	km := NewKeyMap(false)
+	// colemak users moving down to n collide with new goal
+	if err := km.Override(models.Keybindings{"down": {"down", "n"}}); err != nil {
+		t.Fatalf("Override: %v", err)
+	}
+
+	conflicts := km.Conflicts()
+	if len(conflicts) != 1 {
+		t.Fatalf("expected one conflict, got %v", conflicts)
+	}
+	c := conflicts[0]
+	if c.Scope != "goals" || c.Key != "n" || strings.Join(c.Actions, ",") != "down,newGoal" {
+		t.Errorf("unexpected conflict %+v", c)
+	}
+}
+
+func TestConflictsRepeatedAction(t *testing.T) {
+	// a view may list an action in two places, it is still one action
+	registerKeyScope(t, "test", "Up", "Down", "Up")
+	km := NewKeyMap(false)
+	if err := km.Override(models.Keybindings{"up": {"x"}, "down": {"x"}}); err != nil {
+		t.Fatalf("Override: %v", err)
+	}
+
+	conflicts := km.Conflicts()
+	if len(conflicts) != 1 || strings.Join(conflicts[0].Actions, ",") != "up,down" {
+		t.Errorf("expected up and down once each, got %v", conflicts)
+	}
+}
+
+func TestLoadKeyMap(t *testing.T) {
+	registerKeyScope(t, "log list", "Up", "Down", "Edit", "Search", "SaveFilter")
+	t.Setenv("XDG_DATA_HOME", t.TempDir())
+	path, err := storage.KeybindingsPath()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := os.WriteFile(path, []byte(`{"search": ["f"]}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	km, err := LoadKeyMap(false)
+	if err != nil {
+		t.Fatalf("LoadKeyMap: %v", err)
+	}
+	if km.Search.Help().Key != "f" {
+		t.Errorf("expected search on f, got %q", km.Search.Help().Key)
+	}
+
+	// saving a filter is already on s in the log list
+	if err := os.WriteFile(path, []byte(`{"search": ["s"]}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	km, err = LoadKeyMap(false)
+	if err == nil {
+		t.Fatal("expected a conflict error")
+	}
+	if km.Search.Help().Key != "/" {
+		t.Errorf("expected the defaults after a conflict, got %q", km.Search.Help().Key)
+	}
+
+	// only the bad entries keep their defaults
+	if err := os.WriteFile(path, []byte(`{"search": ["s"], "edit": ["E"], "teleport": ["t"]}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	km, err = LoadKeyMap(false)
+	if err == nil || !strings.Contains(err.Error(), "teleport") || !strings.Contains(err.Error(), "search") {
+		t.Fatalf("expected the unknown action and the conflict, got %v", err)
+	}
+	if strings.Contains(err.Error(), "edit") {
+		t.Errorf("expected edit to be accepted, got %v", err)
+	}
+	if km.Edit.Help().Key != "E" || km.Search.Help().Key != "/" {
+		t.Errorf("expected edit on E and search on /, got %q and %q", km.Edit.Help().Key, km.Search.Help().Key)
+	}
+}
+
+func TestListKeyMap(t *testing.T) {
+	saved := GlobalKeyMap
+	t.Cleanup(func() { GlobalKeyMap = saved })
+
+	GlobalKeyMap = NewKeyMap(false)
+	if err := GlobalKeyMap.Override(models.Keybindings{"up": {"up", "e"}, "down": {"down", "g"}}); err != nil {
+		t.Fatalf("Override: %v", err)
+	}
+
+	lk := ListKeyMap()
+	if keys := strings.Join(lk.CursorUp.Keys(), ","); keys != "up,e" {
+		t.Errorf("expected the list to move up with e, got %s", keys)
+	}
+	if keys := strings.Join(lk.GoToStart.Keys(), ","); keys != "home" {
+		t.Errorf("expected g to leave go to start, got %s", keys)
+	}
+	if lk.Quit.Enabled() {
+		t.Error("expected no quit keys in lists")
+	}
+	if keys := strings.Join(TableKeyMap().LineDown.Keys(), ","); keys != "down,g" {
+		t.Errorf("expected the table to move down with g, got %s", keys)
+	}
+}
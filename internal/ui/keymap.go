@@ -1,7 +1,11 @@
 package ui
 
 import (
+	"slices"
+
 	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/list"
+	"github.com/charmbracelet/bubbles/table"
 	"github.com/mamuzad/vidlogd/internal/models"
 )
 
@@ -9,22 +13,27 @@ import (
 var GlobalKeyMap KeyMap
 
 // Initialize global keymap
-func InitKeyMap() {
+func InitKeyMap() error {
 	// Load settings to get vim preference
-	UpdateKeyMap()
+	return UpdateKeyMap()
 }
 
-// Update keymap based on current VimMotions setting
-func UpdateKeyMap() {
+// Update keymap based on current VimMotions setting and the user's
+// keybindings. On error the bad entries keep their defaults. Views pick up
+// the new keys for their lists and tables on the next UIRefreshMsg.
+func UpdateKeyMap() error {
 	settings := models.LoadSettings()
-	GlobalKeyMap = NewKeyMap(settings.VimMotions)
+	km, err := LoadKeyMap(settings.VimMotions)
+	GlobalKeyMap = km
+	return err
 }
 
 type KeyMap struct {
 	// global actions
-	Exit key.Binding
-	Back key.Binding
-	Help key.Binding // for toggling help
+	Exit     key.Binding
+	Back     key.Binding
+	Help     key.Binding // for toggling help
+	ErrorLog key.Binding
 
 	// navigation
 	Up     key.Binding
@@ -46,8 +55,42 @@ type KeyMap struct {
 	PrevField key.Binding
 
 	// stat navigation
-	Cycle     key.Binding
-	CycleBack key.Binding
+	Cycle      key.Binding
+	CycleBack  key.Binding
+	PrevPeriod key.Binding
+	NextPeriod key.Binding
+	Report     key.Binding
+	Ratings    key.Binding
+	Period     key.Binding
+	Goals      key.Binding
+	NewGoal    key.Binding
+
+	// channels
+	Channel  key.Binding
+	Favorite key.Binding
+	CopyLink key.Binding
+	Aliases  key.Binding
+
+	// duplicates and library checks
+	LogRewatch   key.Binding
+	OpenExisting key.Binding
+	MergeAll     key.Binding
+	FixAll       key.Binding
+
+	// saved filters
+	NextFilter   key.Binding
+	PrevFilter   key.Binding
+	SaveFilter   key.Binding
+	DeleteFilter key.Binding
+
+	// log list columns
+	Sort        key.Binding
+	SortReverse key.Binding
+	Columns     key.Binding
+
+	// multi-select
+	Mark        key.Binding
+	BulkActions key.Binding
 
 	// vim-specific
 	InsertMode key.Binding
@@ -72,9 +115,10 @@ type KeyMap struct {
 func NewKeyMap(useVim bool) KeyMap {
 	// base keymap
 	km := KeyMap{
-		Exit: key.NewBinding(key.WithKeys("ctrl+c"), key.WithHelp("ctrl+c", "exit")),
-		Back: key.NewBinding(key.WithKeys("q"), key.WithHelp("q", "back")),
-		Help: key.NewBinding(key.WithKeys("?"), key.WithHelp("?", "more")),
+		Exit:     key.NewBinding(key.WithKeys("ctrl+c"), key.WithHelp("ctrl+c", "exit")),
+		Back:     key.NewBinding(key.WithKeys("q"), key.WithHelp("q", "back")),
+		Help:     key.NewBinding(key.WithKeys("?"), key.WithHelp("?", "more")),
+		ErrorLog: key.NewBinding(key.WithKeys("ctrl+l"), key.WithHelp("ctrl+l", "error log")),
 
 		// navigation
 		Up:    key.NewBinding(key.WithKeys("up", "k"), key.WithHelp("↑/k", "up")),
@@ -83,8 +127,42 @@ func NewKeyMap(useVim bool) KeyMap {
 		Right: key.NewBinding(key.WithKeys("right", "l"), key.WithHelp("→/l", "right")),
 
 		// stat navigation
-		Cycle:     key.NewBinding(key.WithKeys("tab"), key.WithHelp("tab", "cycle")),
-		CycleBack: key.NewBinding(key.WithKeys("shift+tab"), key.WithHelp("shift+tab", "cycle back")),
+		Cycle:      key.NewBinding(key.WithKeys("tab"), key.WithHelp("tab", "cycle")),
+		CycleBack:  key.NewBinding(key.WithKeys("shift+tab"), key.WithHelp("shift+tab", "cycle back")),
+		PrevPeriod: key.NewBinding(key.WithKeys("<", ","), key.WithHelp("<", "earlier")),
+		NextPeriod: key.NewBinding(key.WithKeys(">", "."), key.WithHelp(">", "later")),
+		Report:     key.NewBinding(key.WithKeys("R"), key.WithHelp("R", "year in review")),
+		Ratings:    key.NewBinding(key.WithKeys("T"), key.WithHelp("T", "rating trends")),
+		Period:     key.NewBinding(key.WithKeys("g"), key.WithHelp("g", "day/week/month/quarter/year")),
+		Goals:      key.NewBinding(key.WithKeys("G"), key.WithHelp("G", "goals")),
+		NewGoal:    key.NewBinding(key.WithKeys("n"), key.WithHelp("n", "new goal")),
+
+		// channels
+		Channel:  key.NewBinding(key.WithKeys("C"), key.WithHelp("C", "channel page")),
+		Favorite: key.NewBinding(key.WithKeys("f"), key.WithHelp("f", "favorite")),
+		CopyLink: key.NewBinding(key.WithKeys("y"), key.WithHelp("y", "copy link")),
+		Aliases:  key.NewBinding(key.WithKeys("m"), key.WithHelp("m", "merge names")),
+
+		// duplicates and library checks
+		LogRewatch:   key.NewBinding(key.WithKeys("ctrl+r"), key.WithHelp("ctrl+r", "log as rewatch")),
+		OpenExisting: key.NewBinding(key.WithKeys("ctrl+o"), key.WithHelp("ctrl+o", "open existing")),
+		MergeAll:     key.NewBinding(key.WithKeys("M"), key.WithHelp("M", "merge all")),
+		FixAll:       key.NewBinding(key.WithKeys("F"), key.WithHelp("F", "fix all")),
+
+		// saved filters
+		NextFilter:   key.NewBinding(key.WithKeys("]"), key.WithHelp("]", "next filter")),
+		PrevFilter:   key.NewBinding(key.WithKeys("["), key.WithHelp("[", "prev filter")),
+		SaveFilter:   key.NewBinding(key.WithKeys("s"), key.WithHelp("s", "save filter")),
+		DeleteFilter: key.NewBinding(key.WithKeys("X"), key.WithHelp("X", "delete filter")),
+
+		// log list columns
+		Sort:        key.NewBinding(key.WithKeys("o"), key.WithHelp("o", "sort by next column")),
+		SortReverse: key.NewBinding(key.WithKeys("O"), key.WithHelp("O", "reverse sort")),
+		Columns:     key.NewBinding(key.WithKeys("c"), key.WithHelp("c", "columns")),
+
+		// multi-select
+		Mark:        key.NewBinding(key.WithKeys(" "), key.WithHelp("space", "mark")),
+		BulkActions: key.NewBinding(key.WithKeys("a"), key.WithHelp("a", "bulk actions")),
 
 		// common actions (include space for select)
 		Select:     key.NewBinding(key.WithKeys("enter"), key.WithHelp("enter", "select")),
@@ -92,7 +170,7 @@ func NewKeyMap(useVim bool) KeyMap {
 		Delete:     key.NewBinding(key.WithKeys("x", "d"), key.WithHelp("x/d", "delete")),
 		Save:       key.NewBinding(key.WithKeys("ctrl+s"), key.WithHelp("ctrl+s", "save")),
 		Search:     key.NewBinding(key.WithKeys("/"), key.WithHelp("/", "search")),
-		SearchBack: key.NewBinding(key.WithKeys("esc")),
+		SearchBack: key.NewBinding(key.WithKeys("esc"), key.WithHelp("esc", "close")),
 
 		// rating number inputs
 		Rating: key.NewBinding(
@@ -130,10 +208,10 @@ func NewKeyMap(useVim bool) KeyMap {
 		km.NextField = key.NewBinding(key.WithKeys("down", "tab"), key.WithHelp("↓/tab", "next field"))
 		km.PrevField = key.NewBinding(key.WithKeys("up", "shift+tab"), key.WithHelp("↑/shift+tab", "prev field"))
 
-		// no vim modes in standard mode
+		// no vim modes in standard mode, visual mode only selects list ranges
 		km.InsertMode = key.NewBinding(key.WithKeys(), key.WithHelp("", ""))
 		km.NormalMode = key.NewBinding(key.WithKeys(), key.WithHelp("", ""))
-		km.VisualMode = key.NewBinding(key.WithKeys(), key.WithHelp("", ""))
+		km.VisualMode = key.NewBinding(key.WithKeys("V"), key.WithHelp("V", "visual mode"))
 		km.Paste = key.NewBinding(key.WithKeys(), key.WithHelp("", ""))
 		km.Yank = key.NewBinding(key.WithKeys(), key.WithHelp("", ""))
 
@@ -144,3 +222,57 @@ func NewKeyMap(useVim bool) KeyMap {
 
 	return km
 }
+
+// ListKeyMap is the bubbles list key map moving with GlobalKeyMap's up,
+// down, left and right, so rebound keys work in every list. Quitting is
+// left to each view's back action.
+func ListKeyMap() list.KeyMap {
+	km := list.DefaultKeyMap()
+	km.CursorUp = GlobalKeyMap.Up
+	km.CursorDown = GlobalKeyMap.Down
+	km.PrevPage = withKeys(GlobalKeyMap.Left, "prev page", "pgup")
+	km.NextPage = withKeys(GlobalKeyMap.Right, "next page", "pgdown")
+	km.ShowFullHelp = withKeys(GlobalKeyMap.Help, "more")
+	km.CloseFullHelp = withKeys(GlobalKeyMap.Help, "close help")
+	km.Quit = key.NewBinding(key.WithKeys(), key.WithHelp("", ""))
+
+	taken := slices.Concat(km.CursorUp.Keys(), km.CursorDown.Keys(), km.PrevPage.Keys(), km.NextPage.Keys())
+	freeKeys(&km.GoToStart, taken)
+	freeKeys(&km.GoToEnd, taken)
+	return km
+}
+
+// TableKeyMap is the bubbles table key map moving with GlobalKeyMap's up
+// and down
+func TableKeyMap() table.KeyMap {
+	km := table.DefaultKeyMap()
+	km.LineUp = GlobalKeyMap.Up
+	km.LineDown = GlobalKeyMap.Down
+
+	taken := slices.Concat(km.LineUp.Keys(), km.LineDown.Keys())
+	for _, b := range []*key.Binding{&km.PageUp, &km.PageDown, &km.HalfPageUp, &km.HalfPageDown, &km.GotoTop, &km.GotoBottom} {
+		freeKeys(b, taken)
+	}
+	return km
+}
+
+// withKeys returns a binding on the keys of b and extra, described as desc
+func withKeys(b key.Binding, desc string, extra ...string) key.Binding {
+	keys := slices.Concat(b.Keys(), extra)
+	return key.NewBinding(key.WithKeys(keys...), key.WithHelp(keysHelp(keys), desc))
+}
+
+// freeKeys removes taken keys from b, so a rebound action is not shadowed
+// by a bubbles default on the same key
+func freeKeys(b *key.Binding, taken []string) {
+	var keys []string
+	for _, k := range b.Keys() {
+		if !slices.Contains(taken, k) {
+			keys = append(keys, k)
+		}
+	}
+	if len(keys) < len(b.Keys()) {
+		b.SetKeys(keys...)
+		b.SetHelp(keysHelp(keys), b.Help().Desc)
+	}
+}
@@ -0,0 +1,181 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/mamuzad/vidlogd/internal/models"
+	"github.com/mamuzad/vidlogd/internal/storage"
+)
+
+// ToastTimeout is how long a toast stays in the status bar
+const ToastTimeout = 4 * time.Second
+
+// ErrorMsg reports a failure to the user. The app shows it as a toast and
+// keeps it in the error log.
+type ErrorMsg struct {
+	Context string // what was being done, e.g. "save video"
+	Err     error
+}
+
+func (e ErrorMsg) Error() string {
+	return fmt.Sprintf("%s: %v", e.Context, e.Err)
+}
+
+// ReportError returns a command reporting err, or nil when err is nil
+func ReportError(context string, err error) tea.Cmd {
+	if err == nil {
+		return nil
+	}
+	return func() tea.Msg { return ErrorMsg{Context: context, Err: err} }
+}
+
+// NotifyMsg shows a short confirmation, e.g. "saved", as a toast
+type NotifyMsg struct {
+	Text string
+}
+
+// Notify returns a command showing text as a toast
+func Notify(text string) tea.Cmd {
+	return func() tea.Msg { return NotifyMsg{Text: text} }
+}
+
+// ViewStatus is what the current view adds to the status line
+type ViewStatus struct {
+	Filter  string // active saved filter or search, empty for none
+	Shown   int    // entries left by the filter
+	Unsaved bool   // the view holds edits that are not saved
+}
+
+// Status is everything the status line shows besides toasts
+type Status struct {
+	View    ViewType
+	Profile string
+	Total   int // videos in the profile, -1 while unknown
+	ViewStatus
+}
+
+// toastExpiredMsg hides the toast it was scheduled for
+type toastExpiredMsg struct {
+	id int
+}
+
+// Notifier holds the status line's toast and counts the errors reported
+// this session
+type Notifier struct {
+	toast    string
+	toastErr bool
+	toastID  int
+	errors   int
+}
+
+// errorLogFailedMsg reports an error that could not be added to the
+// error log
+type errorLogFailedMsg struct {
+	toastID int // toast showing the error
+	err     error
+}
+
+// Error shows msg as a toast and appends it to the error log
+func (n *Notifier) Error(msg ErrorMsg) tea.Cmd {
+	n.errors++
+	// joined errors span lines, the toast has one
+	toast := n.show(strings.ReplaceAll(msg.Error(), "\n", "; "), true)
+
+	id := n.toastID
+	entry := models.ErrorEntry{
+		Time:    time.Now(),
+		Profile: storage.Profile(),
+		Context: msg.Context,
+		Message: msg.Err.Error(),
+	}
+	return tea.Batch(toast, func() tea.Msg {
+		if err := models.AppendErrorLog(entry); err != nil {
+			return errorLogFailedMsg{toastID: id, err: err}
+		}
+		return nil
+	})
+}
+
+// Notify shows msg as a toast
+func (n *Notifier) Notify(msg NotifyMsg) tea.Cmd {
+	return n.show(msg.Text, false)
+}
+
+// show replaces the toast and schedules it to expire
+func (n *Notifier) show(text string, isErr bool) tea.Cmd {
+	n.toastID++
+	id := n.toastID
+	n.toast, n.toastErr = text, isErr
+	return tea.Tick(ToastTimeout, func(time.Time) tea.Msg { return toastExpiredMsg{id: id} })
+}
+
+// Update hides expired toasts and reports errors the error log could not
+// keep, returning whether msg was for the notifier
+func (n *Notifier) Update(msg tea.Msg) (bool, tea.Cmd) {
+	switch msg := msg.(type) {
+	case toastExpiredMsg:
+		if msg.id == n.toastID {
+			n.toast = ""
+		}
+		return true, nil
+	case errorLogFailedMsg:
+		text := "error log: " + msg.err.Error()
+		if msg.toastID == n.toastID {
+			// the error is still showing, say it was not kept
+			text = n.toast + "; not logged: " + msg.err.Error()
+		}
+		return true, n.show(strings.ReplaceAll(text, "\n", "; "), true)
+	}
+	return false, nil
+}
+
+// View renders the status line: the view and profile on the left, the
+// toast or the session's error count on the right
+func (n Notifier) View(width int, status Status) string {
+	dim := lipgloss.NewStyle().Foreground(Gray)
+	parts := []string{status.View.String(), "profile " + status.Profile}
+	if status.Filter != "" {
+		parts = append(parts, fmt.Sprintf("%s (%d shown)", status.Filter, status.Shown))
+	}
+	if status.Total >= 0 {
+		parts = append(parts, pluralize(status.Total, "video"))
+	}
+	left := dim.Render(strings.Join(parts, " "+Symbols.Separator+" "))
+	if status.Unsaved {
+		left += dim.Render(" "+Symbols.Separator+" ") + lipgloss.NewStyle().Foreground(PrimaryColor).Render(Symbols.Unsaved+" unsaved")
+	}
+
+	var right string
+	switch {
+	case n.toast != "" && n.toastErr:
+		right = lipgloss.NewStyle().Foreground(DangerColor).Render(Symbols.Warning + " " + n.toast)
+	case n.toast != "":
+		right = lipgloss.NewStyle().Foreground(PrimaryColor).Render(n.toast)
+	case n.errors > 0:
+		right = dim.Render(fmt.Sprintf("%s %s %s %s %s", Symbols.Warning, pluralize(n.errors, "error"), Symbols.Separator,
+			GlobalKeyMap.ErrorLog.Help().Key, GlobalKeyMap.ErrorLog.Help().Desc))
+	}
+
+	if right == "" {
+		return lipgloss.NewStyle().MaxWidth(width).Render(left)
+	}
+	// the toast matters more than the view info, which is cut first
+	room := width - lipgloss.Width(right) - 2
+	if room < 10 {
+		return lipgloss.NewStyle().MaxWidth(width).Render(right)
+	}
+	left = lipgloss.NewStyle().MaxWidth(room).Render(left)
+	return left + strings.Repeat(" ", width-lipgloss.Width(left)-lipgloss.Width(right)) + right
+}
+
+// pluralize formats a count with its noun, e.g. "1 video" or "3 videos"
+func pluralize(n int, noun string) string {
+	if n == 1 {
+		return "1 " + noun
+	}
+	return fmt.Sprintf("%d %ss", n, noun)
+}
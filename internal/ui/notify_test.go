@@ -0,0 +1,31 @@
+package ui
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestNotifierErrorLogFailed(t *testing.T) {
+	var n Notifier
+	n.Error(ErrorMsg{Context: "save video", Err: errors.New("disk full")})
+	shown := n.toastID
+
+	handled, cmd := n.Update(errorLogFailedMsg{toastID: shown, err: errors.New("read-only")})
+	if !handled || cmd == nil {
+		t.Fatalf("expected the failure to be handled with a new timer, got %v", handled)
+	}
+	if !strings.Contains(n.toast, "disk full") || !strings.Contains(n.toast, "not logged: read-only") {
+		t.Errorf("expected the toast to keep the error and add the failure, got %q", n.toast)
+	}
+
+	// once the toast moved on the failure gets its own
+	n.Notify(NotifyMsg{Text: "saved"})
+	n.Update(errorLogFailedMsg{toastID: shown, err: errors.New("read-only")})
+	if n.toast != "error log: read-only" || !n.toastErr {
+		t.Errorf("expected a separate error toast, got %q", n.toast)
+	}
+	if n.errors != 1 {
+		t.Errorf("expected the failure not to count as a logged error, got %d", n.errors)
+	}
+}
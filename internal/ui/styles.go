@@ -1,6 +1,9 @@
 package ui
 
 import (
+	"fmt"
+	"strconv"
+
 	"github.com/charmbracelet/lipgloss"
 )
 
@@ -23,23 +26,17 @@ var (
 
 	PrimaryColor      = lipgloss.Color(Red)
 	PrimaryBackground = lipgloss.Color(RedBg)
-	White             = lipgloss.Color("#FFFFFF")
+	White             = lipgloss.Color("#FFFFFF") // text on primary backgrounds
+	TextColor         = lipgloss.Color("#FFFFFF")
 	Gray              = lipgloss.Color("#909090")
 	DangerColor       = lipgloss.Color(Red)
+	ChartColor        = lipgloss.Color(Red)
 )
 
-// set theme colors and update all styles
-func SetThemeColors(primary, primaryBg string) {
-	PrimaryColor = lipgloss.Color(primary)
-	PrimaryBackground = lipgloss.Color(primaryBg)
-
-	initStyles()
-}
-
 func initStyles() {
 	// main popup container style
 	PopupStyle = lipgloss.NewStyle().
-		Border(lipgloss.RoundedBorder()).
+		Border(Border()).
 		BorderForeground(PrimaryColor).
 		Padding(1, 2)
 
@@ -47,7 +44,7 @@ func initStyles() {
 	TitleStyle = lipgloss.NewStyle().
 		Foreground(PrimaryColor).
 		Bold(true).
-		Border(lipgloss.RoundedBorder()).
+		Border(Border()).
 		Padding(0, 2).
 		Margin(0, 0, 1, 0).
 		AlignHorizontal(lipgloss.Center)
@@ -58,16 +55,16 @@ func initStyles() {
 
 	// normal form field style
 	FormFieldStyle = lipgloss.NewStyle().
-		Border(lipgloss.RoundedBorder()).
+		Border(Border()).
 		BorderForeground(Gray)
 
 	// focused form field
 	FormFieldFocusedStyle = lipgloss.NewStyle().
-		Border(lipgloss.RoundedBorder()).
+		Border(Border()).
 		BorderForeground(PrimaryBackground)
 
 	HeaderStyle = lipgloss.NewStyle().
-		Foreground(White).
+		Foreground(TextColor).
 		Bold(true).
 		BorderBottom(true).
 		Width(15).
@@ -83,17 +80,17 @@ func initStyles() {
 
 	// form button style
 	ButtonStyle = lipgloss.NewStyle().
-		Border(lipgloss.RoundedBorder()).
+		Border(Border()).
 		Padding(0, 1)
 
 	ButtonStyleFocused = lipgloss.NewStyle().
-		Border(lipgloss.RoundedBorder()).
+		Border(Border()).
 		BorderForeground(PrimaryColor).
 		Background(PrimaryBackground).
 		Padding(0, 1)
 
 	TableHeaderStyle = lipgloss.NewStyle().
-		BorderStyle(lipgloss.RoundedBorder()).
+		BorderStyle(Border()).
 		BorderBottom(true).
 		Background(PrimaryColor).
 		Foreground(White).
@@ -102,8 +99,8 @@ func initStyles() {
 
 	// table styles
 	TableStyle = lipgloss.NewStyle().
-		Border(lipgloss.RoundedBorder()).
-		BorderForeground(White).
+		Border(Border()).
+		BorderForeground(TextColor).
 		Padding(1, 2)
 
 	TableSelectedRowStyle = lipgloss.NewStyle().
@@ -111,9 +108,26 @@ func initStyles() {
 		Foreground(White).
 		Bold(true)
 
+	// search matches inside table cells
+	TableMatchStyle = lipgloss.NewStyle().
+		Foreground(PrimaryColor).
+		Bold(true).
+		Underline(true)
+
+	// rows marked for a bulk action
+	TableMarkedStyle = lipgloss.NewStyle().
+		Foreground(PrimaryColor).
+		Bold(true)
+
+	// calendar heatmap, from no activity to the busiest days
+	HeatmapLevels = []lipgloss.Style{lipgloss.NewStyle().Foreground(Gray)}
+	for _, t := range []float64{0.3, 0.55, 0.8, 1} {
+		HeatmapLevels = append(HeatmapLevels, lipgloss.NewStyle().Foreground(blend(heatmapBase, string(PrimaryColor), t)))
+	}
+
 	// log details styles
 	ReviewStyle = lipgloss.NewStyle().
-		Border(lipgloss.RoundedBorder()).
+		Border(Border()).
 		Padding(1).
 		Width(70)
 
@@ -124,7 +138,7 @@ func initStyles() {
 
 	// search box style
 	SearchStyle = lipgloss.NewStyle().
-		BorderStyle(lipgloss.NormalBorder()).
+		BorderStyle(Border()).
 		BorderBottom(true).
 		Padding(0, 1).
 		Margin(0, 1).
@@ -132,12 +146,15 @@ func initStyles() {
 
 	// modal styles
 	ModalStyle = lipgloss.NewStyle().
-		Border(lipgloss.RoundedBorder()).
+		Border(Border()).
 		BorderForeground(DangerColor)
 
 	DangerStyle = lipgloss.NewStyle().
 		Foreground(DangerColor).
 		Bold(true)
+
+	applyStyleSpecs()
+	applyAccessibility()
 }
 
 var (
@@ -165,6 +182,11 @@ var (
 	TableStyle            lipgloss.Style
 	TableHeaderStyle      lipgloss.Style
 	TableSelectedRowStyle lipgloss.Style
+	TableMatchStyle       lipgloss.Style
+	TableMarkedStyle      lipgloss.Style
+
+	// heatmap intensity buckets, 0 for days without videos
+	HeatmapLevels []lipgloss.Style
 
 	// log details styles
 	ReviewStyle lipgloss.Style
@@ -180,21 +202,66 @@ var (
 	DangerStyle lipgloss.Style
 )
 
-// centerHorizontally centers content horizontally in the terminal
+// centerHorizontally centers content horizontally in the terminal, unless
+// the layout is linear
 func CenterHorizontally(content string, width int) string {
+	if Linear {
+		return content
+	}
 	return lipgloss.Place(width, lipgloss.Height(content), lipgloss.Center, lipgloss.Top, content)
 }
 
-// centerVertically centers content vertically in the terminal
+// centerVertically centers content vertically in the terminal, unless the
+// layout is linear
 func CenterVertically(content string, height int) string {
+	if Linear {
+		return content
+	}
 	return lipgloss.Place(lipgloss.Width(content), height, lipgloss.Left, lipgloss.Center, content)
 }
 
-// centerBoth centers content both horizontally and vertically
+// centerBoth centers content both horizontally and vertically, unless the
+// layout is linear
 func CenterBoth(content string, width, height int) string {
+	if Linear {
+		return content
+	}
 	return lipgloss.Place(width, height, lipgloss.Center, lipgloss.Center, content)
 }
 
 func init() {
 	initStyles()
 }
+
+// darkest heatmap shade, blended toward the theme color
+var heatmapBase = "#303030"
+
+// blend mixes two #rrggbb colors, t=0 is from and t=1 is to
+func blend(from, to string, t float64) lipgloss.Color {
+	a, okA := parseHex(from)
+	b, okB := parseHex(to)
+	if !okA || !okB {
+		return lipgloss.Color(to)
+	}
+
+	var mixed [3]int
+	for i := range mixed {
+		mixed[i] = int(float64(a[i]) + (float64(b[i])-float64(a[i]))*t + 0.5)
+	}
+	return lipgloss.Color(fmt.Sprintf("#%02X%02X%02X", mixed[0], mixed[1], mixed[2]))
+}
+
+func parseHex(color string) ([3]int, bool) {
+	var rgb [3]int
+	if len(color) != 7 || color[0] != '#' {
+		return rgb, false
+	}
+	for i := range rgb {
+		v, err := strconv.ParseUint(color[1+2*i:3+2*i], 16, 8)
+		if err != nil {
+			return rgb, false
+		}
+		rgb[i] = int(v)
+	}
+	return rgb, true
+}
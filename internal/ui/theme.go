@@ -0,0 +1,332 @@
+package ui
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/mamuzad/vidlogd/internal/models"
+)
+
+// Palette is the set of colors the styles are built from
+type Palette struct {
+	Primary     string
+	PrimaryBg   string
+	OnPrimary   string // text on primary backgrounds
+	Text        string
+	Muted       string
+	Danger      string
+	Chart       string
+	HeatmapBase string // shade of days without videos
+}
+
+// Theme is a palette for dark and light terminals, with optional changes
+// to single styles
+type Theme struct {
+	Name   string
+	Dark   Palette
+	Light  Palette
+	Styles map[string]models.StyleSpec
+}
+
+// Palette returns the variant for a dark or light background
+func (t Theme) Palette(dark bool) Palette {
+	if dark {
+		return t.Dark
+	}
+	return t.Light
+}
+
+// the colors the built-in themes share
+var (
+	darkBase  = Palette{OnPrimary: "#FFFFFF", Text: "#FFFFFF", Muted: "#909090", Danger: Red, HeatmapBase: "#303030"}
+	lightBase = Palette{OnPrimary: "#FFFFFF", Text: "#1F1F1F", Muted: "#6B6B6B", Danger: Red, HeatmapBase: "#E0E0E0"}
+)
+
+func accentTheme(name, primary, primaryBg string) Theme {
+	dark, light := darkBase, lightBase
+	dark.Primary, dark.PrimaryBg, dark.Chart = primary, primaryBg, primary
+	light.Primary, light.PrimaryBg, light.Chart = primary, primaryBg, primary
+	return Theme{Name: name, Dark: dark, Light: light}
+}
+
+// BuiltinThemes ship with vidlogd, the first one is the default
+var BuiltinThemes = []Theme{
+	accentTheme("red", Red, RedBg),
+	accentTheme("blue", Blue, BlueBg),
+	accentTheme("green", Green, GreenBg),
+	accentTheme("purple", Purple, PurpleBg),
+	accentTheme("orange", Orange, OrangeBg),
+	accentTheme("teal", Teal, TealBg),
+	accentTheme("pink", Pink, PinkBg),
+	highContrastTheme(),
+}
+
+// highContrastTheme keeps to black, white and one strong accent, without
+// faint text
+func highContrastTheme() Theme {
+	off := false
+	return Theme{
+		Name: "high-contrast",
+		Dark: Palette{Primary: "#FFFF00", PrimaryBg: "#FFFF00", OnPrimary: "#000000", Text: "#FFFFFF",
+			Muted: "#E0E0E0", Danger: "#FF6060", Chart: "#FFFF00", HeatmapBase: "#404040"},
+		Light: Palette{Primary: "#0000AA", PrimaryBg: "#0000AA", OnPrimary: "#FFFFFF", Text: "#000000",
+			Muted: "#202020", Danger: "#AA0000", Chart: "#0000AA", HeatmapBase: "#C0C0C0"},
+		Styles: map[string]models.StyleSpec{
+			"description": {Faint: &off},
+		},
+	}
+}
+
+// the active theme's palette and style changes, used by initStyles
+var (
+	activePalette = BuiltinThemes[0].Dark
+	activeStyles  map[string]models.StyleSpec
+)
+
+// SetTheme switches to t's variant for the background and rebuilds every
+// style
+func SetTheme(t Theme, dark bool) {
+	p := t.Palette(dark)
+	activePalette = p
+	activeStyles = t.Styles
+
+	PrimaryColor = lipgloss.Color(p.Primary)
+	PrimaryBackground = lipgloss.Color(p.PrimaryBg)
+	White = lipgloss.Color(p.OnPrimary)
+	TextColor = lipgloss.Color(p.Text)
+	Gray = lipgloss.Color(p.Muted)
+	DangerColor = lipgloss.Color(p.Danger)
+	ChartColor = lipgloss.Color(p.Chart)
+	heatmapBase = p.HeatmapBase
+
+	initStyles()
+}
+
+// DarkBackground resolves the background setting, asking the terminal when
+// it is "auto" or empty
+func DarkBackground(mode string) bool {
+	switch mode {
+	case "dark":
+		return true
+	case "light":
+		return false
+	}
+	return lipgloss.HasDarkBackground()
+}
+
+// FindTheme returns the built-in or user theme called name. When it cannot
+// be found or read the default theme is returned with the error.
+func FindTheme(name string) (Theme, error) {
+	if name == "" {
+		return BuiltinThemes[0], nil
+	}
+	for _, t := range BuiltinThemes {
+		if t.Name == name {
+			return t, nil
+		}
+	}
+
+	files, loadErr := loadThemes()
+	for _, f := range files {
+		if f.Name == name {
+			t, err := ThemeFromFile(f)
+			if err != nil {
+				return BuiltinThemes[0], fmt.Errorf("theme %s: %w", name, err)
+			}
+			return t, nil
+		}
+	}
+	return BuiltinThemes[0], errors.Join(fmt.Errorf("theme %q not found", name), loadErr)
+}
+
+// ThemeNames lists the built-in themes followed by the user's theme files
+func ThemeNames() ([]string, error) {
+	names := builtinThemeNames()
+	files, err := loadThemes()
+	for _, f := range files {
+		names = append(names, f.Name)
+	}
+	return names, err
+}
+
+func builtinThemeNames() []string {
+	names := make([]string, 0, len(BuiltinThemes))
+	for _, t := range BuiltinThemes {
+		names = append(names, t.Name)
+	}
+	return names
+}
+
+// loadThemes reads the user's theme files, skipping those that would hide
+// a built-in theme
+func loadThemes() ([]models.ThemeFile, error) {
+	return models.LoadThemes(builtinThemeNames()...)
+}
+
+// ThemeFromFile builds a theme from a theme file. Colors it leaves out
+// come from the default theme, and a file with only one variant uses it
+// for both backgrounds.
+func ThemeFromFile(f models.ThemeFile) (Theme, error) {
+	darkSpec, lightSpec := f.Dark, f.Light
+	if darkSpec == nil {
+		darkSpec = lightSpec
+	}
+	if lightSpec == nil {
+		lightSpec = darkSpec
+	}
+
+	t := Theme{Name: f.Name, Styles: f.Styles}
+	var errs []error
+	var err error
+	if t.Dark, err = mergePalette(BuiltinThemes[0].Dark, darkSpec); err != nil {
+		errs = append(errs, fmt.Errorf("dark: %w", err))
+	}
+	if t.Light, err = mergePalette(BuiltinThemes[0].Light, lightSpec); err != nil {
+		errs = append(errs, fmt.Errorf("light: %w", err))
+	}
+
+	for name, spec := range f.Styles {
+		if _, ok := styleTargets()[name]; !ok {
+			errs = append(errs, fmt.Errorf("unknown style %q", name))
+			continue
+		}
+		for _, c := range []string{spec.Foreground, spec.Background, spec.Border} {
+			if _, err := t.Dark.color(c); err != nil {
+				errs = append(errs, fmt.Errorf("style %s: %w", name, err))
+			}
+		}
+	}
+	return t, errors.Join(errs...)
+}
+
+// mergePalette fills base with the colors set in spec
+func mergePalette(base Palette, spec *models.ThemePalette) (Palette, error) {
+	if spec == nil {
+		return base, nil
+	}
+	p := base
+	// the chart follows a new primary color unless it is set too
+	if spec.Primary != "" && spec.Chart == "" {
+		p.Chart = spec.Primary
+	}
+
+	var errs []error
+	for _, field := range []struct {
+		value string
+		dst   *string
+	}{
+		{spec.Primary, &p.Primary},
+		{spec.PrimaryBg, &p.PrimaryBg},
+		{spec.OnPrimary, &p.OnPrimary},
+		{spec.Text, &p.Text},
+		{spec.Muted, &p.Muted},
+		{spec.Danger, &p.Danger},
+		{spec.Chart, &p.Chart},
+		{spec.HeatmapBase, &p.HeatmapBase},
+	} {
+		if field.value == "" {
+			continue
+		}
+		if !validColor(field.value) {
+			errs = append(errs, fmt.Errorf("invalid color %q", field.value))
+			continue
+		}
+		*field.dst = field.value
+	}
+	return p, errors.Join(errs...)
+}
+
+var hexColorRegex = regexp.MustCompile(`^#([0-9A-Fa-f]{3}|[0-9A-Fa-f]{6})$`)
+
+// validColor accepts hex colors and ANSI color numbers
+func validColor(s string) bool {
+	if hexColorRegex.MatchString(s) {
+		return true
+	}
+	n, err := strconv.Atoi(s)
+	return err == nil && n >= 0 && n <= 255
+}
+
+// color resolves a style color: a palette name or a literal color. Empty
+// means unset.
+func (p Palette) color(value string) (lipgloss.Color, error) {
+	named := map[string]string{
+		"primary":    p.Primary,
+		"primary_bg": p.PrimaryBg,
+		"on_primary": p.OnPrimary,
+		"text":       p.Text,
+		"muted":      p.Muted,
+		"danger":     p.Danger,
+		"chart":      p.Chart,
+	}
+	if c, ok := named[strings.ToLower(value)]; ok {
+		return lipgloss.Color(c), nil
+	}
+	if value != "" && !validColor(value) {
+		return "", fmt.Errorf("invalid color %q", value)
+	}
+	return lipgloss.Color(value), nil
+}
+
+// styleTargets names the styles a theme file can change
+func styleTargets() map[string]*lipgloss.Style {
+	return map[string]*lipgloss.Style{
+		"popup":              &PopupStyle,
+		"title":              &TitleStyle,
+		"menu_item":          &MenuItemStyle,
+		"header":             &HeaderStyle,
+		"form_field":         &FormFieldStyle,
+		"form_field_focused": &FormFieldFocusedStyle,
+		"star":               &StarStyle,
+		"mode":               &ModeStyle,
+		"button":             &ButtonStyle,
+		"button_focused":     &ButtonStyleFocused,
+		"table":              &TableStyle,
+		"table_header":       &TableHeaderStyle,
+		"table_selected_row": &TableSelectedRowStyle,
+		"table_match":        &TableMatchStyle,
+		"table_marked":       &TableMarkedStyle,
+		"review":             &ReviewStyle,
+		"description":        &DescriptionStyle,
+		"search":             &SearchStyle,
+		"modal":              &ModalStyle,
+		"danger":             &DangerStyle,
+	}
+}
+
+// applyStyleSpecs applies the active theme's style changes on top of the
+// styles built from its palette. Specs were checked by ThemeFromFile.
+func applyStyleSpecs() {
+	targets := styleTargets()
+	for name, spec := range activeStyles {
+		style, ok := targets[name]
+		if !ok {
+			continue
+		}
+		if c, err := activePalette.color(spec.Foreground); err == nil && c != "" {
+			*style = style.Foreground(c)
+		}
+		if c, err := activePalette.color(spec.Background); err == nil && c != "" {
+			*style = style.Background(c)
+		}
+		if c, err := activePalette.color(spec.Border); err == nil && c != "" {
+			*style = style.BorderForeground(c)
+		}
+		if spec.Bold != nil {
+			*style = style.Bold(*spec.Bold)
+		}
+		if spec.Faint != nil {
+			*style = style.Faint(*spec.Faint)
+		}
+		if spec.Italic != nil {
+			*style = style.Italic(*spec.Italic)
+		}
+		if spec.Underline != nil {
+			*style = style.Underline(*spec.Underline)
+		}
+	}
+}
@@ -0,0 +1,102 @@
+package ui
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/mamuzad/vidlogd/internal/models"
+	"github.com/mamuzad/vidlogd/internal/storage"
+)
+
+func TestThemeFromFile(t *testing.T) {
+	bold := true
+	theme, err := ThemeFromFile(models.ThemeFile{
+		Name:  "paper",
+		Light: &models.ThemePalette{Primary: "#005F87", Text: "#202020"},
+		Styles: map[string]models.StyleSpec{
+			"popup":  {Border: "muted"},
+			"header": {Foreground: "primary", Bold: &bold},
+		},
+	})
+	if err != nil {
+		t.Fatalf("ThemeFromFile: %v", err)
+	}
+
+	// one variant serves both backgrounds
+	if theme.Dark.Primary != "#005F87" || theme.Light.Primary != "#005F87" {
+		t.Errorf("expected the light palette for both, got %+v", theme)
+	}
+	if theme.Light.Chart != "#005F87" {
+		t.Errorf("expected the chart to follow the primary color, got %q", theme.Light.Chart)
+	}
+	if theme.Light.Muted != BuiltinThemes[0].Light.Muted {
+		t.Errorf("expected unset colors from the default theme, got %q", theme.Light.Muted)
+	}
+
+	defer SetTheme(BuiltinThemes[0], true)
+	SetTheme(theme, false)
+	if TextColor != lipgloss.Color("#202020") {
+		t.Errorf("expected the text color to be applied, got %v", TextColor)
+	}
+	if PopupStyle.GetBorderTopForeground() != lipgloss.Color(theme.Light.Muted) {
+		t.Errorf("expected the popup border override, got %v", PopupStyle.GetBorderTopForeground())
+	}
+	if HeaderStyle.GetForeground() != lipgloss.Color("#005F87") || !HeaderStyle.GetBold() {
+		t.Error("expected the header override")
+	}
+}
+
+func TestThemeFromFileErrors(t *testing.T) {
+	_, err := ThemeFromFile(models.ThemeFile{
+		Name:   "broken",
+		Dark:   &models.ThemePalette{Primary: "reddish"},
+		Styles: map[string]models.StyleSpec{"sidebar": {}, "popup": {Border: "#12345"}},
+	})
+	if err == nil {
+		t.Fatal("expected errors")
+	}
+	for _, want := range []string{`"reddish"`, `"sidebar"`, `"#12345"`} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("expected %s in %v", want, err)
+		}
+	}
+}
+
+func TestFindTheme(t *testing.T) {
+	t.Setenv("XDG_DATA_HOME", t.TempDir())
+
+	theme, err := FindTheme("teal")
+	if err != nil || theme.Dark.Primary != Teal {
+		t.Errorf("expected the built-in teal theme, got %+v, %v", theme, err)
+	}
+	theme, err = FindTheme("missing")
+	if err == nil || theme.Name != BuiltinThemes[0].Name {
+		t.Errorf("expected the default theme and an error, got %q, %v", theme.Name, err)
+	}
+}
+
+func TestThemeNames(t *testing.T) {
+	t.Setenv("XDG_DATA_HOME", t.TempDir())
+	dir, err := storage.ThemesDir()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	// a user theme cannot hide a built-in one
+	if err := os.WriteFile(filepath.Join(dir, "teal.json"), []byte(`{"dark": {"primary": "#88C0D0"}}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	names, err := ThemeNames()
+	if err == nil || !strings.Contains(err.Error(), "teal.json") {
+		t.Errorf("expected the clashing file to be reported, got %v", err)
+	}
+	if len(names) != len(BuiltinThemes) {
+		t.Errorf("expected only the built-in themes, got %v", names)
+	}
+}
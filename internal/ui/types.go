@@ -1,5 +1,7 @@
 package ui
 
+import tea "github.com/charmbracelet/bubbletea"
+
 type ViewType int
 
 const (
@@ -9,6 +11,11 @@ const (
 	LogDetailsView
 	SettingsView
 	StatsView
+	TransferView
+	ChannelView
+	DedupeView
+	DoctorView
+	ErrorLogView
 )
 
 type Route struct {
@@ -23,6 +30,10 @@ type (
 	SettingsRouteState struct {
 		ListIndex int
 	}
+	ChannelRouteState struct {
+		Channel   string
+		ChannelID string // resolves renamed channels when known
+	}
 )
 
 type (
@@ -33,4 +44,35 @@ type (
 	ClearFormMsg struct{}
 	BackMsg      struct{}
 	UIRefreshMsg struct{}
+	// sent after storage switched to another profile
+	ProfileSwitchedMsg struct {
+		Profile string
+	}
+	// sent after videos were added, changed or removed
+	LibraryChangedMsg struct{}
 )
+
+// LibraryChanged tells the app that videos were added, changed or removed
+func LibraryChanged() tea.Msg { return LibraryChangedMsg{} }
+
+// viewNames label the views in the status line
+var viewNames = map[ViewType]string{
+	MainMenuView:   "menu",
+	LogVideoView:   "log video",
+	LogListView:    "logs",
+	LogDetailsView: "log details",
+	SettingsView:   "settings",
+	StatsView:      "stats",
+	TransferView:   "import / export",
+	ChannelView:    "channel",
+	DedupeView:     "duplicates",
+	DoctorView:     "check library",
+	ErrorLogView:   "error log",
+}
+
+func (v ViewType) String() string {
+	if name, ok := viewNames[v]; ok {
+		return name
+	}
+	return "unknown"
+}
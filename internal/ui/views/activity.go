@@ -0,0 +1,101 @@
+package views
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/mamuzad/vidlogd/internal/analytics"
+	"github.com/mamuzad/vidlogd/internal/models"
+	"github.com/mamuzad/vidlogd/internal/ui"
+)
+
+// bucketsShown is how many buckets the activity chart fits, at least nine
+func (m StatsModel) bucketsShown() int {
+	return max(9, m.contentWidth()/6)
+}
+
+// activityBuckets returns the buckets in the activity chart's window, which
+// ends at periodEnd or now
+func (m StatsModel) activityBuckets() []analytics.Bucket {
+	last := m.periodEnd
+	if last.IsZero() {
+		last = m.clock()
+	}
+	return analytics.Buckets(m.currentVideos(), m.granularity, last, m.bucketsShown())
+}
+
+// scrollActivity moves the activity window by whole windows, stopping at
+// the present and at the oldest video
+func (m *StatsModel) scrollActivity(windows int) {
+	g := m.granularity
+	buckets := m.activityBuckets()
+	now := g.Start(m.clock())
+
+	if windows < 0 {
+		oldest := time.Time{}
+		for _, video := range m.currentVideos() {
+			if !video.LogDate.IsZero() && (oldest.IsZero() || video.LogDate.Before(oldest)) {
+				oldest = video.LogDate
+			}
+		}
+		if oldest.IsZero() || !buckets[0].Start.After(oldest) {
+			return
+		}
+	}
+
+	last := g.Add(buckets[len(buckets)-1].Start, windows*len(buckets))
+	if !last.Before(now) {
+		m.periodEnd = time.Time{}
+		return
+	}
+	m.periodEnd = last
+}
+
+// cycleGranularity switches to the next bucket size; a window scrolled into
+// the past then ends at the new bucket holding the start of its last one
+func (m *StatsModel) cycleGranularity() {
+	next := (int(m.granularity) + 1) % len(analytics.Granularities)
+	m.granularity = analytics.Granularities[next]
+}
+
+// visibleVideos are the videos the dashboard cards summarize: the activity
+// chart's window while it is shown, otherwise everything matching the filters
+func (m StatsModel) visibleVideos() []models.Video {
+	if m.viewMode != 1 {
+		return m.currentVideos()
+	}
+	buckets := m.activityBuckets()
+	return analytics.Between(m.currentVideos(), buckets[0].Start, buckets[len(buckets)-1].End)
+}
+
+// visibleClock is "now" for the cards, the end of a window in the past
+func (m StatsModel) visibleClock() analytics.Clock {
+	if m.viewMode != 1 || m.periodEnd.IsZero() {
+		return m.clock
+	}
+	buckets := m.activityBuckets()
+	end := buckets[len(buckets)-1].End.Add(-time.Nanosecond)
+	return func() time.Time { return end }
+}
+
+func (m StatsModel) prepareActivityChartData(buckets []analytics.Bucket) ChartData {
+	labels := make([]string, len(buckets))
+	values := make([]float64, len(buckets))
+	total := 0
+
+	for i, b := range buckets {
+		labels[i] = m.granularity.Label(b.Start)
+		values[i] = float64(b.Count)
+		total += b.Count
+	}
+
+	from := buckets[0].Start
+	to := buckets[len(buckets)-1].End.AddDate(0, 0, -1)
+
+	return ChartData{
+		Title:  ui.Icon(ui.Symbols.CalendarIcon, "    ") + "Activity by " + m.granularity.String(),
+		Labels: labels,
+		Values: values,
+		Footer: fmt.Sprintf("%d videos from %s to %s", total, from.Format(models.ISODateFormat), to.Format(models.ISODateFormat)),
+	}
+}
@@ -0,0 +1,258 @@
+package views
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/mamuzad/vidlogd/internal/models"
+	"github.com/mamuzad/vidlogd/internal/ui"
+)
+
+type bulkAction int
+
+const (
+	bulkDelete bulkAction = iota
+	bulkRating
+	bulkRewatched
+	bulkAddTag
+	bulkRemoveTag
+	bulkCollection
+	bulkExport
+)
+
+var bulkActions = []struct {
+	action bulkAction
+	title  string
+}{
+	{bulkDelete, "delete"},
+	{bulkRating, "set rating"},
+	{bulkRewatched, "toggle rewatched"},
+	{bulkAddTag, "add tag"},
+	{bulkRemoveTag, "remove tag"},
+	{bulkCollection, "move to collection"},
+	{bulkExport, "export csv"},
+}
+
+// bulkDoneMsg reports a finished bulk action
+type bulkDoneMsg struct {
+	status string
+	err    error
+}
+
+type bulkFormClosedMsg struct{}
+
+// bulkUpdate applies update to the videos in ids; status gets the count
+func bulkUpdate(ids []string, status string, update func(*models.Video)) tea.Cmd {
+	return func() tea.Msg {
+		n, err := models.UpdateVideos(ids, update)
+		if err != nil {
+			return bulkDoneMsg{err: err}
+		}
+		return bulkDoneMsg{status: fmt.Sprintf(status, n)}
+	}
+}
+
+func bulkDeleteVideos(ids []string) tea.Cmd {
+	return func() tea.Msg {
+		n, err := models.DeleteVideos(ids)
+		if err != nil {
+			return bulkDoneMsg{err: err}
+		}
+		return bulkDoneMsg{status: fmt.Sprintf("deleted %d videos", n)}
+	}
+}
+
+// markedRows returns the table rows that are marked or inside the visual range
+func (m LogListModel) markedRows() map[int]bool {
+	rows := make(map[int]bool)
+	lo, hi := m.visualRange()
+	for i, video := range m.visibleVideos() {
+		if m.marked[video.ID] || i >= lo && i <= hi {
+			rows[i] = true
+		}
+	}
+	return rows
+}
+
+// visualRange returns the rows between the visual start and the cursor,
+// an empty range when visual mode is off
+func (m LogListModel) visualRange() (int, int) {
+	if m.visualStart < 0 {
+		return 0, -1
+	}
+	return min(m.visualStart, m.table.Cursor()), max(m.visualStart, m.table.Cursor())
+}
+
+func (m LogListModel) hasSelection() bool {
+	return len(m.marked) > 0 || m.visualStart >= 0
+}
+
+// selectedVideos returns the marked videos in table order, or the video
+// under the cursor when nothing is marked
+func (m LogListModel) selectedVideos() []models.Video {
+	rows := m.markedRows()
+	var selected []models.Video
+	for i, video := range m.visibleVideos() {
+		if rows[i] {
+			selected = append(selected, video)
+		}
+	}
+	if len(selected) == 0 {
+		if video, ok := m.selectedVideo(); ok {
+			selected = append(selected, video)
+		}
+	}
+	return selected
+}
+
+func (m *LogListModel) clearSelection() {
+	m.marked = make(map[string]bool)
+	m.visualStart = -1
+}
+
+// toggleVisual starts a visual range at the cursor, or marks the range
+// and leaves visual mode
+func (m *LogListModel) toggleVisual() {
+	if m.visualStart < 0 {
+		m.visualStart = m.table.Cursor()
+		return
+	}
+	lo, hi := m.visualRange()
+	videos := m.visibleVideos()
+	for i := lo; i <= hi && i < len(videos); i++ {
+		m.marked[videos[i].ID] = true
+	}
+	m.visualStart = -1
+}
+
+// toggleMark marks or unmarks the cursor row and moves down
+func (m *LogListModel) toggleMark() {
+	video, ok := m.selectedVideo()
+	if !ok {
+		return
+	}
+	if m.marked[video.ID] {
+		delete(m.marked, video.ID)
+	} else {
+		m.marked[video.ID] = true
+	}
+	m.table.MoveDown(1)
+	m.offset = clampOffset(m.offset, m.table.Cursor(), m.table.Height(), len(m.table.Rows()))
+}
+
+// updateBulkMenu moves through the bulk actions and runs the chosen one
+func (m LogListModel) updateBulkMenu(msg tea.KeyMsg) (LogListModel, tea.Cmd) {
+	switch {
+	case key.Matches(msg, ui.GlobalKeyMap.Up):
+		m.bulkCursor = max(0, m.bulkCursor-1)
+	case key.Matches(msg, ui.GlobalKeyMap.Down):
+		m.bulkCursor = min(len(bulkActions)-1, m.bulkCursor+1)
+	case key.Matches(msg, ui.GlobalKeyMap.Select):
+		m.bulkMenu = false
+		return m.runBulkAction(bulkActions[m.bulkCursor].action)
+	case key.Matches(msg, ui.GlobalKeyMap.BulkActions, ui.GlobalKeyMap.Back, ui.GlobalKeyMap.Cancel, ui.GlobalKeyMap.SearchBack):
+		m.bulkMenu = false
+	}
+	return m, nil
+}
+
+func (m LogListModel) runBulkAction(action bulkAction) (LogListModel, tea.Cmd) {
+	videos := m.selectedVideos()
+	if len(videos) == 0 {
+		return m, nil
+	}
+	ids := make([]string, len(videos))
+	allRewatched := true
+	for i, video := range videos {
+		ids[i] = video.ID
+		allRewatched = allRewatched && video.Rewatched
+	}
+
+	closed := func() tea.Cmd {
+		return func() tea.Msg { return bulkFormClosedMsg{} }
+	}
+	count := fmt.Sprintf("%d videos", len(videos))
+
+	var form FormModel
+	switch action {
+	case bulkDelete:
+		m.deleteModal.ShowMany(videos)
+		return m, nil
+	case bulkRewatched:
+		// mark all as rewatched, unless they already are
+		rewatched := !allRewatched
+		return m, bulkUpdate(ids, "updated %d videos", func(v *models.Video) { v.Rewatched = rewatched })
+	case bulkRating:
+		form = NewForm("set rating for "+count, []FormField{
+			{Label: "Rating:", Required: false, CharLimit: 1, Width: 20, Type: FormFieldRating},
+		}, "apply")
+		form.SetHandlers(func(f FormModel) tea.Cmd {
+			rating := f.Rating()
+			return bulkUpdate(ids, "rated %d videos", func(v *models.Video) { v.Rating = rating })
+		}, closed)
+	case bulkAddTag, bulkRemoveTag:
+		title := "add tags to " + count
+		if action == bulkRemoveTag {
+			title = "remove tags from " + count
+		}
+		form = NewForm(title, []FormField{
+			{Placeholder: "tutorial, go", Label: "Tags:", Required: true, CharLimit: 100, Width: 60, Type: FormFieldText},
+		}, "apply")
+		form.SetHandlers(func(f FormModel) tea.Cmd {
+			tags := models.ParseTags(f.Value(0))
+			if action == bulkAddTag {
+				return bulkUpdate(ids, "tagged %d videos", func(v *models.Video) {
+					v.Tags = models.NormalizeTags(append(v.Tags, tags...))
+				})
+			}
+			return bulkUpdate(ids, "untagged %d videos", func(v *models.Video) { v.Tags = removeTags(v.Tags, tags) })
+		}, closed)
+	case bulkCollection:
+		form = NewForm("move "+count+" to a collection", []FormField{
+			{Placeholder: "blank to remove from their collection", Label: "Collection:", Required: false, CharLimit: 60, Width: 60, Type: FormFieldText},
+		}, "move")
+		form.SetHandlers(func(f FormModel) tea.Cmd {
+			collection := strings.TrimSpace(f.Value(0))
+			return bulkUpdate(ids, "moved %d videos", func(v *models.Video) { v.Collection = collection })
+		}, closed)
+	case bulkExport:
+		form = NewForm("export "+count, transferFields(defaultExportPath()), "export")
+		form.SetHandlers(func(f FormModel) tea.Cmd {
+			path, dateFormat := f.Value(0), f.Value(1)
+			return func() tea.Msg {
+				status, err := writeCSV(path, dateFormat, videos)
+				return bulkDoneMsg{status: status, err: err}
+			}
+		}, closed)
+	}
+
+	m.bulkForm = &form
+	m.table.Blur()
+	return m, nil
+}
+
+func removeTags(tags, remove []string) []string {
+	drop := make(map[string]bool, len(remove))
+	for _, tag := range remove {
+		drop[tag] = true
+	}
+	var kept []string
+	for _, tag := range tags {
+		if !drop[strings.ToLower(tag)] {
+			kept = append(kept, tag)
+		}
+	}
+	return kept
+}
+
+func (m LogListModel) bulkMenuView() string {
+	var s strings.Builder
+	s.WriteString(fmt.Sprintf(" %d selected (%s to apply, %s to close)\n\n", len(m.selectedVideos()),
+		ui.GlobalKeyMap.Select.Help().Key, ui.GlobalKeyMap.SearchBack.Help().Key))
+	for i, a := range bulkActions {
+		s.WriteString(ui.RenderRow(fmt.Sprintf(" %s ", a.title), i == m.bulkCursor) + "\n")
+	}
+	return ui.TableStyle.Render(s.String())
+}
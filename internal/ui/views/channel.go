@@ -0,0 +1,355 @@
+package views
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/atotto/clipboard"
+	"github.com/charmbracelet/bubbles/help"
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/mamuzad/vidlogd/internal/analytics"
+	"github.com/mamuzad/vidlogd/internal/models"
+	"github.com/mamuzad/vidlogd/internal/ui"
+	"github.com/mamuzad/vidlogd/internal/ui/chart"
+)
+
+// channel page layout
+const (
+	channelWidth      = 60
+	channelListHeight = 8
+)
+
+func init() {
+	ui.RegisterKeyScope("channel", "Up", "Down", "Select", "Edit", "Favorite", "CopyLink", "Aliases", "Back")
+}
+
+type ChannelKeyMap struct{}
+
+func (k ChannelKeyMap) ShortHelp() []key.Binding {
+	return []key.Binding{
+		ui.GlobalKeyMap.Select,
+		ui.GlobalKeyMap.Favorite,
+		ui.GlobalKeyMap.Edit,
+		ui.GlobalKeyMap.Aliases,
+		ui.GlobalKeyMap.CopyLink,
+		ui.GlobalKeyMap.Back,
+		ui.GlobalKeyMap.Help,
+	}
+}
+
+func (k ChannelKeyMap) FullHelp() [][]key.Binding {
+	return [][]key.Binding{
+		{
+			ui.GlobalKeyMap.Up,
+			ui.GlobalKeyMap.Down,
+			ui.GlobalKeyMap.Select,
+		},
+		{
+			ui.GlobalKeyMap.Favorite,
+			ui.GlobalKeyMap.Edit,
+			ui.GlobalKeyMap.Aliases,
+			ui.GlobalKeyMap.CopyLink,
+		},
+		{
+			ui.GlobalKeyMap.Back,
+			ui.GlobalKeyMap.Exit,
+			ui.GlobalKeyMap.Help,
+		},
+	}
+}
+
+// channelLoadedMsg carries the log and saved channel info for the page
+type channelLoadedMsg struct {
+	videos   []models.Video
+	channels []models.ChannelInfo
+	err      error
+}
+
+// channelSavedMsg reports the channel info after saving it
+type channelSavedMsg struct {
+	info models.ChannelInfo
+	err  error
+}
+
+type channelFormClosedMsg struct{}
+
+// ChannelModel shows everything logged from one channel
+type ChannelModel struct {
+	route     ui.ChannelRouteState
+	channel   string // the route's channel after merges
+	detail    analytics.ChannelDetail
+	info      models.ChannelInfo
+	videoList list.Model
+	help      help.Model
+	form      *FormModel
+	err       error
+}
+
+func NewChannelModel(route ui.ChannelRouteState) ChannelModel {
+	videoList := list.New([]list.Item{}, VideoListDelegate{}, channelWidth, channelListHeight)
+	ui.SetupList(&videoList)
+	videoList.SetShowStatusBar(false)
+	videoList.SetFilteringEnabled(false)
+	videoList.SetShowTitle(false)
+	videoList.SetShowHelp(false)
+	videoList.SetShowPagination(false)
+
+	h := ui.NewHelp()
+	h.ShowAll = false
+
+	return ChannelModel{
+		route:     route,
+		channel:   route.Channel,
+		info:      models.ChannelInfo{Name: route.Channel},
+		videoList: videoList,
+		help:      h,
+	}
+}
+
+// RefreshStyles applies a changed key map or symbols mode to the list
+func (m *ChannelModel) RefreshStyles() {
+	ui.SetupList(&m.videoList)
+}
+
+// Route returns the route parameters this model was created for.
+func (m ChannelModel) Route() ui.ChannelRouteState { return m.route }
+
+func (m ChannelModel) Init() tea.Cmd {
+	return func() tea.Msg {
+		videos, err := models.LoadVideos()
+		if err != nil {
+			return channelLoadedMsg{err: err}
+		}
+		channels, err := models.LoadChannels()
+		return channelLoadedMsg{videos: videos, channels: channels, err: err}
+	}
+}
+
+// saveChannel stores info and reports back with a channelSavedMsg
+func saveChannel(info models.ChannelInfo) tea.Cmd {
+	return func() tea.Msg {
+		_, err := models.UpsertChannel(info)
+		return channelSavedMsg{info: info, err: err}
+	}
+}
+
+// openAliasForm merges other channel names into this one
+func (m *ChannelModel) openAliasForm() {
+	fields := []FormField{
+		{Placeholder: "old name, typo, ...", Label: "Also Known As:", Required: false, CharLimit: 300, Width: 60, Type: FormFieldText, Value: strings.Join(m.info.Aliases, ", ")},
+	}
+	form := NewForm("merge into "+m.channel, fields, "save")
+	form.SetHandlers(
+		func(form FormModel) tea.Cmd {
+			info := m.info
+			info.Aliases = nil
+			for _, alias := range strings.Split(form.Value(0), ",") {
+				alias = strings.TrimSpace(alias)
+				if alias != "" && !strings.EqualFold(alias, m.channel) {
+					info.Aliases = append(info.Aliases, alias)
+				}
+			}
+			return saveChannel(info)
+		},
+		func() tea.Cmd {
+			return func() tea.Msg { return channelFormClosedMsg{} }
+		},
+	)
+	m.form = &form
+}
+
+// openForm edits the channel's link and notes
+func (m *ChannelModel) openForm() {
+	fields := []FormField{
+		{Placeholder: "https://www.youtube.com/@channel", Label: "Link:", Required: false, CharLimit: 200, Width: 60, Type: FormFieldText, Value: m.info.URL},
+		{Placeholder: "what this channel is good for...", Label: "Notes:", Required: false, CharLimit: 500, Width: 60, Type: FormFieldText, Value: m.info.Notes},
+	}
+	form := NewForm("edit "+m.channel, fields, "save")
+	form.SetHandlers(
+		func(form FormModel) tea.Cmd {
+			info := m.info
+			info.URL = strings.TrimSpace(form.Value(0))
+			info.Notes = strings.TrimSpace(form.Value(1))
+			return saveChannel(info)
+		},
+		func() tea.Cmd {
+			return func() tea.Msg { return channelFormClosedMsg{} }
+		},
+	)
+	m.form = &form
+}
+
+func (m ChannelModel) Update(msg tea.Msg) (ChannelModel, tea.Cmd) {
+	switch msg := msg.(type) {
+	case channelLoadedMsg:
+		m.err = msg.err
+		if msg.err != nil {
+			return m, nil
+		}
+		// the route may name an alias or a renamed channel
+		channels := models.NewChannelMap(msg.channels, msg.videos)
+		if name := channels.Resolve(models.Video{Channel: m.route.Channel, ChannelID: m.route.ChannelID}); name != "" {
+			m.channel = name
+		}
+		m.detail = analytics.ChannelDetails(channels.Apply(msg.videos), m.channel)
+		m.info = models.FindChannel(msg.channels, m.channel)
+		m.info.Name = m.channel
+
+		items := make([]list.Item, len(m.detail.Videos))
+		for i, video := range m.detail.Videos {
+			items[i] = VideoItem{video: video}
+		}
+		m.videoList.SetItems(items)
+		return m, nil
+	case channelSavedMsg:
+		if msg.err != nil {
+			if m.form != nil {
+				m.form.SetError(msg.err.Error())
+				return m, nil
+			}
+			return m, ui.ReportError("save channel", msg.err)
+		}
+		m.form = nil
+		aliasesChanged := strings.Join(m.info.Aliases, "\n") != strings.Join(msg.info.Aliases, "\n")
+		m.info = msg.info
+		if aliasesChanged {
+			// regroup the videos under the new names
+			return m, tea.Batch(m.Init(), ui.Notify("channel saved"))
+		}
+		return m, ui.Notify("channel saved")
+	case channelFormClosedMsg:
+		m.form = nil
+		return m, nil
+	}
+
+	if m.form != nil {
+		form, cmd := m.form.Update(msg)
+		m.form = &form
+		return m, cmd
+	}
+
+	var cmd tea.Cmd
+	if msg, ok := msg.(tea.KeyMsg); ok {
+		switch {
+		case key.Matches(msg, ui.GlobalKeyMap.Help):
+			m.help.ShowAll = !m.help.ShowAll
+		case key.Matches(msg, ui.GlobalKeyMap.Back, ui.GlobalKeyMap.Cancel):
+			return m, func() tea.Msg { return ui.BackMsg{} }
+		case key.Matches(msg, ui.GlobalKeyMap.Favorite):
+			info := m.info
+			info.Favorite = !info.Favorite
+			return m, saveChannel(info)
+		case key.Matches(msg, ui.GlobalKeyMap.Edit):
+			m.openForm()
+		case key.Matches(msg, ui.GlobalKeyMap.Aliases):
+			m.openAliasForm()
+		case key.Matches(msg, ui.GlobalKeyMap.CopyLink):
+			if err := clipboard.WriteAll(m.info.Link(m.detail.ChannelID)); err != nil {
+				return m, ui.ReportError("copy link", err)
+			}
+			return m, ui.Notify("link copied")
+		case key.Matches(msg, ui.GlobalKeyMap.Select):
+			if item, ok := m.videoList.SelectedItem().(VideoItem); ok {
+				return m, func() tea.Msg {
+					return ui.NavigateMsg{View: ui.LogDetailsView, State: ui.VideoRouteState{VideoID: item.video.ID}}
+				}
+			}
+		case key.Matches(msg, ui.GlobalKeyMap.Up, ui.GlobalKeyMap.Down):
+			m.videoList, cmd = m.videoList.Update(msg)
+		}
+	}
+	return m, cmd
+}
+
+// formatWatchTime formats seconds as hours and minutes
+func formatWatchTime(seconds int) string {
+	hours, minutes := seconds/3600, seconds/60%60
+	if hours == 0 {
+		return fmt.Sprintf("%dm", minutes)
+	}
+	return fmt.Sprintf("%dh %02dm", hours, minutes)
+}
+
+func (m ChannelModel) View() string {
+	if m.form != nil {
+		return m.form.View()
+	}
+
+	var s strings.Builder
+	s.WriteString(ui.HeaderStyle.Render("channel") + "\n\n")
+
+	if m.err != nil {
+		s.WriteString("could not load channel: " + m.err.Error() + "\n")
+		return s.String()
+	}
+
+	heading := lipgloss.NewStyle().Foreground(ui.PrimaryColor).Bold(true)
+	name := heading.Render(m.channel)
+	if m.info.Favorite {
+		name += heading.Render(" " + ui.Symbols.Favorite)
+	}
+	s.WriteString(name + "\n")
+	if len(m.info.Aliases) > 0 {
+		s.WriteString(ui.DescriptionStyle.Padding(0).Render("also known as "+strings.Join(m.info.Aliases, ", ")) + "\n")
+	}
+	s.WriteString("\n")
+
+	d := m.detail
+	if d.Count == 0 {
+		s.WriteString("no videos logged from this channel\n")
+		s.WriteString("\n" + m.help.View(ChannelKeyMap{}))
+		return s.String()
+	}
+
+	// headline numbers
+	videos := fmt.Sprintf("%d videos", d.Count)
+	if d.Count == 1 {
+		videos = "1 video"
+	}
+	if d.Rewatches > 0 {
+		videos += fmt.Sprintf(" %s %d rewatched", ui.Symbols.Separator, d.Rewatches)
+	}
+	s.WriteString("Videos: " + videos + "\n")
+	if d.TotalRated > 0 {
+		s.WriteString(fmt.Sprintf("Average Rating: %s (%.1f/5 over %d)\n", renderStars(d.AvgRating), d.AvgRating, d.TotalRated))
+	} else {
+		s.WriteString("Average Rating: no ratings\n")
+	}
+	if !d.First.IsZero() {
+		s.WriteString(fmt.Sprintf("Watched: %s to %s\n", d.First.Format(models.ISODateFormat), d.Last.Format(models.ISODateFormat)))
+	}
+	watchTime := formatWatchTime(d.WatchTime)
+	if d.Untimed > 0 {
+		watchTime += fmt.Sprintf(" (%d without a duration)", d.Untimed)
+	}
+	s.WriteString("Watch Time: " + watchTime + "\n")
+	s.WriteString("Link: " + truncateString(m.info.Link(m.detail.ChannelID), channelWidth-7) + "\n")
+
+	// rating trend, oldest first
+	if trends := analytics.ChannelTrends(d.Videos, 2); len(trends) == 1 {
+		t := trends[0]
+		trend := fmt.Sprintf("Rating Trend: %s %.1f %s %.1f", chart.Sparkline(t.Ratings, 1, 5, 24), t.Earlier, ui.Symbols.Arrow, t.Recent)
+		if t.Declining() {
+			trend += lipgloss.NewStyle().Foreground(ui.DangerColor).Render(" " + ui.Symbols.Falling)
+		}
+		s.WriteString(trend + "\n")
+	}
+
+	// notes
+	notes := m.info.Notes
+	if notes == "" {
+		notes = "no notes"
+	}
+	s.WriteString("\nNotes:\n" + ui.ReviewStyle.Width(channelWidth).Render(notes) + "\n")
+
+	// videos, newest first
+	s.WriteString(fmt.Sprintf("\nVideos (%d/%d)\n", m.videoList.Index()+1, len(d.Videos)))
+	s.WriteString(m.videoList.View() + "\n")
+
+	s.WriteString("\n" + m.help.View(ChannelKeyMap{}))
+
+	return s.String()
+}
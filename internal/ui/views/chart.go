@@ -6,140 +6,175 @@ import (
 	"time"
 
 	"github.com/charmbracelet/lipgloss"
+	"github.com/mamuzad/vidlogd/internal/analytics"
 	"github.com/mamuzad/vidlogd/internal/models"
 	"github.com/mamuzad/vidlogd/internal/ui"
+	"github.com/mamuzad/vidlogd/internal/ui/chart"
 )
 
 type ChartData struct {
-	Title    string
-	Labels   []string
-	Values   []int
-	MaxItems int
+	Title       string
+	Labels      []string
+	Values      []float64
+	ValueFormat string  // defaults to whole numbers
+	Scale       float64 // value of a full bar, 0 to fit the largest value
+	Footer      string
+}
+
+// stats boxes fit the window between these content widths
+const (
+	minStatsWidth = 54
+	maxStatsWidth = 110
+
+	// popup and box borders and padding around stats content
+	statsChrome = 12
+)
+
+// contentWidth is the space inside the stats boxes
+func (m StatsModel) contentWidth() int {
+	if m.width <= 0 {
+		return minStatsWidth
+	}
+	return max(minStatsWidth, min(maxStatsWidth, m.width-statsChrome))
+}
+
+// boxWidth is the lipgloss width of a stats box with one column of padding
+func (m StatsModel) boxWidth() int {
+	return m.contentWidth() + 2
 }
 
 func (m StatsModel) renderChart(data ChartData, isFocused bool) string {
 	chartStyle := lipgloss.NewStyle().
-		Border(lipgloss.RoundedBorder()).
+		Border(ui.Border()).
 		Padding(0, 1).
 		Margin(1, 0).
-		Width(56)
+		Width(m.boxWidth())
 
 	if isFocused {
 		chartStyle = chartStyle.BorderForeground(ui.PrimaryColor)
 	}
 
-	var chart strings.Builder
-	chart.WriteString(" " + data.Title + "\n\n")
-
-	if len(data.Values) == 0 {
-		chart.WriteString("No data available")
-		return chartStyle.Render(chart.String()) + "\n"
+	var b strings.Builder
+	b.WriteString(" " + data.Title + "\n\n")
+	b.WriteString(chart.Bars(chart.Series{Labels: data.Labels, Values: data.Values}, chart.Options{
+		Width:  m.contentWidth(),
+		Max:    data.Scale,
+		Format: data.ValueFormat,
+		Style:  lipgloss.NewStyle().Foreground(ui.ChartColor),
+	}))
+	if data.Footer != "" {
+		b.WriteString("\n\n" + ui.DescriptionStyle.Render(data.Footer))
 	}
 
-	maxCount := 0
-	for _, count := range data.Values {
-		if count > maxCount {
-			maxCount = count
-		}
-	}
+	return chartStyle.Render(b.String()) + "\n"
+}
 
-	const maxBarHeight = 8
+func (m StatsModel) prepareRatingChartData(ratings []analytics.RatingBucket) ChartData {
+	labels := make([]string, len(ratings))
+	values := make([]float64, len(ratings))
 
-	// limit items if needed
-	items := len(data.Values)
-	if data.MaxItems > 0 && items > data.MaxItems {
-		items = data.MaxItems
+	for i, bucket := range ratings {
+		labels[i] = renderStars(bucket.Rating)
+		values[i] = float64(bucket.Count)
 	}
 
-	// build each row of the chart from top to bottom
-	for row := maxBarHeight; row >= 1; row-- {
-		for i := range items {
-			count := data.Values[i]
-			barHeight := 0
-			if maxCount > 0 && count > 0 {
-				barHeight = int(float64(maxBarHeight) * float64(count) / float64(maxCount))
-				if barHeight == 0 && count > 0 {
-					barHeight = 1 // ensure at least 1 row for non-zero counts
-				}
-			}
-
-			if row <= barHeight {
-				chart.WriteString("█████ ")
-			} else {
-				chart.WriteString("      ")
-			}
-		}
-		chart.WriteString("\n")
+	return ChartData{
+		Title:  ui.Icon(ui.Symbols.StarIcon, "  ") + "Ratings",
+		Labels: labels,
+		Values: values,
 	}
+}
 
-	// labels
-	for i := range items {
-		chart.WriteString(fmt.Sprintf("%-6s", data.Labels[i]))
-	}
-	chart.WriteString("\n")
+// weekdays in chart order, monday first like the heatmap
+var chartWeekdays = []time.Weekday{
+	time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday, time.Saturday, time.Sunday,
+}
 
-	// values
-	for i := range items {
-		chart.WriteString(fmt.Sprintf("  %-4s", fmt.Sprintf("%d", data.Values[i])))
+// hourLabels names every third hour, the others are too narrow to label
+func hourLabels() []string {
+	labels := make([]string, 24)
+	for hour := 0; hour < 24; hour += 3 {
+		labels[hour] = fmt.Sprintf("%02d", hour)
 	}
-	chart.WriteString("\n")
-
-	return chartStyle.Render(chart.String()) + "\n"
+	return labels
 }
 
-func (m StatsModel) prepareRatingChartData(ratingDist map[float64]int) ChartData {
-	ratings := []float64{1.0, 1.5, 2.0, 2.5, 3.0, 3.5, 4.0, 4.5, 5.0}
-
-	labels := make([]string, len(ratings))
-	values := make([]int, len(ratings))
-
-	for i, rating := range ratings {
-		labels[i] = m.renderStars(rating)
-		values[i] = ratingDist[rating]
+func (m StatsModel) prepareHourChartData(videos []models.Video) ChartData {
+	slots := analytics.ByHour(videos)
+	values := make([]float64, len(slots))
+	peak := 0
+	for hour, slot := range slots {
+		values[hour] = float64(slot.Count)
+		if slot.Count > slots[peak].Count {
+			peak = hour
+		}
 	}
 
 	return ChartData{
-		Title:    "  Ratings",
-		Labels:   labels,
-		Values:   values,
-		MaxItems: 0, // Show all ratings
+		Title:  ui.Icon(ui.Symbols.ClockIcon, "  ") + "Time of day",
+		Labels: hourLabels(),
+		Values: values,
+		Footer: fmt.Sprintf("peak %02d:00 %s work hours (Mon-Fri %d-%d): %.0f%%",
+			peak, ui.Symbols.Separator, analytics.WorkStart, analytics.WorkEnd, analytics.WorkHoursShare(videos)*100),
 	}
 }
 
-func (m StatsModel) prepareMonthlyChartData(monthStats []MonthStats) ChartData {
-	// create a map for quick lookup of existing month data
-	monthMap := make(map[string]int)
-	for _, month := range monthStats {
-		monthMap[month.Month] = month.Count
+func (m StatsModel) prepareWeekdayChartData(videos []models.Video) ChartData {
+	slots := analytics.ByWeekday(videos)
+	labels := make([]string, len(chartWeekdays))
+	values := make([]float64, len(chartWeekdays))
+	for i, weekday := range chartWeekdays {
+		labels[i] = weekday.String()[:3]
+		values[i] = float64(slots[weekday].Count)
 	}
 
-	var continuousMonths []string
+	return ChartData{
+		Title:  ui.Icon(ui.Symbols.WeekIcon, "  ") + "Weekdays",
+		Labels: labels,
+		Values: values,
+	}
+}
 
-	// start from the most recent month and go back
-	now := time.Now()
-	for i := range 9 {
-		monthTime := now.AddDate(0, -i, 0)
-		monthKey := monthTime.Format(models.MonthFormat)
-		continuousMonths = append([]string{monthKey}, continuousMonths...)
+func (m StatsModel) prepareHourRatingChartData(videos []models.Video) ChartData {
+	slots := analytics.ByHour(videos)
+	values := make([]float64, len(slots))
+	best := -1
+	for hour, slot := range slots {
+		values[hour] = slot.AvgRating
+		if slot.Rated > 0 && (best < 0 || slot.AvgRating > slots[best].AvgRating) {
+			best = hour
+		}
 	}
 
-	labels := make([]string, len(continuousMonths))
-	values := make([]int, len(continuousMonths))
+	footer := "no rated videos"
+	if best >= 0 {
+		footer = fmt.Sprintf("best rated at %02d:00 with %.1f/5 over %d videos", best, slots[best].AvgRating, slots[best].Rated)
+	}
 
-	for i, monthKey := range continuousMonths {
-		labels[i] = monthKey
+	return ChartData{
+		Title:       ui.Icon(ui.Symbols.StarOutlineIcon, "  ") + "Rating by time of day",
+		Labels:      hourLabels(),
+		Values:      values,
+		ValueFormat: "%.1f",
+		Scale:       5,
+		Footer:      footer,
+	}
+}
 
-		if count, exists := monthMap[monthKey]; exists {
-			values[i] = count
-		} else {
-			values[i] = 0
-		}
+func (m StatsModel) prepareWeekdayRatingChartData(videos []models.Video) ChartData {
+	slots := analytics.ByWeekday(videos)
+	labels := make([]string, len(chartWeekdays))
+	values := make([]float64, len(chartWeekdays))
+	for i, weekday := range chartWeekdays {
+		labels[i] = weekday.String()[:3]
+		values[i] = slots[weekday].AvgRating
 	}
 
 	return ChartData{
-		Title:    "  Months",
-		Labels:   labels,
-		Values:   values,
-		MaxItems: 9,
+		Title:       ui.Icon(ui.Symbols.StarOutlineIcon, "  ") + "Rating by weekday",
+		Labels:      labels,
+		Values:      values,
+		ValueFormat: "%.1f",
+		Scale:       5,
 	}
 }
@@ -0,0 +1,257 @@
+package views
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/table"
+	"github.com/mamuzad/vidlogd/internal/models"
+	"github.com/mamuzad/vidlogd/internal/ui"
+)
+
+// logColumn describes one column the log list can show
+type logColumn struct {
+	key      string
+	title    string
+	minWidth int
+	weight   int // share of spare width, 0 for fixed width columns
+	value    func(models.Video) string
+	compare  func(a, b models.Video) int
+}
+
+// log list column keys, also persisted in settings
+const (
+	columnTitle      = "title"
+	columnChannel    = "channel"
+	columnRating     = "rating"
+	columnLogged     = "logged"
+	columnReleased   = "released"
+	columnRewatched  = "rewatched"
+	columnReview     = "review"
+	columnTags       = "tags"
+	columnDuration   = "duration"
+	columnCollection = "collection"
+)
+
+var defaultLogColumns = []string{columnTitle, columnChannel, columnRating, columnLogged}
+
+// default sort: most recently logged first
+const defaultLogSort = columnLogged + ":desc"
+
+// cell padding added by the table on each side
+const columnPadding = 2
+
+var logColumns = []logColumn{
+	{
+		key: columnTitle, title: "Title", minWidth: 20, weight: 3,
+		value: func(v models.Video) string { return orDefault(v.Title, "Untitled") },
+		compare: func(a, b models.Video) int {
+			return strings.Compare(strings.ToLower(a.Title), strings.ToLower(b.Title))
+		},
+	},
+	{
+		key: columnChannel, title: "Channel", minWidth: 12, weight: 1,
+		value: func(v models.Video) string { return orDefault(v.Channel, "Unknown Channel") },
+		compare: func(a, b models.Video) int {
+			return strings.Compare(strings.ToLower(a.Channel), strings.ToLower(b.Channel))
+		},
+	},
+	{
+		key: columnRating, title: "Rating", minWidth: 8,
+		value:   func(v models.Video) string { return ratingCell(v.Rating) },
+		compare: func(a, b models.Video) int { return compareFloat(a.Rating, b.Rating) },
+	},
+	{
+		key: columnLogged, title: "Date Logged", minWidth: 19,
+		value: func(v models.Video) string {
+			if v.LogDate.IsZero() {
+				return "No date"
+			}
+			return v.LogDate.Format(models.DateTimeFormat)
+		},
+		compare: func(a, b models.Video) int { return a.LogDate.Compare(b.LogDate) },
+	},
+	{
+		key: columnReleased, title: "Released", minWidth: 10,
+		value:   func(v models.Video) string { return v.ReleaseDate },
+		compare: func(a, b models.Video) int { return strings.Compare(a.ReleaseDate, b.ReleaseDate) },
+	},
+	{
+		key: columnRewatched, title: "Rewatched", minWidth: 9,
+		value: func(v models.Video) string {
+			if v.Rewatched {
+				return "yes"
+			}
+			return ""
+		},
+		compare: func(a, b models.Video) int { return compareBool(a.Rewatched, b.Rewatched) },
+	},
+	{
+		key: columnReview, title: "Review", minWidth: 16, weight: 2,
+		value:   func(v models.Video) string { return strings.Join(strings.Fields(v.Review), " ") },
+		compare: func(a, b models.Video) int { return strings.Compare(a.Review, b.Review) },
+	},
+	{
+		key: columnTags, title: "Tags", minWidth: 10, weight: 1,
+		value: func(v models.Video) string { return strings.Join(v.Tags, ", ") },
+		compare: func(a, b models.Video) int {
+			return strings.Compare(strings.Join(a.Tags, ","), strings.Join(b.Tags, ","))
+		},
+	},
+	{
+		key: columnDuration, title: "Length", minWidth: 8,
+		value:   func(v models.Video) string { return models.FormatDuration(v.Duration) },
+		compare: func(a, b models.Video) int { return a.Duration - b.Duration },
+	},
+	{
+		key: columnCollection, title: "Collection", minWidth: 12, weight: 1,
+		value: func(v models.Video) string { return v.Collection },
+		compare: func(a, b models.Video) int {
+			return strings.Compare(strings.ToLower(a.Collection), strings.ToLower(b.Collection))
+		},
+	},
+}
+
+func findLogColumn(key string) (logColumn, bool) {
+	for _, col := range logColumns {
+		if col.key == key {
+			return col, true
+		}
+	}
+	return logColumn{}, false
+}
+
+// resolveLogColumns returns the known columns for keys, the defaults when none
+func resolveLogColumns(keys []string) []logColumn {
+	var cols []logColumn
+	seen := make(map[string]bool)
+	for _, key := range keys {
+		if col, ok := findLogColumn(key); ok && !seen[key] {
+			seen[key] = true
+			cols = append(cols, col)
+		}
+	}
+	if len(cols) == 0 {
+		return resolveLogColumns(defaultLogColumns)
+	}
+	return cols
+}
+
+// parseLogSort splits a persisted sort like "rating:desc"
+func parseLogSort(s string) (string, bool) {
+	key, dir, _ := strings.Cut(s, ":")
+	if _, ok := findLogColumn(key); !ok {
+		key, dir, _ = strings.Cut(defaultLogSort, ":")
+	}
+	return key, dir == "desc"
+}
+
+func formatLogSort(key string, desc bool) string {
+	if desc {
+		return key + ":desc"
+	}
+	return key + ":asc"
+}
+
+// sortVideos sorts by the given column, newest log first among equals
+func sortVideos(videos []models.Video, key string, desc bool) {
+	col, ok := findLogColumn(key)
+	if !ok {
+		return
+	}
+	sort.SliceStable(videos, func(i, j int) bool {
+		c := col.compare(videos[i], videos[j])
+		if c == 0 {
+			// ties would otherwise keep the order of the previous sort
+			return videos[i].LogDate.After(videos[j].LogDate)
+		}
+		if desc {
+			return c > 0
+		}
+		return c < 0
+	})
+}
+
+// columnWidths fits columns to the available width: each column gets its
+// minimum, and flexible columns share what is left by weight
+func columnWidths(cols []logColumn, available int) []int {
+	widths := make([]int, len(cols))
+	used, totalWeight := 0, 0
+	for i, col := range cols {
+		widths[i] = col.minWidth
+		used += col.minWidth + columnPadding
+		totalWeight += col.weight
+	}
+
+	spare := available - used
+	if spare <= 0 || totalWeight == 0 {
+		return widths
+	}
+
+	given := 0
+	last := -1
+	for i, col := range cols {
+		if col.weight == 0 {
+			continue
+		}
+		extra := spare * col.weight / totalWeight
+		widths[i] += extra
+		given += extra
+		last = i
+	}
+	// rounding leftovers go to the last flexible column
+	widths[last] += spare - given
+
+	return widths
+}
+
+// tableColumns builds bubbles columns, marking the sorted one
+func tableColumns(cols []logColumn, widths []int, sortKey string, desc bool) []table.Column {
+	columns := make([]table.Column, len(cols))
+	for i, col := range cols {
+		title := col.title
+		if col.key == sortKey {
+			if desc {
+				title += " " + ui.Symbols.Falling
+			} else {
+				title += " " + ui.Symbols.Ascending
+			}
+		}
+		columns[i] = table.Column{Title: title, Width: widths[i]}
+	}
+	return columns
+}
+
+func ratingCell(rating float64) string {
+	if rating <= 0 {
+		return ""
+	}
+	return ui.Stars(rating)
+}
+
+func orDefault(s, fallback string) string {
+	if s == "" {
+		return fallback
+	}
+	return s
+}
+
+func compareFloat(a, b float64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+func compareBool(a, b bool) int {
+	switch {
+	case a == b:
+		return 0
+	case a:
+		return 1
+	}
+	return -1
+}
@@ -0,0 +1,248 @@
+package views
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/help"
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/mamuzad/vidlogd/internal/dedupe"
+	"github.com/mamuzad/vidlogd/internal/models"
+	"github.com/mamuzad/vidlogd/internal/ui"
+)
+
+// duplicates screen layout
+const (
+	dedupeWidth = 60
+	dedupeRows  = 6
+)
+
+func init() {
+	ui.RegisterKeyScope("duplicates", "Up", "Down", "Select", "MergeAll", "Back")
+}
+
+type DedupeKeyMap struct{}
+
+func (k DedupeKeyMap) ShortHelp() []key.Binding {
+	return []key.Binding{
+		ui.GlobalKeyMap.Up,
+		ui.GlobalKeyMap.Down,
+		ui.Relabel(ui.GlobalKeyMap.Select, "merge"),
+		ui.GlobalKeyMap.MergeAll,
+		ui.GlobalKeyMap.Back,
+	}
+}
+
+func (k DedupeKeyMap) FullHelp() [][]key.Binding {
+	return [][]key.Binding{k.ShortHelp()}
+}
+
+// dedupeLoadedMsg carries the duplicate groups found in the log
+type dedupeLoadedMsg struct {
+	groups []dedupe.Group
+	err    error
+}
+
+// dedupeMergedMsg reports how many groups were merged
+type dedupeMergedMsg struct {
+	merged int
+	err    error
+}
+
+// DedupeModel lists videos logged more than once and merges them
+type DedupeModel struct {
+	groups []dedupe.Group
+	cursor int
+	help   help.Model
+	err    error
+
+	// groups waiting for a yes before they are merged
+	confirm []dedupe.Group
+}
+
+func NewDedupeModel() DedupeModel {
+	h := ui.NewHelp()
+	h.ShowAll = false
+	return DedupeModel{help: h}
+}
+
+func (m DedupeModel) Init() tea.Cmd {
+	return loadDuplicates
+}
+
+func loadDuplicates() tea.Msg {
+	videos, err := models.LoadVideos()
+	if err != nil {
+		return dedupeLoadedMsg{err: err}
+	}
+	return dedupeLoadedMsg{groups: dedupe.Find(videos)}
+}
+
+// mergeGroups merges each group with a write per group, stopping at the
+// first error
+func mergeGroups(groups []dedupe.Group) tea.Cmd {
+	return func() tea.Msg {
+		for i, g := range groups {
+			if err := models.MergeVideos(g.Merged(), g.Removed()); err != nil {
+				return dedupeMergedMsg{merged: i, err: err}
+			}
+		}
+		return dedupeMergedMsg{merged: len(groups)}
+	}
+}
+
+func (m DedupeModel) Update(msg tea.Msg) (DedupeModel, tea.Cmd) {
+	switch msg := msg.(type) {
+	case dedupeLoadedMsg:
+		m.err = msg.err
+		m.groups = msg.groups
+		m.cursor = min(m.cursor, max(0, len(m.groups)-1))
+		return m, nil
+	case dedupeMergedMsg:
+		if msg.err != nil {
+			// groups before the failing one were merged
+			return m, tea.Batch(loadDuplicates, ui.LibraryChanged, ui.ReportError("merge duplicates", msg.err))
+		}
+		done := fmt.Sprintf("merged %d videos", msg.merged)
+		if msg.merged == 1 {
+			done = "merged 1 video"
+		}
+		return m, tea.Batch(loadDuplicates, ui.LibraryChanged, ui.Notify(done))
+	}
+
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return m, nil
+	}
+
+	if m.confirm != nil {
+		groups := m.confirm
+		m.confirm = nil
+		if key.Matches(keyMsg, ui.GlobalKeyMap.Yes) {
+			return m, mergeGroups(groups)
+		}
+		return m, nil
+	}
+
+	switch {
+	case key.Matches(keyMsg, ui.GlobalKeyMap.Back, ui.GlobalKeyMap.Cancel):
+		return m, func() tea.Msg { return ui.BackMsg{} }
+	case key.Matches(keyMsg, ui.GlobalKeyMap.Up):
+		if m.cursor > 0 {
+			m.cursor--
+		}
+	case key.Matches(keyMsg, ui.GlobalKeyMap.Down):
+		if m.cursor < len(m.groups)-1 {
+			m.cursor++
+		}
+	case key.Matches(keyMsg, ui.GlobalKeyMap.Select):
+		if len(m.groups) > 0 {
+			m.confirm = m.groups[m.cursor : m.cursor+1]
+		}
+	case key.Matches(keyMsg, ui.GlobalKeyMap.MergeAll):
+		if len(m.groups) > 0 {
+			m.confirm = m.groups
+		}
+	}
+	return m, nil
+}
+
+func (m DedupeModel) View() string {
+	var s strings.Builder
+	s.WriteString(ui.HeaderStyle.Render("duplicates") + "\n\n")
+
+	if m.err != nil {
+		s.WriteString("could not load videos: " + m.err.Error() + "\n")
+		return s.String()
+	}
+
+	if len(m.groups) == 0 {
+		s.WriteString("no video is logged twice, rewatches are kept as they are\n")
+	} else {
+		found := fmt.Sprintf("%d videos are", len(m.groups))
+		if len(m.groups) == 1 {
+			found = "1 video is"
+		}
+		s.WriteString(ui.DescriptionStyle.Padding(0).Render(found+" logged more than once, rewatches are left alone") + "\n\n")
+
+		offset := max(0, min(m.cursor-dedupeRows+1, len(m.groups)-dedupeRows))
+		for i := offset; i < min(len(m.groups), offset+dedupeRows); i++ {
+			g := m.groups[i]
+			line := fmt.Sprintf("%-*s %dx", dedupeWidth-4, truncateString(g.Merged().Title, dedupeWidth-8), len(g.Videos))
+			s.WriteString(ui.RenderItem(line, i == m.cursor))
+			s.WriteString("\n")
+		}
+
+		if m.confirm != nil {
+			s.WriteString(m.renderConfirm())
+		} else {
+			s.WriteString("\n" + m.renderPreview(m.groups[m.cursor]))
+		}
+	}
+
+	s.WriteString("\n" + m.help.View(DedupeKeyMap{}))
+	return s.String()
+}
+
+// renderPreview shows each entry of a group and the entry they merge into
+func (m DedupeModel) renderPreview(g dedupe.Group) string {
+	merged := g.Merged()
+	keep := lipgloss.NewStyle().Foreground(ui.PrimaryColor).Bold(true)
+
+	var s strings.Builder
+	for _, video := range g.Videos {
+		marker := ui.DescriptionStyle.Padding(0).Render("merge")
+		if video.ID == merged.ID {
+			marker = keep.Render("keep ")
+		}
+		s.WriteString(marker + "  " + dedupeSummary(video) + "\n")
+	}
+	s.WriteString(keep.Render("into ") + "  " + dedupeSummary(merged) + "\n")
+	return s.String()
+}
+
+// renderConfirm asks before merging, since merging deletes the extra entries
+func (m DedupeModel) renderConfirm() string {
+	question := fmt.Sprintf("Merge %d videos?", len(m.confirm))
+	if len(m.confirm) == 1 {
+		question = fmt.Sprintf("Merge \"%s\"?", m.confirm[0].Merged().Title)
+	}
+	removed := 0
+	for _, g := range m.confirm {
+		removed += len(g.Videos) - 1
+	}
+	entries := fmt.Sprintf("%d extra entries are deleted.", removed)
+	if removed == 1 {
+		entries = "1 extra entry is deleted."
+	}
+
+	yesHelp := ui.GlobalKeyMap.Yes.Help().Key
+	noHelp := ui.GlobalKeyMap.No.Help().Key
+	body := ui.ModalStyle.Padding(0, 2).Render(
+		ui.DangerStyle.Render("Confirm merge") + "\n\n" +
+			question + "\n" + entries + "\n\n" +
+			ui.DescriptionStyle.Render(fmt.Sprintf("%s: merge   %s: cancel", yesHelp, noHelp)),
+	)
+	return "\n" + ui.CenterHorizontally(body, dedupeWidth) + "\n"
+}
+
+// dedupeSummary puts an entry's log date, rating, tags and review on one
+// short line
+func dedupeSummary(video models.Video) string {
+	parts := []string{"no date"}
+	if !video.LogDate.IsZero() {
+		parts[0] = video.LogDate.Format(models.ISODateFormat)
+	}
+	if video.Rating > 0 {
+		parts = append(parts, ui.Stars(video.Rating))
+	}
+	if len(video.Tags) > 0 {
+		parts = append(parts, truncateString("#"+strings.Join(video.Tags, " #"), 14))
+	}
+	if review := strings.Join(strings.Fields(video.Review), " "); review != "" {
+		parts = append(parts, fmt.Sprintf("%q", truncateString(review, 14)))
+	}
+	return strings.Join(parts, " "+ui.Symbols.Separator+" ")
+}
@@ -0,0 +1,183 @@
+package views
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/help"
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/mamuzad/vidlogd/internal/doctor"
+	"github.com/mamuzad/vidlogd/internal/ui"
+)
+
+// library check screen layout
+const (
+	doctorWidth = 60
+	doctorRows  = 8
+)
+
+func init() {
+	ui.RegisterKeyScope("check library", "Up", "Down", "Select", "FixAll", "Back")
+}
+
+type DoctorKeyMap struct{}
+
+func (k DoctorKeyMap) ShortHelp() []key.Binding {
+	return []key.Binding{
+		ui.GlobalKeyMap.Up,
+		ui.GlobalKeyMap.Down,
+		ui.Relabel(ui.GlobalKeyMap.Select, "fix/edit"),
+		ui.GlobalKeyMap.FixAll,
+		ui.GlobalKeyMap.Back,
+	}
+}
+
+func (k DoctorKeyMap) FullHelp() [][]key.Binding {
+	return [][]key.Binding{k.ShortHelp()}
+}
+
+// doctorScannedMsg carries the problems found in the library
+type doctorScannedMsg struct {
+	issues []doctor.Issue
+	err    error
+}
+
+// doctorFixedMsg reports how many problems were fixed
+type doctorFixedMsg struct {
+	fixed int
+	err   error
+}
+
+// DoctorModel lists problems in the library and repairs them
+type DoctorModel struct {
+	issues []doctor.Issue
+	cursor int
+	help   help.Model
+	err    error
+}
+
+func NewDoctorModel() DoctorModel {
+	h := ui.NewHelp()
+	h.ShowAll = false
+	return DoctorModel{help: h}
+}
+
+func (m DoctorModel) Init() tea.Cmd {
+	return scanLibrary
+}
+
+func scanLibrary() tea.Msg {
+	issues, err := doctor.Scan()
+	return doctorScannedMsg{issues: issues, err: err}
+}
+
+func fixIssues(issues []doctor.Issue) tea.Cmd {
+	return func() tea.Msg {
+		fixed, err := doctor.Fix(issues)
+		return doctorFixedMsg{fixed: fixed, err: err}
+	}
+}
+
+func (m DoctorModel) Update(msg tea.Msg) (DoctorModel, tea.Cmd) {
+	switch msg := msg.(type) {
+	case doctorScannedMsg:
+		m.err = msg.err
+		m.issues = msg.issues
+		m.cursor = min(m.cursor, max(0, len(m.issues)-1))
+		return m, nil
+	case doctorFixedMsg:
+		if msg.err != nil {
+			return m, tea.Batch(scanLibrary, ui.LibraryChanged, ui.ReportError("fix library", msg.err))
+		}
+		done := fmt.Sprintf("fixed %d problems", msg.fixed)
+		if msg.fixed == 1 {
+			done = "fixed 1 problem"
+		}
+		return m, tea.Batch(scanLibrary, ui.LibraryChanged, ui.Notify(done))
+	}
+
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return m, nil
+	}
+
+	switch {
+	case key.Matches(keyMsg, ui.GlobalKeyMap.Back, ui.GlobalKeyMap.Cancel):
+		return m, func() tea.Msg { return ui.BackMsg{} }
+	case key.Matches(keyMsg, ui.GlobalKeyMap.Up):
+		if m.cursor > 0 {
+			m.cursor--
+		}
+	case key.Matches(keyMsg, ui.GlobalKeyMap.Down):
+		if m.cursor < len(m.issues)-1 {
+			m.cursor++
+		}
+	case key.Matches(keyMsg, ui.GlobalKeyMap.Select):
+		if len(m.issues) == 0 {
+			return m, nil
+		}
+		issue := m.issues[m.cursor]
+		if issue.Fixable() {
+			return m, fixIssues([]doctor.Issue{issue})
+		}
+		// the rest needs the user, open the log in the edit form
+		if issue.VideoID != "" {
+			return m, func() tea.Msg {
+				return ui.NavigateMsg{View: ui.LogVideoView, State: ui.VideoRouteState{VideoID: issue.VideoID}}
+			}
+		}
+	case key.Matches(keyMsg, ui.GlobalKeyMap.FixAll):
+		if len(m.issues) > 0 {
+			return m, fixIssues(m.issues)
+		}
+	}
+	return m, nil
+}
+
+func (m DoctorModel) View() string {
+	var s strings.Builder
+	s.WriteString(ui.HeaderStyle.Render("check library") + "\n\n")
+
+	if m.err != nil {
+		s.WriteString("could not check the library: " + m.err.Error() + "\n")
+		s.WriteString("\n" + m.help.View(DoctorKeyMap{}))
+		return s.String()
+	}
+
+	if len(m.issues) == 0 {
+		s.WriteString("no problems found\n")
+	} else {
+		fixable := 0
+		for _, issue := range m.issues {
+			if issue.Fixable() {
+				fixable++
+			}
+		}
+		s.WriteString(ui.DescriptionStyle.Padding(0).Render(fmt.Sprintf("%d problems, %d can be fixed automatically", len(m.issues), fixable)) + "\n\n")
+
+		kindStyle := lipgloss.NewStyle().Foreground(ui.DangerColor).Width(18)
+		offset := max(0, min(m.cursor-doctorRows+1, len(m.issues)-doctorRows))
+		for i := offset; i < min(len(m.issues), offset+doctorRows); i++ {
+			issue := m.issues[i]
+			name := fmt.Sprintf("%-*s", doctorWidth-20, truncateString(issue.Name, doctorWidth-24))
+			name = ui.RenderItem(name, i == m.cursor)
+			s.WriteString(kindStyle.Render(string(issue.Kind)) + name + "\n")
+		}
+		if len(m.issues) > doctorRows {
+			s.WriteString(ui.DescriptionStyle.Render(fmt.Sprintf("%d-%d of %d", offset+1, min(len(m.issues), offset+doctorRows), len(m.issues))) + "\n")
+		}
+
+		issue := m.issues[m.cursor]
+		s.WriteString("\n" + lipgloss.NewStyle().Width(doctorWidth).Render(issue.Detail) + "\n")
+		if issue.Fixable() {
+			s.WriteString(ui.DescriptionStyle.Padding(0).Render(ui.GlobalKeyMap.Select.Help().Key+" will "+issue.Fix) + "\n")
+		} else if issue.VideoID != "" {
+			s.WriteString(ui.DescriptionStyle.Padding(0).Render(ui.GlobalKeyMap.Select.Help().Key+" opens the log to fix it by hand") + "\n")
+		}
+	}
+
+	s.WriteString("\n" + m.help.View(DoctorKeyMap{}))
+	return s.String()
+}
@@ -0,0 +1,147 @@
+package views
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/help"
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/mamuzad/vidlogd/internal/models"
+	"github.com/mamuzad/vidlogd/internal/ui"
+)
+
+// error log layout
+const (
+	errorLogWidth = 64
+	errorLogRows  = 6
+)
+
+func init() {
+	ui.RegisterKeyScope("error log", "Up", "Down", "Delete", "Back")
+}
+
+type ErrorLogKeyMap struct{}
+
+func (k ErrorLogKeyMap) ShortHelp() []key.Binding {
+	return []key.Binding{
+		ui.GlobalKeyMap.Up,
+		ui.GlobalKeyMap.Down,
+		ui.Relabel(ui.GlobalKeyMap.Delete, "clear log"),
+		ui.GlobalKeyMap.Back,
+	}
+}
+
+func (k ErrorLogKeyMap) FullHelp() [][]key.Binding {
+	return [][]key.Binding{k.ShortHelp()}
+}
+
+// errorLogLoadedMsg carries the logged errors, oldest first
+type errorLogLoadedMsg struct {
+	entries []models.ErrorEntry
+	err     error
+}
+
+// ErrorLogModel lists the errors reported to the user, newest first
+type ErrorLogModel struct {
+	entries []models.ErrorEntry
+	cursor  int
+	help    help.Model
+	err     error
+}
+
+func NewErrorLogModel() ErrorLogModel {
+	h := ui.NewHelp()
+	h.ShowAll = false
+	return ErrorLogModel{help: h}
+}
+
+// RefreshStyles rebuilds the help, whose separators follow the symbols mode
+func (m *ErrorLogModel) RefreshStyles() {
+	showAll := m.help.ShowAll
+	m.help = ui.NewHelp()
+	m.help.ShowAll = showAll
+}
+
+func (m ErrorLogModel) Init() tea.Cmd {
+	return loadErrorLog
+}
+
+func loadErrorLog() tea.Msg {
+	entries, err := models.LoadErrorLog()
+	return errorLogLoadedMsg{entries: entries, err: err}
+}
+
+func (m ErrorLogModel) Update(msg tea.Msg) (ErrorLogModel, tea.Cmd) {
+	switch msg := msg.(type) {
+	case errorLogLoadedMsg:
+		m.err = msg.err
+		// newest first
+		m.entries = make([]models.ErrorEntry, len(msg.entries))
+		for i, entry := range msg.entries {
+			m.entries[len(msg.entries)-1-i] = entry
+		}
+		m.cursor = min(m.cursor, max(0, len(m.entries)-1))
+		return m, nil
+	}
+
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return m, nil
+	}
+
+	switch {
+	case key.Matches(keyMsg, ui.GlobalKeyMap.Back, ui.GlobalKeyMap.Cancel):
+		return m, func() tea.Msg { return ui.BackMsg{} }
+	case key.Matches(keyMsg, ui.GlobalKeyMap.Up):
+		if m.cursor > 0 {
+			m.cursor--
+		}
+	case key.Matches(keyMsg, ui.GlobalKeyMap.Down):
+		if m.cursor < len(m.entries)-1 {
+			m.cursor++
+		}
+	case key.Matches(keyMsg, ui.GlobalKeyMap.Delete):
+		return m, func() tea.Msg {
+			if err := models.ClearErrorLog(); err != nil {
+				return ui.ErrorMsg{Context: "clear error log", Err: err}
+			}
+			return loadErrorLog()
+		}
+	}
+	return m, nil
+}
+
+func (m ErrorLogModel) View() string {
+	var s strings.Builder
+	s.WriteString(ui.HeaderStyle.Render("error log") + "\n\n")
+
+	switch {
+	case m.err != nil:
+		s.WriteString("could not read the error log: " + m.err.Error() + "\n")
+	case len(m.entries) == 0:
+		s.WriteString("no errors logged\n")
+	default:
+		dim := lipgloss.NewStyle().Foreground(ui.Gray)
+		offset := max(0, min(m.cursor-errorLogRows+1, len(m.entries)-errorLogRows))
+		for i := offset; i < min(len(m.entries), offset+errorLogRows); i++ {
+			entry := m.entries[i]
+			line := fmt.Sprintf("%s  %s", entry.Time.Local().Format("2006-01-02 15:04"), entry.Context)
+			s.WriteString(ui.RenderItem(line, i == m.cursor))
+			s.WriteString("\n")
+		}
+		if len(m.entries) > errorLogRows {
+			s.WriteString(ui.DescriptionStyle.Render(fmt.Sprintf("%d-%d of %d", offset+1, min(len(m.entries), offset+errorLogRows), len(m.entries))) + "\n")
+		}
+
+		entry := m.entries[m.cursor]
+		s.WriteString("\n" + lipgloss.NewStyle().Foreground(ui.DangerColor).Width(errorLogWidth).Render(entry.Message) + "\n")
+		if entry.Profile != "" {
+			s.WriteString(dim.Render("profile "+entry.Profile) + "\n")
+		}
+	}
+
+	s.WriteString("\n" + m.help.View(ErrorLogKeyMap{}))
+	return s.String()
+}
@@ -0,0 +1,213 @@
+package views
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/mamuzad/vidlogd/internal/models"
+	"github.com/mamuzad/vidlogd/internal/query"
+	"github.com/mamuzad/vidlogd/internal/ui"
+)
+
+// FiltersLoadedMsg carries the saved filters, shared by the log list and stats
+type FiltersLoadedMsg struct {
+	filters []models.SavedFilter
+	active  string // filter to select, if any
+	err     error
+}
+
+func loadFilters() tea.Msg {
+	filters, err := models.LoadFilters()
+	return FiltersLoadedMsg{filters: filters, err: err}
+}
+
+// filterTabs tracks the saved filters and which one is applied.
+// Tab 0 is "all", tab i is filters[i-1].
+type filterTabs struct {
+	filters []models.SavedFilter
+	active  int
+}
+
+// set replaces the filters, keeping the active tab by name when possible
+func (t *filterTabs) set(filters []models.SavedFilter, selectName string) {
+	if selectName == "" {
+		if f, ok := t.current(); ok {
+			selectName = f.Name
+		}
+	}
+
+	t.filters = filters
+	t.active = 0
+	for i, f := range filters {
+		if strings.EqualFold(f.Name, selectName) {
+			t.active = i + 1
+			break
+		}
+	}
+}
+
+func (t filterTabs) current() (models.SavedFilter, bool) {
+	if t.active <= 0 || t.active > len(t.filters) {
+		return models.SavedFilter{}, false
+	}
+	return t.filters[t.active-1], true
+}
+
+func (t *filterTabs) cycle(forward bool) {
+	n := len(t.filters) + 1
+	if forward {
+		t.active = (t.active + 1) % n
+	} else {
+		t.active = (t.active + n - 1) % n
+	}
+}
+
+// apply narrows videos to the active filter, best matches first
+func (t filterTabs) apply(videos []models.Video) []models.Video {
+	f, ok := t.current()
+	if !ok {
+		return videos
+	}
+
+	q, err := query.Parse(f.QueryString())
+	if err != nil {
+		return nil
+	}
+
+	results := query.Search(videos, q)
+	filtered := make([]models.Video, len(results))
+	for i, result := range results {
+		filtered[i] = result.Video
+	}
+	return filtered
+}
+
+// View renders the tab row, or nothing when no filters are saved
+func (t filterTabs) View() string {
+	if len(t.filters) == 0 {
+		return ""
+	}
+
+	tab := lipgloss.NewStyle().Padding(0, 1).Foreground(ui.Gray)
+	activeTab := ui.TableSelectedRowStyle.Padding(0, 1)
+
+	names := append([]string{"all"}, make([]string, len(t.filters))...)
+	for i, f := range t.filters {
+		names[i+1] = f.Name
+	}
+
+	tabs := make([]string, len(names))
+	for i, name := range names {
+		if i == t.active {
+			tabs[i] = activeTab.Render(name)
+		} else {
+			tabs[i] = tab.Render(name)
+		}
+	}
+	return lipgloss.JoinHorizontal(lipgloss.Top, tabs...)
+}
+
+// filter form field order, see filterFromForm
+func filterFields(f models.SavedFilter) []FormField {
+	rating := func(r float64) string {
+		if r == 0 {
+			return ""
+		}
+		return strconv.FormatFloat(r, 'g', -1, 64)
+	}
+
+	return []FormField{
+		{Placeholder: "favorites", Label: "Name:", Required: true, CharLimit: 30, Width: 34, Type: FormFieldText, Value: f.Name},
+		{Placeholder: `"goroutine" -shorts`, Label: "Search:", Required: false, CharLimit: 200, Width: 60, Type: FormFieldText, Value: f.Query},
+		{Placeholder: "any channel", Label: "Channel:", Required: false, CharLimit: 100, Width: 60, Type: FormFieldText, Value: f.Channel},
+		{Placeholder: "0.5-5", Label: "Min Rating:", Required: false, CharLimit: 3, Width: 17, Type: FormFieldText, Value: rating(f.MinRating), SideBySide: true},
+		{Placeholder: "0.5-5", Label: "Max Rating:", Required: false, CharLimit: 3, Width: 17, Type: FormFieldText, Value: rating(f.MaxRating), SideBySide: true},
+		{Placeholder: "YYYY-MM-DD", Label: "Logged From:", Required: false, CharLimit: 10, Width: 17, Type: FormFieldDate, Value: f.From, SideBySide: true},
+		{Placeholder: "YYYY-MM-DD", Label: "Logged To:", Required: false, CharLimit: 10, Width: 17, Type: FormFieldDate, Value: f.To, SideBySide: true},
+		{Placeholder: "yes, no or blank for either", Label: "Rewatched:", Required: false, CharLimit: 3, Width: 34, Type: FormFieldText, Value: f.Rewatched},
+	}
+}
+
+// filterFromForm reads a saved filter back out of the filter form
+func filterFromForm(form FormModel) (models.SavedFilter, error) {
+	values := form.AllValues()
+	for i := range values {
+		values[i] = strings.TrimSpace(values[i])
+	}
+
+	f := models.SavedFilter{
+		Name:      values[0],
+		Query:     values[1],
+		Channel:   values[2],
+		From:      values[5],
+		To:        values[6],
+		Rewatched: strings.ToLower(values[7]),
+	}
+
+	var err error
+	if f.MinRating, err = parseFilterRating("min rating", values[3]); err != nil {
+		return f, err
+	}
+	if f.MaxRating, err = parseFilterRating("max rating", values[4]); err != nil {
+		return f, err
+	}
+	if f.MaxRating > 0 && f.MinRating > f.MaxRating {
+		return f, fmt.Errorf("min rating is above max rating")
+	}
+	switch f.Rewatched {
+	case "", "yes", "no":
+	default:
+		return f, fmt.Errorf("rewatched must be yes, no or blank")
+	}
+
+	if _, err := query.Parse(f.QueryString()); err != nil {
+		return f, err
+	}
+	return f, nil
+}
+
+func parseFilterRating(name, value string) (float64, error) {
+	if value == "" {
+		return 0, nil
+	}
+	r, err := strconv.ParseFloat(value, 64)
+	if err != nil || !models.IsValidRating(r) || r == 0 {
+		return 0, fmt.Errorf("%s must be 0.5 to 5 in 0.5 steps", name)
+	}
+	return r, nil
+}
+
+// newFilterForm opens the save filter form; saving upserts by name and
+// reports back with a FiltersLoadedMsg
+func newFilterForm(f models.SavedFilter, onCancel func() tea.Cmd) FormModel {
+	form := NewForm("save filter", filterFields(f), "save")
+	form.SetHandlers(
+		func(form FormModel) tea.Cmd {
+			f, err := filterFromForm(form)
+			if err != nil {
+				return func() tea.Msg { return filterFormErrMsg{err: err} }
+			}
+			return func() tea.Msg {
+				filters, err := models.UpsertFilter(f)
+				return FiltersLoadedMsg{filters: filters, active: f.Name, err: err}
+			}
+		},
+		onCancel,
+	)
+	return form
+}
+
+// filterFormErrMsg reports a filter that could not be saved
+type filterFormErrMsg struct {
+	err error
+}
+
+func deleteFilter(name string) tea.Cmd {
+	return func() tea.Msg {
+		filters, err := models.DeleteFilter(name)
+		return FiltersLoadedMsg{filters: filters, err: err}
+	}
+}
@@ -2,7 +2,6 @@ package views
 
 import (
 	"fmt"
-	"regexp"
 	"strconv"
 	"strings"
 	"time"
@@ -14,6 +13,7 @@ import (
 	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/mamuzad/vidlogd/internal/dedupe"
 	"github.com/mamuzad/vidlogd/internal/models"
 	"github.com/mamuzad/vidlogd/internal/services"
 	"github.com/mamuzad/vidlogd/internal/ui"
@@ -64,17 +64,29 @@ type FormModel struct {
 	onSave         func(FormModel) tea.Cmd
 	onCancel       func() tea.Cmd
 	lastURL        string
-	ratingValue    float64 // current rating value for the rating field
+	ratingValue    float64       // current rating value for the rating field
+	savedRating    float64       // rating the form opened with
+	duration       int           // seconds, from fetched metadata
+	channelID      string        // YouTube channel ID, from fetched metadata
+	editingID      string        // video being edited, skipped when looking for duplicates
+	duplicate      *models.Video // already logged entry for the url, if any
 	help           help.Model
 	renderedFields map[int]bool // track which fields have been rendered (for side by side)
 	// vim mode support
 	vimMode string
 }
 
+func init() {
+	ui.RegisterKeyScope("log form", "NextField", "PrevField", "Select", "Save", "Cancel", "LogRewatch", "OpenExisting",
+		"InsertMode", "NormalMode", "VisualMode", "Paste", "Yank")
+	ui.RegisterKeyScope("rating field", "NextField", "PrevField", "Save", "Cancel", "RatingUp", "RatingDown", "Rating", "RatingHalf")
+}
+
 // FormKeyMap implements help.KeyMap for the form
 type FormKeyMap struct {
-	onRating bool
-	vimMode  string
+	onRating  bool
+	vimMode   string
+	duplicate bool
 }
 
 func (k FormKeyMap) ShortHelp() []key.Binding {
@@ -84,6 +96,9 @@ func (k FormKeyMap) ShortHelp() []key.Binding {
 		ui.GlobalKeyMap.Select,
 		ui.GlobalKeyMap.Help,
 	}
+	if k.duplicate {
+		keys = append(keys, ui.GlobalKeyMap.LogRewatch, ui.GlobalKeyMap.OpenExisting)
+	}
 
 	return keys
 }
@@ -155,7 +170,7 @@ func NewForm(title string, fields []FormField, saveText string) FormModel {
 		inputs[i] = input
 	}
 
-	h := help.New()
+	h := ui.NewHelp()
 	h.ShowAll = false // start with compact help
 
 	// start in insert mode for non-vim, normal mode for vim
@@ -219,6 +234,14 @@ func NewVideoLogForm(editing bool, existingVideo *models.Video) FormModel {
 
 	form := NewForm(formTitle, fields, buttonText)
 	form.ratingValue = ratingValue
+	form.savedRating = ratingValue
+	if existingVideo != nil {
+		form.duration = existingVideo.Duration
+		form.channelID = existingVideo.ChannelID
+	}
+	if editing && existingVideo != nil {
+		form.editingID = existingVideo.ID
+	}
 
 	// store original URL when editing to prevent auto-fill for same video
 	if editing && existingVideo != nil {
@@ -277,6 +300,60 @@ func (m FormModel) Rating() float64 {
 	return m.ratingValue
 }
 
+// Dirty reports whether any field differs from the value the form opened
+// with
+func (m FormModel) Dirty() bool {
+	if m.ratingValue != m.savedRating {
+		return true
+	}
+	for i, input := range m.inputs {
+		value, initial := input.Value(), m.fields[i].Value
+		if m.fields[i].Type == FormFieldCheckbox {
+			// an unset checkbox reads as unchecked
+			value, initial = strconv.FormatBool(value == "true"), strconv.FormatBool(initial == "true")
+		}
+		if value != initial {
+			return true
+		}
+	}
+	return false
+}
+
+// SetError shows a form level error under the save button
+func (m *FormModel) SetError(msg string) {
+	m.fieldErrors[button] = msg
+}
+
+// focusedType reports whether the focused field is of the given type
+func (m FormModel) focusedType(fieldType FieldType) bool {
+	return m.focused < len(m.fields) && m.fields[m.focused].Type == fieldType
+}
+
+// duplicateCheckedMsg reports the entry already logged for url, if any
+type duplicateCheckedMsg struct {
+	url   string
+	video *models.Video
+}
+
+// checkDuplicate looks for videoURL in the log, skipping the video being edited
+func checkDuplicate(videoURL, editingID string) tea.Cmd {
+	return func() tea.Msg {
+		videos, err := models.LoadVideos()
+		if err != nil {
+			return duplicateCheckedMsg{url: videoURL}
+		}
+		if video, ok := dedupe.Existing(videos, videoURL, editingID); ok {
+			return duplicateCheckedMsg{url: videoURL, video: &video}
+		}
+		return duplicateCheckedMsg{url: videoURL}
+	}
+}
+
+// showDuplicate reports whether to warn that the url is already logged
+func (m FormModel) showDuplicate() bool {
+	return m.duplicate != nil && len(m.inputs) > rewatch && m.inputs[rewatch].Value() != "true"
+}
+
 func (m FormModel) Init() tea.Cmd {
 	return textinput.Blink
 }
@@ -311,6 +388,8 @@ func (m FormModel) Update(msg tea.Msg) (FormModel, tea.Cmd) {
 					m.inputs[channel].CursorStart()
 				}
 			}
+			m.duration = msg.Metadata.Duration
+			m.channelID = msg.Metadata.ChannelID
 			if msg.Metadata.ReleaseDate != "" && len(m.inputs) > release {
 				m.inputs[release].SetValue(msg.Metadata.ReleaseDate)
 				m.fieldErrors[release] = ""
@@ -320,6 +399,14 @@ func (m FormModel) Update(msg tea.Msg) (FormModel, tea.Cmd) {
 				m.inputs[logDate].SetValue(currentDate)
 				m.fieldErrors[logDate] = ""
 			}
+			return m, ui.Notify("metadata fetched")
+		}
+		return m, nil
+
+	case duplicateCheckedMsg:
+		// the url may have changed while checking
+		if len(m.inputs) > url && msg.url == m.inputs[url].Value() {
+			m.duplicate = msg.video
 		}
 		return m, nil
 
@@ -328,6 +415,18 @@ func (m FormModel) Update(msg tea.Msg) (FormModel, tea.Cmd) {
 		case key.Matches(msg, ui.GlobalKeyMap.Help):
 			m.help.ShowAll = !m.help.ShowAll
 			return m, nil
+		case key.Matches(msg, ui.GlobalKeyMap.LogRewatch):
+			if m.showDuplicate() {
+				m.inputs[rewatch].SetValue("true")
+				return m, nil
+			}
+		case key.Matches(msg, ui.GlobalKeyMap.OpenExisting):
+			if m.showDuplicate() {
+				id := m.duplicate.ID
+				return m, func() tea.Msg {
+					return ui.NavigateMsg{View: ui.LogDetailsView, State: ui.VideoRouteState{VideoID: id}}
+				}
+			}
 		case key.Matches(msg, ui.GlobalKeyMap.Back, ui.GlobalKeyMap.Cancel):
 			shouldCancel := false
 			if !Settings.VimMotions {
@@ -403,28 +502,28 @@ func (m FormModel) Update(msg tea.Msg) (FormModel, tea.Cmd) {
 				m.nextInput()
 			}
 		case key.Matches(msg, ui.GlobalKeyMap.RatingDown):
-			if m.focused == rating {
+			if m.focusedType(FormFieldRating) {
 				if m.ratingValue > 0 {
 					m.ratingValue -= 0.5
 				}
 				return m, nil
 			}
 		case key.Matches(msg, ui.GlobalKeyMap.RatingUp):
-			if m.focused == rating {
+			if m.focusedType(FormFieldRating) {
 				if m.ratingValue < 5 {
 					m.ratingValue += 0.5
 				}
 				return m, nil
 			}
 		case key.Matches(msg, ui.GlobalKeyMap.Rating):
-			if m.focused == rating {
+			if m.focusedType(FormFieldRating) {
 				ratingStr := msg.String()
 				rating, _ := strconv.ParseFloat(ratingStr, 64)
 				m.ratingValue = rating
 				return m, nil
 			}
 		case key.Matches(msg, ui.GlobalKeyMap.RatingHalf):
-			if m.focused == rating {
+			if m.focusedType(FormFieldRating) {
 				// add 0.5 to current rating if it's a whole number
 				if m.ratingValue == float64(int(m.ratingValue)) && m.ratingValue < 5 {
 					m.ratingValue += 0.5
@@ -445,12 +544,15 @@ func (m FormModel) Update(msg tea.Msg) (FormModel, tea.Cmd) {
 	}
 
 	// check if URL and auto-fill metadata - regardless of vim mode
-	if m.focused == url && len(m.inputs) > url {
-		currentURL := m.inputs[url].Value()
+	if m.focusedType(FormFieldURL) {
+		currentURL := m.inputs[m.focused].Value()
+		if m.duplicate != nil && dedupe.Key(models.Video{URL: currentURL}) != dedupe.Key(*m.duplicate) {
+			m.duplicate = nil
+		}
 		if currentURL != m.lastURL && services.IsValidYouTubeURL(currentURL) {
 			m.lastURL = currentURL
-			// auto-fill metadata in background
-			cmds = append(cmds, services.FetchYouTubeMetadata(currentURL))
+			// auto-fill metadata and look for an earlier log in background
+			cmds = append(cmds, services.FetchYouTubeMetadata(currentURL), checkDuplicate(currentURL, m.editingID))
 		}
 	}
 
@@ -514,6 +616,9 @@ func (m FormModel) View() string {
 
 		s.WriteString(m.renderField(i))
 		s.WriteString("\n")
+		if i == url && m.showDuplicate() {
+			s.WriteString(m.renderDuplicate() + "\n")
+		}
 	}
 
 	// save button
@@ -524,19 +629,37 @@ func (m FormModel) View() string {
 	}
 
 	if m.fieldErrors[button] != "" {
-		s.WriteString("\n   " + m.fieldErrors[button])
+		s.WriteString("\n " + ui.Icon(ui.Symbols.ErrorIcon, "  ") + m.fieldErrors[button])
 	}
 
-	keymap := FormKeyMap{onRating: m.focused == rating, vimMode: m.vimMode}
+	keymap := FormKeyMap{onRating: m.focusedType(FormFieldRating), vimMode: m.vimMode, duplicate: m.showDuplicate()}
 	s.WriteString("\n\n" + m.help.View(keymap))
 
 	return s.String()
 }
 
+// renderDuplicate warns that the url is already logged and how to proceed
+func (m FormModel) renderDuplicate() string {
+	logged := "before"
+	if !m.duplicate.LogDate.IsZero() {
+		logged = "on " + m.duplicate.LogDate.Format(models.ISODateFormat)
+	}
+	sep := ui.Symbols.Separator
+	warning := fmt.Sprintf("%s already logged %s %s %s %s %s %s %s", ui.Symbols.Warning, logged, sep,
+		ui.GlobalKeyMap.LogRewatch.Help().Key, ui.GlobalKeyMap.LogRewatch.Help().Desc, sep,
+		ui.GlobalKeyMap.OpenExisting.Help().Key, ui.GlobalKeyMap.OpenExisting.Help().Desc)
+	return lipgloss.NewStyle().Foreground(ui.DangerColor).PaddingLeft(3).Render(warning)
+}
+
 // renderRatingStars renders the star rating display
 func (m FormModel) renderRatingStars(focused bool) string {
 	var s strings.Builder
 	s.WriteString(" ")
+	if ui.ASCII {
+		// the rating as text in place of the stars, padded to the same width
+		s.WriteString(ui.StarStyle.Render(fmt.Sprintf("%-19s", models.RatingText(m.ratingValue))))
+		return m.styleRatingField(s.String(), focused)
+	}
 	// render 5 stars
 	for i := 1; i <= 5; i++ {
 		starValue := float64(i)
@@ -566,13 +689,15 @@ func (m FormModel) renderRatingStars(focused bool) string {
 		}
 	}
 
-	var styledRating string
+	return m.styleRatingField(s.String(), focused)
+}
+
+// styleRatingField boxes the rating like the other fields
+func (m FormModel) styleRatingField(rating string, focused bool) string {
 	if focused {
-		styledRating = ui.FormFieldFocusedStyle.Render(s.String())
-	} else {
-		styledRating = ui.FormFieldStyle.Render(s.String())
+		return ui.FormFieldFocusedStyle.Render(rating)
 	}
-	return styledRating
+	return ui.FormFieldStyle.Render(rating)
 }
 
 // nextInput moves focus to the next input
@@ -646,11 +771,11 @@ func (m *FormModel) validateFieldByIndex(index int) string {
 	// validate based on field type
 	switch field.Type {
 	case FormFieldDate:
-		if !isValidDate(value) {
+		if !models.IsValidDate(value) {
 			errorMsg = "invalid date"
 		}
 	case FormFieldDateHour:
-		if !isValidDateHour(value) {
+		if !models.IsValidDateTime(value) {
 			errorMsg = "invalid datetime"
 		}
 	case FormFieldURL:
@@ -665,40 +790,6 @@ func (m *FormModel) validateFieldByIndex(index int) string {
 	return errorMsg
 }
 
-// isValidDateHour validates if the string is a valid date in YYYY-MM-DD HH:MM AM/PM format
-func isValidDateHour(dateStr string) bool {
-	if dateStr == "" {
-		return false
-	}
-
-	// check format with regex first - supports both 12:34 AM and 1:23 PM formats
-	dateRegex := regexp.MustCompile(`^\d{4}-\d{2}-\d{2} \d{1,2}:\d{2} (AM|PM)$`)
-	if !dateRegex.MatchString(dateStr) {
-		return false
-	}
-
-	// try to parse the date to ensure it's actually valid
-	_, err := time.Parse(models.DateTimeFormat, dateStr)
-	return err == nil
-}
-
-// isValidDate validates if the string is a valid date in YYYY-MM-DD format
-func isValidDate(dateStr string) bool {
-	if dateStr == "" {
-		return false
-	}
-
-	// check format with regex first
-	dateRegex := regexp.MustCompile(`^\d{4}-\d{2}-\d{2}$`)
-	if !dateRegex.MatchString(dateStr) {
-		return false
-	}
-
-	// try to parse the date to ensure it's actually valid
-	_, err := time.Parse(models.ISODateFormat, dateStr)
-	return err == nil
-}
-
 func (m FormModel) handleSave() (FormModel, tea.Cmd) {
 	hasErrors := false
 
@@ -760,11 +851,9 @@ func (m FormModel) renderField(i int) string {
 	case FormFieldCheckbox:
 		// render checkbox
 		checked := m.inputs[i].Value() == "true"
-		var checkbox string
+		checkbox := "   "
 		if checked {
-			checkbox = " ✓ "
-		} else {
-			checkbox = "   "
+			checkbox = " " + ui.Symbols.Check + " "
 		}
 		if m.focused == i {
 			checkbox = ui.FormFieldFocusedStyle.Render(checkbox)
@@ -784,14 +873,14 @@ func (m FormModel) renderField(i int) string {
 
 	// show field-specific error if field has been touched and has an error
 	if m.touched[i] && m.fieldErrors[i] != "" {
-		s.WriteString("\n   " + m.fieldErrors[i])
+		s.WriteString("\n " + ui.Icon(ui.Symbols.ErrorIcon, "  ") + m.fieldErrors[i])
 	}
 
 	return s.String()
 }
 
-func (form FormModel) Video() models.Video {
-	return models.CreateVideo(
+func (form FormModel) Video() (models.Video, error) {
+	video, err := models.CreateVideo(
 		form.Value(url),
 		form.Value(title),
 		form.Value(channel),
@@ -801,4 +890,10 @@ func (form FormModel) Video() models.Video {
 		form.Value(rewatch) == "true",
 		form.Rating(),
 	)
+	if err != nil {
+		return models.Video{}, err
+	}
+	video.Duration = form.duration
+	video.ChannelID = form.channelID
+	return video, nil
 }
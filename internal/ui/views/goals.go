@@ -0,0 +1,299 @@
+package views
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/mamuzad/vidlogd/internal/analytics"
+	"github.com/mamuzad/vidlogd/internal/models"
+	"github.com/mamuzad/vidlogd/internal/ui"
+)
+
+// goals layout
+const (
+	goalCards   = 4  // goal cards on the dashboard
+	goalHistory = 12 // periods shown per goal
+	goalRows    = 4  // goals shown at once on the goals screen
+)
+
+// goalsLoadedMsg carries the viewing goals after loading or saving them
+type goalsLoadedMsg struct {
+	goals []models.Goal
+	err   error
+}
+
+// goalFormErrMsg reports a goal that could not be saved
+type goalFormErrMsg struct {
+	err error
+}
+
+type goalFormClosedMsg struct{}
+
+func loadGoals() tea.Msg {
+	goals, err := models.LoadGoals()
+	return goalsLoadedMsg{goals: goals, err: err}
+}
+
+// trackGoals measures every goal against the whole log; goals ignore the
+// dashboard filters
+func (m StatsModel) trackGoals() []analytics.GoalProgress {
+	progress := make([]analytics.GoalProgress, len(m.goals))
+	for i, goal := range m.goals {
+		progress[i] = analytics.TrackGoal(m.videos, goal, m.clock(), goalHistory)
+	}
+	return progress
+}
+
+// updateGoals handles keys while the goals are shown
+func (m StatsModel) updateGoals(msg tea.KeyMsg) (StatsModel, tea.Cmd) {
+	switch {
+	case key.Matches(msg, ui.GlobalKeyMap.Up):
+		m.goalCursor = max(0, m.goalCursor-1)
+	case key.Matches(msg, ui.GlobalKeyMap.Down):
+		m.goalCursor = max(0, min(m.goalCursor+1, len(m.goals)-1))
+	case key.Matches(msg, ui.GlobalKeyMap.NewGoal):
+		m.openGoalForm(models.Goal{Kind: models.GoalAtLeast, Metric: models.GoalVideos, Period: "week"}, "")
+	case key.Matches(msg, ui.GlobalKeyMap.Edit, ui.GlobalKeyMap.Select):
+		if m.goalCursor < len(m.goals) {
+			goal := m.goals[m.goalCursor]
+			m.openGoalForm(goal, goal.Name)
+		}
+	case key.Matches(msg, ui.GlobalKeyMap.Delete):
+		if m.goalCursor < len(m.goals) {
+			return m, deleteGoal(m.goals[m.goalCursor].Name)
+		}
+	case key.Matches(msg, ui.GlobalKeyMap.Goals, ui.GlobalKeyMap.Back, ui.GlobalKeyMap.Cancel, ui.GlobalKeyMap.SearchBack):
+		m.goalsOpen = false
+		m.goalsErr = ""
+	}
+	return m, nil
+}
+
+// goal form field order, see goalFromForm
+func goalFields(g models.Goal) []FormField {
+	target := ""
+	if g.Target > 0 {
+		target = strconv.FormatFloat(g.Target, 'g', -1, 64)
+	}
+	return []FormField{
+		{Placeholder: "learning", Label: "Name:", Required: true, CharLimit: 30, Width: 34, Type: FormFieldText, Value: g.Name},
+		{Placeholder: "at least/at most", Label: "Goal:", Required: true, CharLimit: 8, Width: 17, Type: FormFieldText, Value: strings.ReplaceAll(g.Kind, "_", " "), SideBySide: true},
+		{Placeholder: "3", Label: "Target:", Required: true, CharLimit: 5, Width: 17, Type: FormFieldText, Value: target, SideBySide: true},
+		{Placeholder: "videos/hours", Label: "Count:", Required: true, CharLimit: 6, Width: 17, Type: FormFieldText, Value: g.Metric, SideBySide: true},
+		{Placeholder: "day/week/month", Label: "Per:", Required: true, CharLimit: 5, Width: 17, Type: FormFieldText, Value: g.Period, SideBySide: true},
+		{Placeholder: "any tag", Label: "Tag:", Required: false, CharLimit: 50, Width: 34, Type: FormFieldText, Value: g.Tag},
+	}
+}
+
+// goalFromForm reads a goal back out of the goal form
+func goalFromForm(form FormModel) (models.Goal, error) {
+	values := form.AllValues()
+	for i := range values {
+		values[i] = strings.TrimSpace(values[i])
+	}
+
+	g := models.Goal{
+		Name:   values[0],
+		Kind:   strings.ReplaceAll(strings.ToLower(values[1]), " ", "_"),
+		Metric: strings.ToLower(values[3]),
+		Period: strings.ToLower(values[4]),
+		Tag:    strings.TrimPrefix(values[5], "#"),
+	}
+	target, err := strconv.ParseFloat(values[2], 64)
+	if err != nil {
+		return g, fmt.Errorf("target must be a number")
+	}
+	g.Target = target
+	return g, g.Validate()
+}
+
+// openGoalForm adds a goal, or edits the goal called previous
+func (m *StatsModel) openGoalForm(g models.Goal, previous string) {
+	title := "new goal"
+	if previous != "" {
+		title = "edit goal"
+	}
+	form := NewForm(title, goalFields(g), "save")
+	form.SetHandlers(
+		func(form FormModel) tea.Cmd {
+			g, err := goalFromForm(form)
+			if err != nil {
+				return func() tea.Msg { return goalFormErrMsg{err: err} }
+			}
+			return func() tea.Msg {
+				goals, err := models.UpsertGoal(previous, g)
+				return goalsLoadedMsg{goals: goals, err: err}
+			}
+		},
+		func() tea.Cmd {
+			return func() tea.Msg { return goalFormClosedMsg{} }
+		},
+	)
+	m.goalForm = &form
+}
+
+func deleteGoal(name string) tea.Cmd {
+	return func() tea.Msg {
+		goals, err := models.DeleteGoal(name)
+		return goalsLoadedMsg{goals: goals, err: err}
+	}
+}
+
+// periodName names the current goal period
+func periodName(period string) string {
+	if period == "day" {
+		return "today"
+	}
+	return "this " + period
+}
+
+// goalValue formats a goal's progress like 12/20 or 1.5/2h
+func goalValue(p analytics.GoalProgress, value float64) string {
+	if p.Goal.Metric == models.GoalHours {
+		return fmt.Sprintf("%s/%sh", strconv.FormatFloat(roundTenth(value), 'f', -1, 64), strconv.FormatFloat(p.Goal.Target, 'g', -1, 64))
+	}
+	return fmt.Sprintf("%.0f/%s", value, strconv.FormatFloat(p.Goal.Target, 'g', -1, 64))
+}
+
+func roundTenth(v float64) float64 {
+	return float64(int(v*10+0.5)) / 10
+}
+
+// goalStatus sums up the current period, warning when a limit is exceeded.
+// Long statuses spell out the warning.
+func goalStatus(p analytics.GoalProgress, width int, long bool) string {
+	status := goalValue(p, p.Current().Value) + " " + periodName(p.Goal.Period)
+	switch {
+	case p.Exceeded():
+		if long {
+			status += ", over limit"
+		}
+		return lipgloss.NewStyle().Foreground(ui.DangerColor).Render(ui.Symbols.Warning + " " + truncateString(status, width-2))
+	case !p.Goal.IsLimit() && p.Met(p.Current()):
+		return ui.Symbols.Check + " " + truncateString(status, width-2)
+	}
+	return truncateString(status, width)
+}
+
+// renderGoalCards draws a card per goal beside the streak cards' width
+func (m StatsModel) renderGoalCards(progress []analytics.GoalProgress) string {
+	if len(progress) == 0 {
+		return ""
+	}
+	shown := progress[:min(goalCards, len(progress))]
+
+	cardStyle := lipgloss.NewStyle().
+		Border(ui.Border()).
+		Padding(0, 1).
+		Height(2)
+
+	// same total width as the other card rows
+	total := m.boxWidth() + 2 - 2*len(shown)
+	cards := make([]string, len(shown))
+	for i, p := range shown {
+		width := total / len(shown)
+		if i == len(shown)-1 {
+			width = total - width*(len(shown)-1)
+		}
+		style := cardStyle.Width(width)
+		if p.Exceeded() {
+			style = style.BorderForeground(ui.DangerColor)
+		}
+		// truncateString adds an ellipsis past its limit
+		name := truncateString(p.Goal.Name, width-3)
+		if more := len(progress) - len(shown); more > 0 && i == len(shown)-1 {
+			name = truncateString(p.Goal.Name, width-7) + fmt.Sprintf(" +%d", more)
+		}
+		cards[i] = style.Render(name + "\n" + goalStatus(p, width-3, false))
+	}
+	return lipgloss.JoinHorizontal(lipgloss.Top, cards...)
+}
+
+// renderGoals draws each goal's history in place of the charts
+func (m StatsModel) renderGoals() string {
+	boxStyle := lipgloss.NewStyle().
+		Border(ui.Border()).
+		BorderForeground(ui.PrimaryColor).
+		Padding(0, 1).
+		Margin(1, 0).
+		Width(m.boxWidth())
+
+	heading := lipgloss.NewStyle().Foreground(ui.PrimaryColor).Bold(true)
+	met := lipgloss.NewStyle().Foreground(ui.PrimaryColor)
+	missed := lipgloss.NewStyle().Foreground(ui.Gray)
+	over := lipgloss.NewStyle().Foreground(ui.DangerColor)
+
+	var s strings.Builder
+	s.WriteString(" " + heading.Render("goals") + "\n")
+
+	if m.goalsErr != "" {
+		s.WriteString("\n" + over.Render(" "+m.goalsErr) + "\n")
+	}
+	if len(m.goals) == 0 {
+		s.WriteString("\n no goals yet, press " + ui.GlobalKeyMap.NewGoal.Help().Key + " to add one")
+		return boxStyle.Render(s.String()) + "\n"
+	}
+
+	progress := m.trackGoals()
+	offset := max(0, min(m.goalCursor-goalRows+1, len(progress)-goalRows))
+	for i := offset; i < min(len(progress), offset+goalRows); i++ {
+		p := progress[i]
+
+		name := ui.RenderRow(" "+p.Goal.Name+" ", i == m.goalCursor)
+		s.WriteString("\n" + name + ui.DescriptionStyle.Padding(0, 1).Render(p.Goal.String()) + "\n")
+
+		// one block per period, oldest first, the current one last. Without
+		// color missed periods are drawn empty and exceeded limits crossed out.
+		missedCell, overCell := ui.Symbols.Filled, ui.Symbols.Filled
+		if ui.NoColor {
+			missedCell, overCell = ui.Symbols.Empty, "x"
+		}
+		var history strings.Builder
+		for j, period := range p.Periods {
+			last := j == len(p.Periods)-1
+			switch {
+			case p.Goal.IsLimit() && !p.Met(period):
+				history.WriteString(over.Render(overCell))
+			case p.Met(period):
+				history.WriteString(met.Render(ui.Symbols.Filled))
+			case last:
+				history.WriteString(missed.Render(ui.Symbols.Hollow))
+			default:
+				history.WriteString(missed.Render(missedCell))
+			}
+		}
+		s.WriteString(" " + history.String() + "  " + goalStatus(p, m.contentWidth()-len(p.Periods)-6, true) + "\n")
+		s.WriteString(ui.DescriptionStyle.Render(fmt.Sprintf("streak %d %s met %d of the last %d %ss",
+			p.Streak(), ui.Symbols.Separator, p.Hits(), len(p.Periods)-1, p.Goal.Period)) + "\n")
+	}
+	if len(progress) > goalRows {
+		s.WriteString(ui.DescriptionStyle.Render(fmt.Sprintf("%d-%d of %d goals", offset+1, min(len(progress), offset+goalRows), len(progress))) + "\n")
+	}
+
+	return boxStyle.Render(strings.TrimRight(s.String(), "\n")) + "\n"
+}
+
+func init() {
+	ui.RegisterKeyScope("goals", "Up", "Down", "Select", "Edit", "Delete", "NewGoal", "Back")
+}
+
+type GoalsKeyMap struct{}
+
+func (k GoalsKeyMap) ShortHelp() []key.Binding {
+	return []key.Binding{
+		ui.GlobalKeyMap.Up,
+		ui.GlobalKeyMap.Down,
+		ui.GlobalKeyMap.NewGoal,
+		ui.GlobalKeyMap.Edit,
+		ui.GlobalKeyMap.Delete,
+		ui.GlobalKeyMap.Back,
+	}
+}
+
+func (k GoalsKeyMap) FullHelp() [][]key.Binding {
+	return [][]key.Binding{k.ShortHelp()}
+}
@@ -0,0 +1,192 @@
+package views
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/mamuzad/vidlogd/internal/analytics"
+	"github.com/mamuzad/vidlogd/internal/ui"
+)
+
+// heatmap weeks start on monday
+func weekdayRow(t time.Time) int {
+	return (int(t.Weekday()) + 6) % 7
+}
+
+// heatmapLevel buckets count into one of the ui.HeatmapLevels
+func heatmapLevel(count, busiest int) int {
+	if count == 0 || busiest == 0 {
+		return 0
+	}
+	levels := len(ui.HeatmapLevels) - 1
+	return max(1, (count*levels+busiest-1)/busiest)
+}
+
+// moveHeatmapDay moves the selected day, following it into other years
+func (m *StatsModel) moveHeatmapDay(days int) {
+	m.heatmapDay = m.heatmapDay.AddDate(0, 0, days)
+	m.refreshDayList()
+}
+
+func (m *StatsModel) moveHeatmapYear(years int) {
+	m.heatmapDay = m.heatmapDay.AddDate(years, 0, 0)
+	m.refreshDayList()
+}
+
+// refreshDayList fills the drill down list with the selected day's videos
+func (m *StatsModel) refreshDayList() {
+	videos := analytics.IndexDays(m.currentVideos())[m.heatmapDay]
+	items := make([]list.Item, len(videos))
+	for i, video := range videos {
+		items[i] = VideoItem{video: video}
+	}
+	m.dayList.SetItems(items)
+	m.dayList.Select(0)
+	if len(items) == 0 {
+		m.dayListOpen = false
+	}
+}
+
+// updateHeatmap handles keys while the calendar is focused
+func (m StatsModel) updateHeatmap(msg tea.KeyMsg) (StatsModel, tea.Cmd) {
+	if m.dayListOpen {
+		switch {
+		case key.Matches(msg, ui.GlobalKeyMap.Up), key.Matches(msg, ui.GlobalKeyMap.Down):
+			var cmd tea.Cmd
+			m.dayList, cmd = m.dayList.Update(msg)
+			return m, cmd
+		case key.Matches(msg, ui.GlobalKeyMap.Select):
+			if item, ok := m.dayList.SelectedItem().(VideoItem); ok {
+				return m, func() tea.Msg {
+					return ui.NavigateMsg{View: ui.LogDetailsView, State: ui.VideoRouteState{VideoID: item.video.ID}}
+				}
+			}
+		case key.Matches(msg, ui.GlobalKeyMap.Back, ui.GlobalKeyMap.Cancel, ui.GlobalKeyMap.SearchBack):
+			m.dayListOpen = false
+		}
+		return m, nil
+	}
+
+	switch {
+	case key.Matches(msg, ui.GlobalKeyMap.Left):
+		m.moveHeatmapDay(-7)
+	case key.Matches(msg, ui.GlobalKeyMap.Right):
+		m.moveHeatmapDay(7)
+	case key.Matches(msg, ui.GlobalKeyMap.Up):
+		m.moveHeatmapDay(-1)
+	case key.Matches(msg, ui.GlobalKeyMap.Down):
+		m.moveHeatmapDay(1)
+	case key.Matches(msg, ui.GlobalKeyMap.PrevPeriod):
+		m.moveHeatmapYear(-1)
+	case key.Matches(msg, ui.GlobalKeyMap.NextPeriod):
+		m.moveHeatmapYear(1)
+	case key.Matches(msg, ui.GlobalKeyMap.Select):
+		m.refreshDayList()
+		m.dayListOpen = len(m.dayList.Items()) > 0
+	case key.Matches(msg, ui.GlobalKeyMap.Back, ui.GlobalKeyMap.Cancel, ui.GlobalKeyMap.SearchBack):
+		m.heatmapActive = false
+	}
+	return m, nil
+}
+
+// renderHeatmap draws the selected day's year as a week by weekday grid
+func (m StatsModel) renderHeatmap(isFocused bool) string {
+	chartStyle := lipgloss.NewStyle().
+		Border(ui.Border()).
+		Padding(0, 1).
+		Margin(1, 0).
+		Width(m.boxWidth())
+
+	if isFocused {
+		chartStyle = chartStyle.BorderForeground(ui.PrimaryColor)
+	}
+
+	idx := analytics.IndexDays(m.currentVideos())
+	year := m.heatmapDay.Year()
+	busiest := idx.Busiest(year)
+
+	jan1 := time.Date(year, time.January, 1, 0, 0, 0, 0, time.Local)
+	start := jan1.AddDate(0, 0, -weekdayRow(jan1))
+	next := jan1.AddDate(1, 0, 0)
+	weeks := (analytics.DaysBetween(start, next) + 6) / 7
+
+	var chart strings.Builder
+	yearHelp := ui.GlobalKeyMap.PrevPeriod.Help().Key + " " + ui.GlobalKeyMap.NextPeriod.Help().Key
+	chart.WriteString(fmt.Sprintf(" Activity %d  %s\n\n", year, ui.DescriptionStyle.Render(yearHelp)))
+
+	// month labels over the week each month starts in
+	labels := []rune(strings.Repeat(" ", weeks))
+	for month := time.January; month <= time.December; month++ {
+		first := time.Date(year, month, 1, 0, 0, 0, 0, time.Local)
+		week := analytics.DaysBetween(start, first) / 7
+		name := []rune(first.Format("Jan"))
+		if week+len(name) <= len(labels) && (week == 0 || labels[week-1] == ' ') {
+			copy(labels[week:], name)
+		}
+	}
+	chart.WriteString(string(labels) + "\n")
+
+	selected := lipgloss.NewStyle().Foreground(ui.White).Background(ui.PrimaryBackground).Bold(true).Reverse(ui.NoColor)
+	for row := range 7 {
+		for week := range weeks {
+			day := start.AddDate(0, 0, week*7+row)
+			if day.Year() != year {
+				chart.WriteString(" ")
+				continue
+			}
+			count := len(idx[day])
+			level := heatmapLevel(count, busiest)
+			cell := heatCell(level)
+			if day.Equal(m.heatmapDay) {
+				chart.WriteString(selected.Render(cell))
+			} else {
+				chart.WriteString(ui.HeatmapLevels[level].Render(cell))
+			}
+		}
+		chart.WriteString("\n")
+	}
+
+	// legend and the selected day
+	chart.WriteString("\n less " + ui.HeatmapLevels[0].Render(heatCell(0)))
+	for level, style := range ui.HeatmapLevels[1:] {
+		chart.WriteString(style.Render(heatCell(level + 1)))
+	}
+	chart.WriteString(" more\n")
+
+	count := len(idx[m.heatmapDay])
+	noun := "videos"
+	if count == 1 {
+		noun = "video"
+	}
+	chart.WriteString(fmt.Sprintf(" %s: %d %s", m.heatmapDay.Format("Mon 2006-01-02"), count, noun))
+
+	switch {
+	case m.dayListOpen:
+		chart.WriteString("\n\n" + m.dayList.View())
+	case m.heatmapActive && count > 0:
+		chart.WriteString(ui.DescriptionStyle.Render("  (" + ui.GlobalKeyMap.Select.Help().Key + " to list)"))
+	case !m.heatmapActive:
+		chart.WriteString(ui.DescriptionStyle.Render("  (" + ui.GlobalKeyMap.Select.Help().Key + " to browse days)"))
+	}
+
+	return chartStyle.Render(chart.String()) + "\n"
+}
+
+// heatCell is the glyph for a heatmap level. Without color the levels are
+// told apart by shade.
+func heatCell(level int) string {
+	switch {
+	case ui.NoColor && ui.ASCII:
+		return []string{".", "-", "+", "*", "#"}[level]
+	case ui.NoColor:
+		return []string{"·", "░", "▒", "▓", "█"}[level]
+	case level == 0:
+		return ui.Symbols.Empty
+	}
+	return ui.Symbols.Filled
+}
@@ -5,11 +5,13 @@ import (
 	"io"
 	"strings"
 
+	"github.com/atotto/clipboard"
 	"github.com/charmbracelet/bubbles/help"
 	"github.com/charmbracelet/bubbles/key"
 	"github.com/charmbracelet/bubbles/list"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/mamuzad/vidlogd/internal/analytics"
 	"github.com/mamuzad/vidlogd/internal/models"
 	"github.com/mamuzad/vidlogd/internal/ui"
 )
@@ -32,15 +34,14 @@ func (d ActionItemDelegate) Render(w io.Writer, m list.Model, index int, listIte
 		return
 	}
 
-	style := ui.MenuItemStyle
-	if index == m.Index() {
-		style = style.Background(ui.PrimaryColor).Foreground(ui.White)
-	}
-
-	styledText := style.Render(i.title)
+	styledText := ui.RenderItem(i.title, index == m.Index())
 	fmt.Fprint(w, styledText)
 }
 
+func init() {
+	ui.RegisterKeyScope("log details", "Up", "Down", "Select", "Edit", "Delete", "Channel", "CopyLink", "Back")
+}
+
 type LogDetailsKeyMap struct{}
 
 func (k LogDetailsKeyMap) ShortHelp() []key.Binding {
@@ -50,6 +51,8 @@ func (k LogDetailsKeyMap) ShortHelp() []key.Binding {
 		ui.GlobalKeyMap.Select,
 		ui.GlobalKeyMap.Edit,
 		ui.GlobalKeyMap.Delete,
+		ui.GlobalKeyMap.Channel,
+		ui.GlobalKeyMap.CopyLink,
 		ui.GlobalKeyMap.Back,
 		ui.GlobalKeyMap.Help,
 	}
@@ -65,6 +68,8 @@ func (k LogDetailsKeyMap) FullHelp() [][]key.Binding {
 		{
 			ui.GlobalKeyMap.Edit,
 			ui.GlobalKeyMap.Delete,
+			ui.GlobalKeyMap.Channel,
+			ui.GlobalKeyMap.CopyLink,
 			ui.GlobalKeyMap.Back,
 		},
 		{
@@ -77,6 +82,7 @@ func (k LogDetailsKeyMap) FullHelp() [][]key.Binding {
 type LogDetailsModel struct {
 	videoID     string
 	video       *models.Video
+	loadErr     error
 	actionsList list.Model
 	help        help.Model
 
@@ -84,14 +90,12 @@ type LogDetailsModel struct {
 }
 
 func NewLogDetailsModel(videoID string) LogDetailsModel {
-	var video *models.Video
-	if foundVideo, err := models.FindVideoByID(videoID); err == nil {
-		video = foundVideo
-	}
+	video, loadErr := models.FindVideoByID(videoID)
 
 	items := []list.Item{
 		ActionItem{title: "edit"},
 		ActionItem{title: "delete"},
+		ActionItem{title: "channel"},
 		ActionItem{title: "back"},
 	}
 
@@ -99,24 +103,41 @@ func NewLogDetailsModel(videoID string) LogDetailsModel {
 	const listHeight = 5
 
 	l := list.New(items, ActionItemDelegate{}, defaultWidth, listHeight)
+	ui.SetupList(&l)
 	l.SetShowStatusBar(false)
 	l.SetFilteringEnabled(false)
 	l.SetShowTitle(false)
 	l.SetShowHelp(false)
-	l.KeyMap.Quit.SetKeys()
-	l.KeyMap.Quit.SetHelp("", "")
 
-	h := help.New()
+	h := ui.NewHelp()
 	h.ShowAll = false // start with compact help
 
 	return LogDetailsModel{
 		videoID:     videoID,
 		video:       video,
+		loadErr:     loadErr,
 		actionsList: l,
 		help:        h,
 	}
 }
 
+// RefreshStyles applies a changed key map or symbols mode to the list
+func (m *LogDetailsModel) RefreshStyles() {
+	ui.SetupList(&m.actionsList)
+}
+
+// videoDeletedMsg is sent once a single video has been deleted
+type videoDeletedMsg struct{}
+
+func deleteVideo(id string) tea.Cmd {
+	return func() tea.Msg {
+		if err := models.DeleteVideo(id); err != nil {
+			return ui.ErrorMsg{Context: "delete video", Err: err}
+		}
+		return videoDeletedMsg{}
+	}
+}
+
 // VideoID returns the route parameter this model was created for.
 func (m LogDetailsModel) VideoID() string { return m.videoID }
 
@@ -130,13 +151,13 @@ func (m LogDetailsModel) Update(msg tea.Msg) (LogDetailsModel, tea.Cmd) {
 		if msg.TargetID == "" {
 			return m, nil
 		}
-		targetID := msg.TargetID
-		return m, func() tea.Msg {
-			if err := models.DeleteVideo(targetID); err != nil {
-				return err
-			}
-			return ui.BackMsg{}
-		}
+		return m, deleteVideo(msg.TargetID)
+	case videoDeletedMsg:
+		return m, tea.Batch(
+			ui.LibraryChanged,
+			func() tea.Msg { return ui.BackMsg{} },
+			ui.Notify("video deleted"),
+		)
 	case ui.DeleteCancelMsg:
 		return m, nil
 	case tea.KeyMsg:
@@ -167,6 +188,17 @@ func (m LogDetailsModel) Update(msg tea.Msg) (LogDetailsModel, tea.Cmd) {
 				m.deleteModal.Show(m.video)
 				return m, nil
 			}
+		case key.Matches(msg, ui.GlobalKeyMap.Channel):
+			if m.video != nil {
+				return m, openChannel(analytics.ChannelName(*m.video), m.video.ChannelID)
+			}
+		case key.Matches(msg, ui.GlobalKeyMap.CopyLink):
+			if m.video != nil {
+				if err := clipboard.WriteAll(m.video.URL); err != nil {
+					return m, ui.ReportError("copy url", err)
+				}
+				return m, ui.Notify("copied url")
+			}
 		case key.Matches(msg, ui.GlobalKeyMap.Select):
 			selectedItem, ok := m.actionsList.SelectedItem().(ActionItem)
 			if !ok {
@@ -188,6 +220,10 @@ func (m LogDetailsModel) Update(msg tea.Msg) (LogDetailsModel, tea.Cmd) {
 					m.deleteModal.Show(m.video)
 					return m, nil
 				}
+			case "channel":
+				if m.video != nil {
+					return m, openChannel(analytics.ChannelName(*m.video), m.video.ChannelID)
+				}
 			case "back":
 				return m, func() tea.Msg {
 					return ui.BackMsg{}
@@ -207,22 +243,14 @@ func (m LogDetailsModel) Update(msg tea.Msg) (LogDetailsModel, tea.Cmd) {
 
 // helper to render stars
 func renderStars(rating float64) string {
-	var stars strings.Builder
-	for i := 1; i <= 5; i++ {
-		starValue := float64(i)
-		if rating >= starValue {
-			stars.WriteString("★") // filled star
-		} else if rating >= starValue-0.5 {
-			stars.WriteString("⯨") // half star
-		} else {
-			stars.WriteString("☆")
-		}
-	}
-	return stars.String()
+	return ui.Stars(rating)
 }
 
 func (m LogDetailsModel) View() string {
 	if m.video == nil {
+		if m.loadErr != nil {
+			return "could not load the log: " + m.loadErr.Error()
+		}
 		return "Log not found"
 	}
 
@@ -239,9 +267,9 @@ func (m LogDetailsModel) View() string {
 	s.WriteString("Date Logged: " + m.video.LogDate.Format(models.DateTimeFormat) + "\n\n")
 	var rewatched string
 	if m.video.Rewatched {
-		rewatched = "  rewatched"
+		rewatched = ui.Icon(ui.Symbols.RewatchIcon, "  ") + "rewatched"
 	} else {
-		rewatched = "  first watch"
+		rewatched = ui.Icon(ui.Symbols.FirstWatchIcon, "  ") + "first watch"
 	}
 	s.WriteString(fmt.Sprintf(
 		"Rating: %s (%.1f/5)  %s\n\n",
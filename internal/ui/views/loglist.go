@@ -1,6 +1,7 @@
 package views
 
 import (
+	"fmt"
 	"strings"
 
 	"github.com/charmbracelet/bubbles/help"
@@ -10,10 +11,15 @@ import (
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 	"github.com/mamuzad/vidlogd/internal/models"
+	"github.com/mamuzad/vidlogd/internal/query"
 	"github.com/mamuzad/vidlogd/internal/ui"
-	"github.com/sahilm/fuzzy"
 )
 
+func init() {
+	ui.RegisterKeyScope("log list", "Up", "Down", "Select", "Edit", "Delete", "Search", "Mark", "BulkActions", "VisualMode",
+		"NextFilter", "PrevFilter", "SaveFilter", "DeleteFilter", "Sort", "SortReverse", "Columns", "Back")
+}
+
 type LogListKeyMap struct{}
 
 func (k LogListKeyMap) ShortHelp() []key.Binding {
@@ -25,6 +31,8 @@ func (k LogListKeyMap) ShortHelp() []key.Binding {
 		ui.GlobalKeyMap.Delete,
 		ui.GlobalKeyMap.Back,
 		ui.GlobalKeyMap.Search,
+		ui.GlobalKeyMap.NextFilter,
+		ui.GlobalKeyMap.Mark,
 		ui.GlobalKeyMap.Help,
 	}
 }
@@ -48,6 +56,22 @@ func (k LogListKeyMap) FullHelp() [][]key.Binding {
 			ui.GlobalKeyMap.Search,
 			ui.GlobalKeyMap.Help,
 		},
+		{
+			ui.GlobalKeyMap.NextFilter,
+			ui.GlobalKeyMap.PrevFilter,
+			ui.GlobalKeyMap.SaveFilter,
+			ui.GlobalKeyMap.DeleteFilter,
+		},
+		{
+			ui.GlobalKeyMap.Sort,
+			ui.GlobalKeyMap.SortReverse,
+			ui.GlobalKeyMap.Columns,
+		},
+		{
+			ui.GlobalKeyMap.Mark,
+			ui.GlobalKeyMap.VisualMode,
+			ui.GlobalKeyMap.BulkActions,
+		},
 	}
 }
 
@@ -59,27 +83,55 @@ type LogListModel struct {
 	filtered   []models.Video
 	isFiltered bool
 	focused    bool
+	highlights []string // query terms highlighted in the table
+	queryErr   error
+	offset     int // first visible table row
+
+	// saved filters
+	tabs                filterTabs
+	filterForm          *FormModel
+	filterErr           error
+	confirmDeleteFilter bool
+
+	// configurable columns
+	columns      []logColumn
+	sortKey      string
+	sortDesc     bool
+	width        int // terminal width, 0 until known
+	pickColumns  bool
+	columnCursor int
+
+	// multi-select and bulk actions
+	marked      map[string]bool // video ids
+	visualStart int             // row where visual mode started, -1 when off
+	bulkMenu    bool
+	bulkCursor  int
+	bulkForm    *FormModel
+	bulkErr     error
 
 	deleteModal ui.DeleteModal
 }
 
-// RefreshStyles reapplies cached styles
+// RefreshStyles reapplies cached styles and the table keys
 func (m *LogListModel) RefreshStyles() {
 	m.updateTableStyles()
+	m.table.KeyMap = ui.TableKeyMap()
 }
 
-func NewLogListModel() LogListModel {
-	columns := []table.Column{
-		{Title: "Title", Width: 35},
-		{Title: "Channel", Width: 15},
-		{Title: "Rating", Width: 8},
-		{Title: "Date Logged", Width: 20},
-	}
+// table width used before the terminal size is known, and the widest the
+// table grows on large terminals
+const (
+	defaultTableWidth = 86
+	maxTableWidth     = 180
+	// popup and table borders and padding around the table
+	tableChrome = 12
+)
 
+func NewLogListModel() LogListModel {
 	t := table.New(
-		table.WithColumns(columns),
 		table.WithFocused(true),
 		table.WithHeight(15),
+		table.WithKeyMap(ui.TableKeyMap()),
 	)
 
 	s := table.DefaultStyles()
@@ -88,33 +140,130 @@ func NewLogListModel() LogListModel {
 
 	t.SetStyles(s)
 
-	h := help.New()
+	h := ui.NewHelp()
 	h.ShowAll = false // start with compact help
 
 	search := textinput.New()
-	search.Placeholder = "search videos..."
-	search.Prompt = "  "
-	search.CharLimit = 50
+	search.Placeholder = "search... channel:name rating:>=4 logged:2025-01"
+	search.Prompt = ui.Icon(ui.Symbols.SearchIcon, "  ")
+	search.CharLimit = 200
 	search.Width = 50
 
-	return LogListModel{
+	sortKey, sortDesc := parseLogSort(Settings.LogSort)
+
+	m := LogListModel{
 		table:      t,
 		help:       h,
 		search:     search,
 		filtered:   []models.Video{},
 		isFiltered: false,
 		focused:    false,
+		columns:    resolveLogColumns(Settings.LogColumns),
+		sortKey:    sortKey,
+		sortDesc:   sortDesc,
 	}
+	m.clearSelection()
+	m.applyColumns()
+	return m
 }
 
-func (m LogListModel) Init() tea.Cmd {
-	return func() tea.Msg {
-		videos, err := models.LoadVideos()
-		if err != nil {
-			return err
+// SetSize fits the columns to the terminal width
+func (m *LogListModel) SetSize(width, height int) {
+	m.width = width
+	m.applyColumns()
+}
+
+// applyColumns recomputes column widths and rebuilds the rows
+func (m *LogListModel) applyColumns() {
+	available := defaultTableWidth
+	if m.width > 0 {
+		available = min(maxTableWidth, m.width-tableChrome)
+	}
+
+	widths := columnWidths(m.columns, available)
+	// clear rows first, bubbles renders them against the new columns
+	m.table.SetRows(nil)
+	m.table.SetColumns(tableColumns(m.columns, widths, m.sortKey, m.sortDesc))
+	m.updateTableRows()
+}
+
+// saveColumns persists the column layout and sort order
+func (m *LogListModel) saveColumns() tea.Cmd {
+	keys := make([]string, len(m.columns))
+	for i, col := range m.columns {
+		keys[i] = col.key
+	}
+	Settings.LogColumns = keys
+	Settings.LogSort = formatLogSort(m.sortKey, m.sortDesc)
+	return ui.ReportError("save columns", models.SaveSettings(Settings))
+}
+
+// cycleSort sorts by the next visible column, starting with the column's
+// natural direction: text ascending, numbers and dates descending
+func (m *LogListModel) cycleSort() tea.Cmd {
+	next := 0
+	for i, col := range m.columns {
+		if col.key == m.sortKey {
+			next = (i + 1) % len(m.columns)
+			break
+		}
+	}
+	m.sortKey = m.columns[next].key
+	switch m.sortKey {
+	case columnTitle, columnChannel, columnReview, columnTags:
+		m.sortDesc = false
+	default:
+		m.sortDesc = true
+	}
+	return m.resort()
+}
+
+func (m *LogListModel) resort() tea.Cmd {
+	cmd := m.saveColumns()
+	m.filterVideos()
+	m.applyColumns()
+	return cmd
+}
+
+// toggleColumn shows or hides a column, keeping at least one visible
+func (m *LogListModel) toggleColumn(key string) tea.Cmd {
+	for i, col := range m.columns {
+		if col.key == key {
+			if len(m.columns) > 1 {
+				m.columns = append(m.columns[:i:i], m.columns[i+1:]...)
+			}
+			cmd := m.saveColumns()
+			m.applyColumns()
+			return cmd
 		}
-		return LoadVideosMsg{videos: videos}
 	}
+	if col, ok := findLogColumn(key); ok {
+		m.columns = append(m.columns, col)
+	}
+	cmd := m.saveColumns()
+	m.applyColumns()
+	return cmd
+}
+
+func (m LogListModel) hasColumn(key string) bool {
+	for _, col := range m.columns {
+		if col.key == key {
+			return true
+		}
+	}
+	return false
+}
+
+func (m LogListModel) Init() tea.Cmd {
+	return tea.Batch(loadLogVideos, loadFilters)
+}
+
+func loadLogVideos() tea.Msg {
+	videos, err := models.LoadMergedVideos()
+	if err != nil {
+		return ui.ErrorMsg{Context: "load videos", Err: err}
+	}
+	return LoadVideosMsg{videos: videos}
 }
 
 type LoadVideosMsg struct {
@@ -122,23 +271,101 @@ type LoadVideosMsg struct {
 }
 
 func (m *LogListModel) filterVideos() {
-	if m.search.Value() == "" {
+	// the active saved filter and the search box narrow together
+	input := m.search.Value()
+	if f, ok := m.tabs.current(); ok {
+		input = f.QueryString() + " " + input
+	}
+
+	q, err := query.Parse(input)
+	if err != nil {
+		// keep the last results while the query is being typed
+		m.queryErr = err
+		return
+	}
+	m.queryErr = nil
+
+	sortVideos(m.videos, m.sortKey, m.sortDesc)
+
+	if q.IsEmpty() {
 		m.isFiltered = false
 		m.filtered = m.videos
+		m.highlights = nil
 		return
 	}
 
 	m.isFiltered = true
-	searchable := make([]string, len(m.videos))
-	for i, v := range m.videos {
-		searchable[i] = strings.ToLower(v.Title + " " + v.Channel)
+	m.highlights = q.Highlights()
+	results := query.Search(m.videos, q)
+	m.filtered = make([]models.Video, len(results))
+	for i, result := range results {
+		m.filtered[i] = result.Video
+	}
+
+	// text searches stay in relevance order
+	if len(m.highlights) == 0 {
+		sortVideos(m.filtered, m.sortKey, m.sortDesc)
+	}
+}
+
+// Status describes the active saved filter and search for the status line
+func (m LogListModel) Status() ui.ViewStatus {
+	if !m.isFiltered {
+		return ui.ViewStatus{}
+	}
+	var parts []string
+	if f, ok := m.tabs.current(); ok {
+		parts = append(parts, f.Name)
+	}
+	if search := strings.TrimSpace(m.search.Value()); search != "" {
+		parts = append(parts, fmt.Sprintf("%q", search))
 	}
+	return ui.ViewStatus{Filter: strings.Join(parts, " + "), Shown: len(m.filtered)}
+}
+
+// refilter reapplies the filters and moves the cursor back to the top
+func (m *LogListModel) refilter() {
+	m.filterVideos()
+	m.updateTableRows()
+	m.table.SetCursor(0)
+	m.offset = 0
+	m.visualStart = -1
+}
+
+// openFilterForm saves the current tab and search as a filter. With no
+// search text the active filter is edited in place.
+func (m *LogListModel) openFilterForm() {
+	f, _ := m.tabs.current()
+	if search := strings.TrimSpace(m.search.Value()); search != "" {
+		f.Query = strings.TrimSpace(f.Query + " " + search)
+		f.Name = ""
+	}
+
+	form := newFilterForm(f, func() tea.Cmd {
+		return func() tea.Msg { return filterFormClosedMsg{} }
+	})
+	m.filterForm = &form
+	m.table.Blur()
+}
+
+type filterFormClosedMsg struct{}
+
+// visibleVideos returns the videos in table order
+func (m LogListModel) visibleVideos() []models.Video {
+	if m.isFiltered {
+		return m.filtered
+	}
+	return m.videos
+}
 
-	matches := fuzzy.Find(m.search.Value(), searchable)
-	m.filtered = make([]models.Video, len(matches))
-	for i, match := range matches {
-		m.filtered[i] = m.videos[match.Index]
+// selectedVideo returns the video under the cursor
+func (m LogListModel) selectedVideo() (models.Video, bool) {
+	videos := m.visibleVideos()
+	cursor := m.table.Cursor()
+	if cursor < 0 || cursor >= len(videos) {
+		return models.Video{}, false
 	}
+	return videos[cursor], true
 }
 
 func (m LogListModel) Update(msg tea.Msg) (LogListModel, tea.Cmd) {
@@ -151,26 +378,95 @@ func (m LogListModel) Update(msg tea.Msg) (LogListModel, tea.Cmd) {
 		m.filterVideos()
 		m.updateTableRows()
 		return m, nil
+	case FiltersLoadedMsg:
+		m.filterErr = msg.err
+		if msg.err != nil {
+			if m.filterForm != nil {
+				m.filterForm.SetError(msg.err.Error())
+			}
+			return m, nil
+		}
+		m.filterForm = nil
+		m.table.Focus()
+		before, _ := m.tabs.current()
+		m.tabs.set(msg.filters, msg.active)
+		if msg.active != "" {
+			// the saved filter now includes the search text
+			m.search.SetValue("")
+		}
+		if after, _ := m.tabs.current(); msg.active != "" || after != before {
+			m.refilter()
+		} else {
+			m.filterVideos()
+			m.updateTableRows()
+		}
+		return m, nil
+	case filterFormErrMsg:
+		if m.filterForm != nil {
+			m.filterForm.SetError(msg.err.Error())
+		}
+		return m, nil
+	case filterFormClosedMsg:
+		m.filterForm = nil
+		m.table.Focus()
+		return m, nil
+	case bulkDoneMsg:
+		if msg.err != nil {
+			if m.bulkForm != nil {
+				m.bulkForm.SetError(msg.err.Error())
+				return m, nil
+			}
+			m.bulkErr = msg.err
+			return m, nil
+		}
+		m.bulkForm = nil
+		m.bulkErr = nil
+		m.table.Focus()
+		m.clearSelection()
+		return m, tea.Batch(loadLogVideos, ui.LibraryChanged, ui.Notify(msg.status))
+	case bulkFormClosedMsg:
+		m.bulkForm = nil
+		m.table.Focus()
+		return m, nil
 	case ui.DeleteConfirmMsg:
+		if len(msg.TargetIDs) > 0 {
+			m.deleteModal.Hide()
+			return m, bulkDeleteVideos(msg.TargetIDs)
+		}
 		if msg.TargetID == "" {
 			return m, nil
 		}
 		targetID := msg.TargetID
 		m.deleteModal.Hide()
-		return m, func() tea.Msg {
-			if err := models.DeleteVideo(targetID); err != nil {
-				return err
-			}
-			videos, err := models.LoadVideos()
-			if err != nil {
-				return err
-			}
-			return LoadVideosMsg{videos: videos}
-		}
+		return m, deleteVideo(targetID)
+	case videoDeletedMsg:
+		return m, tea.Batch(loadLogVideos, ui.LibraryChanged, ui.Notify("video deleted"))
 	case ui.DeleteCancelMsg:
 		m.deleteModal.Hide()
 		return m, nil
+	}
+
+	if m.filterForm != nil {
+		form, cmd := m.filterForm.Update(msg)
+		m.filterForm = &form
+		return m, cmd
+	}
+	if m.bulkForm != nil {
+		form, cmd := m.bulkForm.Update(msg)
+		m.bulkForm = &form
+		return m, cmd
+	}
+
+	switch msg := msg.(type) {
 	case tea.KeyMsg:
+		if m.confirmDeleteFilter {
+			m.confirmDeleteFilter = false
+			if f, ok := m.tabs.current(); ok && key.Matches(msg, ui.GlobalKeyMap.Yes) {
+				return m, deleteFilter(f.Name)
+			}
+			return m, nil
+		}
+
 		if m.deleteModal.Visible {
 			handled, cmd := m.deleteModal.Update(msg)
 			if handled {
@@ -178,6 +474,16 @@ func (m LogListModel) Update(msg tea.Msg) (LogListModel, tea.Cmd) {
 			}
 		}
 
+		if m.pickColumns {
+			return m.updateColumnPicker(msg)
+		}
+		if m.bulkMenu {
+			return m.updateBulkMenu(msg)
+		}
+		if !m.focused {
+			m.bulkErr = nil
+		}
+
 		switch {
 		case key.Matches(msg, ui.GlobalKeyMap.Help):
 			m.help.ShowAll = !m.help.ShowAll
@@ -194,42 +500,64 @@ func (m LogListModel) Update(msg tea.Msg) (LogListModel, tea.Cmd) {
 				m.table.Blur()
 			}
 			return m, nil
+		case !m.focused && m.hasSelection() && key.Matches(msg, ui.GlobalKeyMap.SearchBack):
+			m.clearSelection()
+			return m, nil
 		case key.Matches(msg, ui.GlobalKeyMap.Cancel):
 			return m, func() tea.Msg { return ui.BackMsg{} }
 		case m.focused:
 			// when search is focused, only handle search input
 			m.search, searchCmd = m.search.Update(msg)
-			m.filterVideos()
-			m.updateTableRows()
+			m.refilter()
 			return m, searchCmd
 		case key.Matches(msg, ui.GlobalKeyMap.Back):
 			return m, func() tea.Msg { return ui.BackMsg{} }
+		case key.Matches(msg, ui.GlobalKeyMap.NextFilter), key.Matches(msg, ui.GlobalKeyMap.PrevFilter):
+			m.tabs.cycle(key.Matches(msg, ui.GlobalKeyMap.NextFilter))
+			m.refilter()
+			return m, nil
+		case key.Matches(msg, ui.GlobalKeyMap.SaveFilter):
+			m.openFilterForm()
+			return m, nil
+		case key.Matches(msg, ui.GlobalKeyMap.DeleteFilter):
+			_, m.confirmDeleteFilter = m.tabs.current()
+			return m, nil
+		case key.Matches(msg, ui.GlobalKeyMap.Sort):
+			return m, m.cycleSort()
+		case key.Matches(msg, ui.GlobalKeyMap.SortReverse):
+			m.sortDesc = !m.sortDesc
+			return m, m.resort()
+		case key.Matches(msg, ui.GlobalKeyMap.Mark):
+			m.toggleMark()
+			return m, nil
+		case key.Matches(msg, ui.GlobalKeyMap.VisualMode):
+			m.toggleVisual()
+			return m, nil
+		case key.Matches(msg, ui.GlobalKeyMap.BulkActions):
+			if len(m.visibleVideos()) > 0 {
+				m.bulkMenu = true
+				m.bulkCursor = 0
+			}
+			return m, nil
+		case key.Matches(msg, ui.GlobalKeyMap.Columns):
+			m.pickColumns = true
+			m.columnCursor = 0
+			return m, nil
 		case key.Matches(msg, ui.GlobalKeyMap.Edit): // quick edit shortcut
-			if len(m.videos) > 0 {
-				selectedRow := m.table.Cursor()
-				if selectedRow < len(m.videos) {
-					videoToEdit := m.videos[selectedRow]
-					return m, func() tea.Msg {
-						return ui.NavigateMsg{
-							View:  ui.LogVideoView,
-							State: ui.VideoRouteState{VideoID: videoToEdit.ID},
-						}
+			if videoToEdit, ok := m.selectedVideo(); ok {
+				return m, func() tea.Msg {
+					return ui.NavigateMsg{
+						View:  ui.LogVideoView,
+						State: ui.VideoRouteState{VideoID: videoToEdit.ID},
 					}
 				}
 			}
 			return m, nil
 		case key.Matches(msg, ui.GlobalKeyMap.Delete): // quick delete shortcut
-			if len(m.videos) > 0 {
-				selectedRow := m.table.Cursor()
-				videosToUse := m.videos
-				if m.isFiltered {
-					videosToUse = m.filtered
-				}
-				if selectedRow < len(videosToUse) {
-					videoToDelete := videosToUse[selectedRow] // copy
-					m.deleteModal.Show(&videoToDelete)
-					return m, nil
-				}
+			if m.hasSelection() {
+				m.deleteModal.ShowMany(m.selectedVideos())
+			} else if videoToDelete, ok := m.selectedVideo(); ok { // copy
+				m.deleteModal.Show(&videoToDelete)
 			}
 			return m, nil
 		case key.Matches(msg, ui.GlobalKeyMap.Select):
@@ -238,50 +566,49 @@ func (m LogListModel) Update(msg tea.Msg) (LogListModel, tea.Cmd) {
 	}
 
 	m.table, cmd = m.table.Update(msg)
+	m.offset = clampOffset(m.offset, m.table.Cursor(), m.table.Height(), len(m.table.Rows()))
 	return m, cmd
 }
 
 func (m *LogListModel) updateTableRows() {
-	videosToUse := m.videos
-	if m.isFiltered {
-		videosToUse = m.filtered
-	}
+	videosToUse := m.visibleVideos()
 
 	rows := make([]table.Row, len(videosToUse))
 	for i, video := range videosToUse {
-		title := video.Title
-		if title == "" {
-			title = "Untitled"
-		}
-
-		channel := video.Channel
-		if channel == "" {
-			channel = "Unknown Channel"
-		}
-
-		// format rating as stars
-		ratingStr := ""
-		if video.Rating > 0 {
-			for j := 1; j <= 5; j++ {
-				starValue := float64(j)
-				if video.Rating >= starValue {
-					ratingStr += "★" // filled star
-				} else if video.Rating >= starValue-0.5 {
-					ratingStr += "⯨" // half star
-				} else {
-					ratingStr += "☆"
-				}
-			}
+		row := make(table.Row, len(m.columns))
+		for j, col := range m.columns {
+			row[j] = col.value(video)
 		}
+		rows[i] = row
+	}
+	m.table.SetRows(rows)
+}
 
-		logDate := video.LogDate.Format(models.DateTimeFormat)
-		if video.LogDate.IsZero() {
-			logDate = "No date"
-		}
+// updateColumnPicker moves through the column list and toggles columns
+func (m LogListModel) updateColumnPicker(msg tea.KeyMsg) (LogListModel, tea.Cmd) {
+	var cmd tea.Cmd
+	switch {
+	case key.Matches(msg, ui.GlobalKeyMap.Up):
+		m.columnCursor = max(0, m.columnCursor-1)
+	case key.Matches(msg, ui.GlobalKeyMap.Down):
+		m.columnCursor = min(len(logColumns)-1, m.columnCursor+1)
+	case key.Matches(msg, ui.GlobalKeyMap.Select), msg.String() == " ":
+		cmd = m.toggleColumn(logColumns[m.columnCursor].key)
+	case key.Matches(msg, ui.GlobalKeyMap.Columns, ui.GlobalKeyMap.Back, ui.GlobalKeyMap.Cancel, ui.GlobalKeyMap.SearchBack):
+		m.pickColumns = false
+	}
+	return m, cmd
+}
 
-		rows[i] = table.Row{title, channel, ratingStr, logDate}
+func (m LogListModel) columnPickerView() string {
+	var s strings.Builder
+	s.WriteString(fmt.Sprintf(" columns (%s/space to toggle, %s to close)\n\n",
+		ui.GlobalKeyMap.Select.Help().Key, ui.GlobalKeyMap.Columns.Help().Key))
+	for i, col := range logColumns {
+		line := fmt.Sprintf(" %s %s ", ui.Checkbox(m.hasColumn(col.key)), col.title)
+		s.WriteString(ui.RenderRow(line, i == m.columnCursor) + "\n")
 	}
-	m.table.SetRows(rows)
+	return ui.TableStyle.Render(s.String())
 }
 
 func (m *LogListModel) updateTableStyles() {
@@ -292,21 +619,23 @@ func (m *LogListModel) updateTableStyles() {
 }
 
 func (m LogListModel) handleSelection() (LogListModel, tea.Cmd) {
-	if len(m.videos) == 0 {
+	selectedVideo, ok := m.selectedVideo()
+	if !ok {
 		return m, nil
 	}
-
-	selectedRow := m.table.Cursor()
-	if selectedRow < len(m.videos) {
-		selectedVideo := m.videos[selectedRow]
-		return m, func() tea.Msg {
-			return ui.NavigateMsg{View: ui.LogDetailsView, State: ui.VideoRouteState{VideoID: selectedVideo.ID}}
-		}
+	return m, func() tea.Msg {
+		return ui.NavigateMsg{View: ui.LogDetailsView, State: ui.VideoRouteState{VideoID: selectedVideo.ID}}
 	}
-	return m, nil
 }
 
 func (m LogListModel) View() string {
+	if m.filterForm != nil {
+		return m.filterForm.View()
+	}
+	if m.bulkForm != nil {
+		return m.bulkForm.View()
+	}
+
 	var s strings.Builder
 
 	s.WriteString(ui.HeaderStyle.Render("video logs") + "\n")
@@ -325,11 +654,45 @@ func (m LogListModel) View() string {
 		currentSearchStyle = ui.SearchStyle.BorderForeground(ui.PrimaryColor)
 	}
 
+	if tabs := m.tabs.View(); tabs != "" {
+		s.WriteString("\n" + tabs)
+	}
 	s.WriteString("\n" + currentSearchStyle.Render(m.search.View()) + "\n")
+	if m.queryErr != nil {
+		s.WriteString(ui.DescriptionStyle.Render("query: "+m.queryErr.Error()) + "\n")
+	}
+	if m.filterErr != nil {
+		s.WriteString(ui.DescriptionStyle.Render("filters: "+m.filterErr.Error()) + "\n")
+	}
+	if m.bulkErr != nil {
+		s.WriteString(ui.DangerStyle.Render("  "+m.bulkErr.Error()) + "\n")
+	}
+	if rows := m.markedRows(); len(rows) > 0 || m.visualStart >= 0 {
+		status := fmt.Sprintf("  %d marked", len(rows))
+		if m.visualStart >= 0 {
+			status += " (visual)"
+		}
+		s.WriteString(ui.DescriptionStyle.Render(status+", "+ui.GlobalKeyMap.BulkActions.Help().Key+" for actions, "+ui.GlobalKeyMap.SearchBack.Help().Key+" to clear") + "\n")
+	}
+	if f, ok := m.tabs.current(); ok && m.confirmDeleteFilter {
+		s.WriteString(ui.DangerStyle.Render("  delete filter \""+f.Name+"\"? (y/n)") + "\n")
+	}
 
-	tableContent := m.table.View()
+	// text columns show search matches
+	highlightCols := make(map[int]bool)
+	for i, col := range m.columns {
+		switch col.key {
+		case columnTitle, columnChannel, columnReview, columnTags:
+			highlightCols[i] = true
+		}
+	}
+	tableContent := renderTable(m.table, m.offset, m.highlights, highlightCols, m.markedRows())
 	styledTable := ui.TableStyle.Render(tableContent)
-	if m.deleteModal.Visible {
+	if m.pickColumns {
+		s.WriteString("\n" + m.columnPickerView())
+	} else if m.bulkMenu {
+		s.WriteString("\n" + m.bulkMenuView())
+	} else if m.deleteModal.Visible {
 		width := lipgloss.Width(styledTable)
 		s.WriteString(m.deleteModal.View(width, 6, 6))
 	} else {
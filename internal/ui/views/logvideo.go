@@ -15,41 +15,55 @@ type LogVideoModel struct {
 func NewLogVideoModel(videoID string) LogVideoModel {
 	editing := videoID != ""
 	var existingVideo *models.Video
+	var loadErr error
 
 	// load existing video if editing
 	if editing {
-		if video, err := models.FindVideoByID(videoID); err == nil {
-			existingVideo = video
-		}
+		existingVideo, loadErr = models.FindVideoByID(videoID)
 	}
 
 	form := NewVideoLogForm(editing, existingVideo)
+	if loadErr != nil {
+		form.SetError("could not load the video: " + loadErr.Error())
+	}
 
 	form.SetHandlers(
 		func(f FormModel) tea.Cmd {
+			video, err := f.Video()
+			if err != nil {
+				return videoSaveFailed("save video", err)
+			}
+
 			if editing {
 				// update existing video
-				if existingVideo != nil {
-					video := f.Video()
-					video.ID = existingVideo.ID // preserve the original ID
-
-					if err := models.UpdateVideo(video); err != nil {
-						// TODO: add errors ui
-					}
+				if existingVideo == nil {
+					return videoSaveFailed("update video", loadErr)
 				}
-				return func() tea.Msg { return ui.BackMsg{} }
-			} else {
-				// create new video
-				video := f.Video()
-				if err := models.SaveVideo(video); err != nil {
-					// TODO: add errors ui
+				video.ID = existingVideo.ID // preserve the original ID
+				video.Tags = existingVideo.Tags
+				video.Collection = existingVideo.Collection
+
+				if err := models.UpdateVideo(video); err != nil {
+					return videoSaveFailed("update video", err)
 				}
-				// clear form by sending clear message then navigate
 				return tea.Batch(
-					func() tea.Msg { return ui.ClearFormMsg{} },
+					ui.LibraryChanged,
 					func() tea.Msg { return ui.BackMsg{} },
+					ui.Notify("video updated"),
 				)
 			}
+
+			// create new video
+			if err := models.SaveVideo(video); err != nil {
+				return videoSaveFailed("save video", err)
+			}
+			// clear form by sending clear message then navigate
+			return tea.Batch(
+				ui.LibraryChanged,
+				func() tea.Msg { return ui.ClearFormMsg{} },
+				func() tea.Msg { return ui.BackMsg{} },
+				ui.Notify("video saved"),
+			)
 		},
 		func() tea.Cmd {
 			if editing {
@@ -65,6 +79,18 @@ func NewLogVideoModel(videoID string) LogVideoModel {
 	return LogVideoModel{form: form, videoID: videoID}
 }
 
+// videoSaveFailedMsg keeps the form open with the user's input after a
+// failed save
+type videoSaveFailedMsg struct {
+	ui.ErrorMsg
+}
+
+func videoSaveFailed(context string, err error) tea.Cmd {
+	return func() tea.Msg {
+		return videoSaveFailedMsg{ui.ErrorMsg{Context: context, Err: err}}
+	}
+}
+
 func (m LogVideoModel) Init() tea.Cmd {
 	return m.form.Init()
 }
@@ -73,6 +99,9 @@ func (m LogVideoModel) Update(msg tea.Msg) (LogVideoModel, tea.Cmd) {
 	var cmd tea.Cmd
 
 	switch msg := msg.(type) {
+	case videoSaveFailedMsg:
+		m.form.SetError(msg.Error())
+		return m, func() tea.Msg { return msg.ErrorMsg }
 	case services.MetadataFetchedMsg:
 		m.form, cmd = m.form.Update(msg)
 		return m, cmd
@@ -88,6 +117,11 @@ func (m LogVideoModel) View() string {
 
 func (m LogVideoModel) VideoID() string { return m.videoID }
 
+// Status marks the form as unsaved once it has been edited
+func (m LogVideoModel) Status() ui.ViewStatus {
+	return ui.ViewStatus{Unsaved: m.form.Dirty()}
+}
+
 // UpdateVimMode updates the vim mode setting for the form
 func (m *LogVideoModel) UpdateVimMode() {
 	m.form.UpdateVimMode()
@@ -3,13 +3,17 @@ package views
 import (
 	"fmt"
 	"io"
+	"strings"
 
 	"github.com/charmbracelet/bubbles/key"
 	"github.com/charmbracelet/bubbles/list"
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/mamuzad/vidlogd/internal/storage"
 	"github.com/mamuzad/vidlogd/internal/ui"
 )
 
+const newProfileItem = "+ new profile"
+
 type MenuItem struct {
 	title string
 }
@@ -27,18 +31,19 @@ func (d MenuItemDelegate) Render(w io.Writer, m list.Model, index int, listItem
 		return
 	}
 
-	style := ui.MenuItemStyle
-	if index == m.Index() {
-		style = style.Background(ui.PrimaryColor).Foreground(ui.White)
-	}
-
-	styledText := style.Render(i.title)
+	styledText := ui.RenderItem(i.title, index == m.Index())
 	centeredText := ui.CenterHorizontally(styledText, m.Width())
 	fmt.Fprint(w, centeredText)
 }
 
 type MainMenuModel struct {
 	list list.Model
+
+	// profile picker
+	profiles      list.Model
+	pickProfile   bool
+	profileForm   *FormModel // for naming a new profile
+	profileErrMsg string
 }
 
 func NewMainMenuModel() MainMenuModel {
@@ -46,7 +51,9 @@ func NewMainMenuModel() MainMenuModel {
 		MenuItem{title: "log video"},
 		MenuItem{title: "view logs"},
 		MenuItem{title: "stats"},
+		MenuItem{title: "import / export"},
 		MenuItem{title: "settings"},
+		MenuItem{title: "profile"},
 		MenuItem{title: "exit"},
 	}
 
@@ -54,16 +61,107 @@ func NewMainMenuModel() MainMenuModel {
 	const listHeight = 14
 
 	l := list.New(items, MenuItemDelegate{}, defaultWidth, listHeight)
+	ui.SetupList(&l)
 	l.SetShowStatusBar(false)
 	l.SetFilteringEnabled(false)
 	l.SetShowTitle(false)
 	l.SetShowHelp(true)
-	l.KeyMap.Quit.SetKeys()
-	l.KeyMap.Quit.SetHelp("", "")
+
+	p := list.New([]list.Item{}, MenuItemDelegate{}, defaultWidth, listHeight)
+	ui.SetupList(&p)
+	p.SetShowStatusBar(false)
+	p.SetFilteringEnabled(false)
+	p.SetShowTitle(false)
+	p.SetShowHelp(true)
 
 	return MainMenuModel{
-		list: l,
+		list:     l,
+		profiles: p,
+	}
+}
+
+// RefreshStyles applies a changed key map or symbols mode to the lists
+func (m *MainMenuModel) RefreshStyles() {
+	ui.SetupList(&m.list)
+	ui.SetupList(&m.profiles)
+}
+
+// openProfilePicker lists existing profiles with the active one selected
+func (m *MainMenuModel) openProfilePicker() {
+	names, err := storage.Profiles()
+	if err != nil {
+		names = []string{storage.DefaultProfile}
+		m.profileErrMsg = err.Error()
+	} else {
+		m.profileErrMsg = ""
+	}
+
+	items := make([]list.Item, 0, len(names)+1)
+	selected := 0
+	for i, name := range names {
+		if name == storage.Profile() {
+			selected = i
+		}
+		items = append(items, MenuItem{title: name})
+	}
+	items = append(items, MenuItem{title: newProfileItem})
+
+	m.profiles.SetItems(items)
+	m.profiles.Select(selected)
+	m.pickProfile = true
+}
+
+func (m *MainMenuModel) closeProfilePicker() {
+	m.pickProfile = false
+	m.profileForm = nil
+	m.profileErrMsg = ""
+}
+
+// newProfileForm asks for the name of a profile to create
+func (m *MainMenuModel) newProfileForm() {
+	fields := []FormField{
+		{Placeholder: "work", Label: "Profile Name:", Required: true, CharLimit: 32, Width: 34, Type: FormFieldText},
+	}
+
+	form := NewForm("new profile", fields, "create")
+	form.SetHandlers(
+		func(f FormModel) tea.Cmd {
+			name := strings.TrimSpace(f.Value(0))
+			return func() tea.Msg { return profileChosenMsg{name: name} }
+		},
+		func() tea.Cmd {
+			return func() tea.Msg { return profileChosenMsg{} }
+		},
+	)
+	m.profileForm = &form
+}
+
+type profileChosenMsg struct {
+	name string // empty when cancelled
+}
+
+// switchProfile points storage at the given profile and reloads its settings
+func (m *MainMenuModel) switchProfile(name string) tea.Cmd {
+	if err := storage.SetProfile(name); err != nil {
+		if m.profileForm != nil {
+			m.profileForm.touched[0] = true
+			m.profileForm.fieldErrors[0] = "letters, numbers, - or _ only"
+		} else {
+			m.profileErrMsg = err.Error()
+		}
+		return nil
 	}
+	m.closeProfilePicker()
+
+	// settings, keymap and theme are per profile
+	err := LoadAndApplySettings()
+
+	profile := storage.Profile()
+	return tea.Batch(
+		ui.ReportError("load settings", err),
+		func() tea.Msg { return ui.ProfileSwitchedMsg{Profile: profile} },
+		func() tea.Msg { return ui.UIRefreshMsg{} },
+	)
 }
 
 func (m MainMenuModel) Init() tea.Cmd {
@@ -74,8 +172,28 @@ func (m MainMenuModel) Update(msg tea.Msg) (MainMenuModel, tea.Cmd) {
 	switch msg := msg.(type) {
 	case tea.WindowSizeMsg:
 		m.list.SetWidth(msg.Width)
+		m.profiles.SetWidth(msg.Width)
 		return m, nil
 
+	case profileChosenMsg:
+		if msg.name == "" {
+			m.profileForm = nil
+			return m, nil
+		}
+		return m, m.switchProfile(msg.name)
+	}
+
+	if m.profileForm != nil {
+		form, cmd := m.profileForm.Update(msg)
+		m.profileForm = &form
+		return m, cmd
+	}
+
+	if m.pickProfile {
+		return m.updateProfilePicker(msg)
+	}
+
+	switch msg := msg.(type) {
 	case tea.KeyMsg:
 		if key.Matches(msg, ui.GlobalKeyMap.Back) {
 			return m, tea.Quit
@@ -90,6 +208,30 @@ func (m MainMenuModel) Update(msg tea.Msg) (MainMenuModel, tea.Cmd) {
 	return m, cmd
 }
 
+func (m MainMenuModel) updateProfilePicker(msg tea.Msg) (MainMenuModel, tea.Cmd) {
+	if msg, ok := msg.(tea.KeyMsg); ok {
+		switch {
+		case key.Matches(msg, ui.GlobalKeyMap.Back, ui.GlobalKeyMap.Cancel):
+			m.closeProfilePicker()
+			return m, nil
+		case key.Matches(msg, ui.GlobalKeyMap.Select):
+			selectedItem, ok := m.profiles.SelectedItem().(MenuItem)
+			if !ok {
+				return m, nil
+			}
+			if selectedItem.title == newProfileItem {
+				m.newProfileForm()
+				return m, nil
+			}
+			return m, m.switchProfile(selectedItem.title)
+		}
+	}
+
+	var cmd tea.Cmd
+	m.profiles, cmd = m.profiles.Update(msg)
+	return m, cmd
+}
+
 func (m MainMenuModel) handleSelection() (MainMenuModel, tea.Cmd) {
 	selectedItem, ok := m.list.SelectedItem().(MenuItem)
 	if !ok {
@@ -109,10 +251,17 @@ func (m MainMenuModel) handleSelection() (MainMenuModel, tea.Cmd) {
 		return m, func() tea.Msg {
 			return ui.NavigateMsg{View: ui.StatsView}
 		}
+	case "import / export":
+		return m, func() tea.Msg {
+			return ui.NavigateMsg{View: ui.TransferView}
+		}
 	case "settings":
 		return m, func() tea.Msg {
 			return ui.NavigateMsg{View: ui.SettingsView}
 		}
+	case "profile":
+		m.openProfilePicker()
+		return m, nil
 	case "exit":
 		return m, tea.Quit
 	}
@@ -120,7 +269,24 @@ func (m MainMenuModel) handleSelection() (MainMenuModel, tea.Cmd) {
 }
 
 func (m MainMenuModel) View() string {
+	if m.profileForm != nil {
+		return m.profileForm.View()
+	}
+
+	if m.pickProfile {
+		width := m.profiles.Width()
+		content := ui.HeaderStyle.Render("profiles") + "\n\n" + m.profiles.View()
+		if m.profileErrMsg != "" {
+			content += "\n" + ui.DangerStyle.Render(m.profileErrMsg)
+		}
+		return ui.CenterHorizontally(content, width)
+	}
+
 	width := m.list.Width()
+	content := m.list.View()
+	if profile := storage.Profile(); profile != storage.DefaultProfile {
+		content = ui.DescriptionStyle.Render("profile: "+profile) + "\n\n" + content
+	}
 
-	return ui.CenterHorizontally(m.list.View(), width)
+	return ui.CenterHorizontally(content, width)
 }
@@ -0,0 +1,149 @@
+package views
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/mamuzad/vidlogd/internal/analytics"
+	"github.com/mamuzad/vidlogd/internal/models"
+	"github.com/mamuzad/vidlogd/internal/ui"
+	"github.com/mamuzad/vidlogd/internal/ui/chart"
+)
+
+// ratings screen layout
+const (
+	ratingWindow    = 10 // videos in the moving average
+	trendMinRated   = 3  // ratings a channel needs to get a trend line
+	trendRows       = 5
+	trendSparkWidth = 18
+)
+
+// updateRatings handles keys while the rating trends are shown
+func (m StatsModel) updateRatings(msg tea.KeyMsg) (StatsModel, tea.Cmd) {
+	switch {
+	case key.Matches(msg, ui.GlobalKeyMap.Up):
+		m.ratingsOffset = max(0, m.ratingsOffset-1)
+	case key.Matches(msg, ui.GlobalKeyMap.Down):
+		trends := analytics.ChannelTrends(m.currentVideos(), trendMinRated)
+		m.ratingsOffset = max(0, min(m.ratingsOffset+1, len(trends)-trendRows))
+	case key.Matches(msg, ui.GlobalKeyMap.Ratings, ui.GlobalKeyMap.Back, ui.GlobalKeyMap.Cancel, ui.GlobalKeyMap.SearchBack):
+		m.ratingsOpen = false
+		m.ratingsOffset = 0
+	}
+	return m, nil
+}
+
+// renderRatings draws the rating trends in place of the charts
+func (m StatsModel) renderRatings() string {
+	boxStyle := lipgloss.NewStyle().
+		Border(ui.Border()).
+		BorderForeground(ui.PrimaryColor).
+		Padding(0, 1).
+		Margin(1, 0).
+		Width(m.boxWidth())
+
+	heading := lipgloss.NewStyle().Foreground(ui.PrimaryColor).Bold(true)
+	warning := lipgloss.NewStyle().Foreground(ui.DangerColor)
+
+	videos := m.currentVideos()
+	points := analytics.RatingTrend(videos, ratingWindow)
+
+	var s strings.Builder
+	s.WriteString(" " + heading.Render("rating trends") + "\n")
+
+	if len(points) == 0 {
+		s.WriteString("\n no rated videos yet")
+		return boxStyle.Render(s.String()) + "\n"
+	}
+
+	section := func(title string) {
+		s.WriteString("\n " + heading.Render(title) + "\n")
+	}
+
+	// moving average
+	series := chart.Series{Labels: make([]string, len(points)), Values: make([]float64, len(points))}
+	for i, p := range points {
+		series.Values[i] = p.Average
+	}
+	series.Labels[0] = points[0].Date.Format(models.ISODateFormat)
+	series.Labels[len(points)-1] = points[len(points)-1].Date.Format(models.ISODateFormat)
+	section(fmt.Sprintf("over time (%d video average, now %.1f)", ratingWindow, series.Values[len(points)-1]))
+	s.WriteString(chart.Line(series, chart.Options{
+		Width:  m.contentWidth() - 1,
+		Height: 3,
+		Format: "%.1f",
+		Style:  lipgloss.NewStyle().Foreground(ui.ChartColor),
+	}) + "\n")
+
+	// first watch against rewatch
+	section("first watch vs rewatch")
+	cmp := analytics.CompareRewatches(videos)
+	s.WriteString(" " + ratingStat("first watch", cmp.FirstWatch) + " " + ui.Symbols.Separator + " " + ratingStat("rewatch", cmp.Rewatch) + "\n")
+
+	// harshness
+	months := analytics.MonthlyHarshness(videos)
+	harshness := make([]float64, len(months))
+	harshest, kindest := months[0], months[0]
+	spread := 0.0
+	for i, month := range months {
+		harshness[i] = month.Harshness
+		spread = max(spread, math.Abs(month.Harshness))
+		if month.Harshness > harshest.Harshness {
+			harshest = month
+		}
+		if month.Harshness < kindest.Harshness {
+			kindest = month
+		}
+	}
+	section("harshness by month")
+	s.WriteString(" " + chart.Sparkline(harshness, -spread, spread, m.contentWidth()-2) + "\n")
+	s.WriteString(ui.DescriptionStyle.Render(fmt.Sprintf("harshest %s %+.1f %s kindest %s %+.1f (avg %.1f)",
+		harshest.Month.Format(models.MonthFormat), harshest.Harshness, ui.Symbols.Separator,
+		kindest.Month.Format(models.MonthFormat), kindest.Harshness,
+		harshest.AvgRating+harshest.Harshness)) + "\n")
+
+	// channel trend lines
+	trends := analytics.ChannelTrends(videos, trendMinRated)
+	section("channels")
+	if len(trends) == 0 {
+		s.WriteString(ui.DescriptionStyle.Render(fmt.Sprintf("channels need %d ratings for a trend", trendMinRated)) + "\n")
+	}
+	offset := min(m.ratingsOffset, max(0, len(trends)-trendRows))
+	for _, t := range trends[offset:min(len(trends), offset+trendRows)] {
+		line := fmt.Sprintf(" %-18s %-*s %.1f %s %.1f", truncateString(t.Channel, 16), trendSparkWidth,
+			chart.Sparkline(t.Ratings, 1, 5, trendSparkWidth), t.Earlier, ui.Symbols.Arrow, t.Recent)
+		if t.Declining() {
+			line += warning.Render(" " + ui.Symbols.Falling)
+		}
+		s.WriteString(line + "\n")
+	}
+	if len(trends) > trendRows {
+		s.WriteString(ui.DescriptionStyle.Render(fmt.Sprintf("%d-%d of %d channels", offset+1, min(len(trends), offset+trendRows), len(trends))) + "\n")
+	}
+
+	// declining channels
+	if declining := analytics.Declining(trends); len(declining) > 0 {
+		names := make([]string, 0, len(declining))
+		for _, t := range declining[:min(3, len(declining))] {
+			names = append(names, fmt.Sprintf("%s (%+.1f)", t.Channel, t.Drift()))
+		}
+		line := "declining: " + strings.Join(names, ", ")
+		if more := len(declining) - len(names); more > 0 {
+			line += fmt.Sprintf(" and %d more", more)
+		}
+		s.WriteString("\n" + warning.Width(m.contentWidth()-2).Render(" "+line) + "\n")
+	}
+
+	return boxStyle.Render(strings.TrimRight(s.String(), "\n")) + "\n"
+}
+
+func ratingStat(label string, stat analytics.RatingStat) string {
+	if stat.Count == 0 {
+		return label + " -"
+	}
+	return fmt.Sprintf("%s %.1f over %d", label, stat.Avg, stat.Count)
+}
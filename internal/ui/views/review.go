@@ -0,0 +1,161 @@
+package views
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/mamuzad/vidlogd/internal/report"
+	"github.com/mamuzad/vidlogd/internal/storage"
+	"github.com/mamuzad/vidlogd/internal/ui"
+)
+
+// reportDoneMsg reports an exported year in review
+type reportDoneMsg struct {
+	status string
+	err    error
+}
+
+type reportFormClosedMsg struct{}
+
+// buildReport summarizes the selected year. It gets the whole log, not the
+// dashboard's filtered videos, so new channels are told apart correctly.
+func (m StatsModel) buildReport() report.Report {
+	return report.New(m.videos, report.Year(m.reportYear))
+}
+
+// updateReport handles keys while the year in review is shown
+func (m StatsModel) updateReport(msg tea.KeyMsg) (StatsModel, tea.Cmd) {
+	switch {
+	case key.Matches(msg, ui.GlobalKeyMap.PrevPeriod):
+		m.reportYear--
+		m.reportStatus = ""
+	case key.Matches(msg, ui.GlobalKeyMap.NextPeriod):
+		m.reportYear++
+		m.reportStatus = ""
+	case key.Matches(msg, ui.GlobalKeyMap.Save):
+		m.openReportForm()
+	case key.Matches(msg, ui.GlobalKeyMap.Report, ui.GlobalKeyMap.Back, ui.GlobalKeyMap.Cancel, ui.GlobalKeyMap.SearchBack):
+		m.reportOpen = false
+		m.reportStatus = ""
+	}
+	return m, nil
+}
+
+// openReportForm asks where to export the report; a .html path writes a
+// web page, anything else Markdown
+func (m *StatsModel) openReportForm() {
+	r := m.buildReport()
+	fields := []FormField{
+		{Placeholder: "~/review.md or ~/review.html", Label: "File Path:", Required: true, CharLimit: 200, Width: 60, Type: FormFieldText, Value: defaultReportPath(m.reportYear)},
+	}
+	form := NewForm("export "+r.Title, fields, "export")
+	form.SetHandlers(
+		func(f FormModel) tea.Cmd {
+			path := f.Value(0)
+			return func() tea.Msg { return exportReport(path, r) }
+		},
+		func() tea.Cmd {
+			return func() tea.Msg { return reportFormClosedMsg{} }
+		},
+	)
+	m.reportForm = &form
+}
+
+func defaultReportPath(year int) string {
+	name := fmt.Sprintf("vidlogd-%d-review.md", year)
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return name
+	}
+	return filepath.Join(home, name)
+}
+
+func exportReport(path string, r report.Report) tea.Msg {
+	path = expandPath(path)
+
+	var buf bytes.Buffer
+	write := report.WriteMarkdown
+	if ext := strings.ToLower(filepath.Ext(path)); ext == ".html" || ext == ".htm" {
+		write = report.WriteHTML
+	}
+	if err := write(&buf, r); err != nil {
+		return reportDoneMsg{err: err}
+	}
+	if err := storage.WriteFileAtomic(path, buf.Bytes(), 0o644); err != nil {
+		return reportDoneMsg{err: err}
+	}
+	return reportDoneMsg{status: "wrote " + path}
+}
+
+// renderReport draws the year in review in place of the charts
+func (m StatsModel) renderReport() string {
+	boxStyle := lipgloss.NewStyle().
+		Border(ui.Border()).
+		BorderForeground(ui.PrimaryColor).
+		Padding(0, 1).
+		Margin(1, 0).
+		Width(m.boxWidth())
+
+	r := m.buildReport()
+	yearHelp := ui.GlobalKeyMap.PrevPeriod.Help().Key + " " + ui.GlobalKeyMap.NextPeriod.Help().Key
+
+	heading := lipgloss.NewStyle().Foreground(ui.PrimaryColor).Bold(true)
+
+	var s strings.Builder
+	s.WriteString(" " + heading.Render(r.Title) + " " + ui.DescriptionStyle.Padding(0).Render(yearHelp) + "\n\n")
+
+	if r.Total == 0 {
+		s.WriteString(" no videos logged in this period")
+		return boxStyle.Render(s.String()) + "\n"
+	}
+
+	for _, line := range r.Highlights() {
+		s.WriteString(" " + ui.Symbols.Bullet + " " + line + "\n")
+	}
+
+	section := func(title string) {
+		s.WriteString("\n " + heading.Render(title) + "\n")
+	}
+
+	if len(r.TopByCount) > 0 {
+		section("top channels")
+		for i, c := range r.TopByCount[:min(3, len(r.TopByCount))] {
+			s.WriteString(fmt.Sprintf(" %d. %-36s %3d videos\n", i+1, truncateString(c.Name, 34), c.Count))
+		}
+	}
+	if len(r.TopByRating) > 0 {
+		section("best rated channels")
+		for i, c := range r.TopByRating[:min(3, len(r.TopByRating))] {
+			s.WriteString(fmt.Sprintf(" %d. %-36s %.1f over %d\n", i+1, truncateString(c.Name, 34), c.AvgRating, c.Rated))
+		}
+	}
+	if len(r.TopVideos) > 0 {
+		section("highest rated")
+		for i, video := range r.TopVideos {
+			s.WriteString(fmt.Sprintf(" %d. %-40s %s\n", i+1, truncateString(orDefault(video.Title, "Untitled"), 38), ui.Stars(video.Rating)))
+		}
+	}
+	if len(r.NewChannels) > 0 {
+		section("new channels")
+		shown := r.NewChannels[:min(4, len(r.NewChannels))]
+		line := strings.Join(shown, ", ")
+		if more := len(r.NewChannels) - len(shown); more > 0 {
+			line += fmt.Sprintf(" and %d more", more)
+		}
+		s.WriteString(lipgloss.NewStyle().Width(m.contentWidth()-2).Render(" "+line) + "\n")
+	}
+
+	if m.reportStatus != "" {
+		s.WriteString("\n" + ui.DescriptionStyle.Render(m.reportStatus))
+	} else {
+		s.WriteString("\n" + ui.DescriptionStyle.Render(ui.GlobalKeyMap.Save.Help().Key+" to export markdown or html"))
+	}
+
+	return boxStyle.Render(strings.TrimRight(s.String(), "\n")) + "\n"
+}
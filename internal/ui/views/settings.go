@@ -1,13 +1,13 @@
 package views
 
 import (
+	"errors"
 	"fmt"
 	"io"
 
 	"github.com/charmbracelet/bubbles/key"
 	"github.com/charmbracelet/bubbles/list"
 	tea "github.com/charmbracelet/bubbletea"
-	"github.com/charmbracelet/lipgloss"
 	"github.com/mamuzad/vidlogd/internal/models"
 	"github.com/mamuzad/vidlogd/internal/ui"
 )
@@ -20,6 +20,9 @@ type SettingType int
 const (
 	VimMotionsToggle SettingType = iota
 	ThemeSelector
+	BackgroundSelector
+	SymbolsSelector
+	LayoutSelector
 	APIKeyEditor
 )
 
@@ -46,16 +49,12 @@ func (d SettingItemDelegate) Render(w io.Writer, m list.Model, index int, listIt
 
 	isSelected := index == m.Index()
 
-	var titleStyle, valueStyle lipgloss.Style
+	valueStyle := ui.MenuItemStyle.Foreground(ui.Gray)
 	if isSelected {
-		titleStyle = ui.MenuItemStyle.Background(ui.PrimaryColor).Foreground(ui.White)
 		valueStyle = ui.MenuItemStyle.Background(ui.PrimaryColor).Foreground(ui.White)
-	} else {
-		titleStyle = ui.MenuItemStyle
-		valueStyle = ui.MenuItemStyle.Foreground(ui.Gray)
 	}
 
-	title := titleStyle.Render(i.title)
+	title := ui.RenderItem(i.title, isSelected)
 	value := valueStyle.Render(fmt.Sprintf("[%s]", i.value))
 
 	line1 := title + " " + value
@@ -67,15 +66,20 @@ func (d SettingItemDelegate) Render(w io.Writer, m list.Model, index int, listIt
 type SettingsModel struct {
 	list list.Model
 	form *FormModel // for API key editing
+
+	themesErr error // theme files left out of the theme list
 }
 
 func NewSettingsModel(index int) SettingsModel {
 	Settings = models.LoadSettings()
 
-	ui.UpdateKeyMap()
-
 	displayAPIKey := renderAPIKey()
 
+	themes, themesErr := ui.ThemeNames()
+	background := orDefault(Settings.Background, "auto")
+	symbols := orDefault(Settings.Symbols, "auto")
+	layout := orDefault(Settings.Layout, "auto")
+
 	items := []list.Item{
 		SettingItem{
 			settingType: VimMotionsToggle,
@@ -87,9 +91,30 @@ func NewSettingsModel(index int) SettingsModel {
 		SettingItem{
 			settingType: ThemeSelector,
 			title:       "Theme",
-			description: "color theme for the vidlogd",
+			description: "built-in or user color theme",
 			value:       Settings.Theme,
-			options:     []string{"red", "blue", "green", "purple", "orange", "teal", "pink"},
+			options:     themes,
+		},
+		SettingItem{
+			settingType: BackgroundSelector,
+			title:       "Background",
+			description: "light or dark variant of the theme",
+			value:       background,
+			options:     []string{"auto", "dark", "light"},
+		},
+		SettingItem{
+			settingType: SymbolsSelector,
+			title:       "Symbols",
+			description: "unicode glyphs or plain ascii",
+			value:       symbols,
+			options:     []string{"auto", "unicode", "ascii"},
+		},
+		SettingItem{
+			settingType: LayoutSelector,
+			title:       "Layout",
+			description: "centered boxes or linear for screen readers",
+			value:       layout,
+			options:     []string{"auto", "centered", "linear"},
 		},
 		SettingItem{
 			settingType: APIKeyEditor,
@@ -101,21 +126,26 @@ func NewSettingsModel(index int) SettingsModel {
 	}
 
 	const defaultWidth = 40
-	const listHeight = 16
+	const listHeight = 22
 
 	l := list.New(items, SettingItemDelegate{}, defaultWidth, listHeight)
+	ui.SetupList(&l)
 	l.SetShowStatusBar(false)
 	l.SetFilteringEnabled(false)
 	l.SetShowTitle(false)
 	l.SetShowHelp(true)
-	l.KeyMap.Quit.SetKeys()
-	l.KeyMap.Quit.SetHelp("", "")
 
 	return SettingsModel{
-		list: l,
+		list:      l,
+		themesErr: themesErr,
 	}
 }
 
+// RefreshStyles applies a changed key map or symbols mode to the list
+func (m *SettingsModel) RefreshStyles() {
+	ui.SetupList(&m.list)
+}
+
 func getBoolString(value bool) string {
 	if value {
 		return "enabled"
@@ -124,7 +154,7 @@ func getBoolString(value bool) string {
 }
 
 func (m SettingsModel) Init() tea.Cmd {
-	return nil
+	return ui.ReportError("load themes", m.themesErr)
 }
 
 func (m SettingsModel) Update(msg tea.Msg) (SettingsModel, tea.Cmd) {
@@ -144,6 +174,13 @@ func (m SettingsModel) Update(msg tea.Msg) (SettingsModel, tea.Cmd) {
 		m.list.SetItems(items)
 		return m, nil
 
+	case settingsFormErrMsg:
+		// keep the form open so the key is not lost
+		if m.form != nil {
+			m.form.SetError(msg.err.Error())
+		}
+		return m, ui.ReportError("save settings", msg.err)
+
 	case tea.WindowSizeMsg:
 		m.list.SetWidth(msg.Width)
 		return m, nil
@@ -198,16 +235,29 @@ func (m SettingsModel) cycleSetting() (SettingsModel, tea.Cmd) {
 	switch selectedItem.settingType {
 	case VimMotionsToggle:
 		Settings.VimMotions = newValue == "enabled"
-		ui.UpdateKeyMap()
 	case ThemeSelector:
 		Settings.Theme = newValue
-		ApplyTheme(Settings.Theme)
-		cmd = func() tea.Msg { return ui.UIRefreshMsg{} }
+		cmd = tea.Batch(ui.ReportError("apply theme", ApplyTheme(Settings.Theme)), func() tea.Msg { return ui.UIRefreshMsg{} })
+	case BackgroundSelector:
+		Settings.Background = newValue
+		cmd = tea.Batch(ui.ReportError("apply theme", ApplyTheme(Settings.Theme)), func() tea.Msg { return ui.UIRefreshMsg{} })
+	case SymbolsSelector:
+		Settings.Symbols = newValue
+		ui.SetAccessibility(Settings.Symbols, Settings.Layout)
+		cmd = tea.Batch(ui.ReportError("apply theme", ApplyTheme(Settings.Theme)), func() tea.Msg { return ui.UIRefreshMsg{} })
+	case LayoutSelector:
+		Settings.Layout = newValue
+		ui.SetAccessibility(Settings.Symbols, Settings.Layout)
+		cmd = tea.Batch(ui.ReportError("apply theme", ApplyTheme(Settings.Theme)), func() tea.Msg { return ui.UIRefreshMsg{} })
 	}
 
 	// save settings to file
 	if err := models.SaveSettings(Settings); err != nil {
-		// TODO: add error ui
+		cmd = tea.Batch(cmd, ui.ReportError("save settings", err))
+	}
+	if selectedItem.settingType == VimMotionsToggle || selectedItem.settingType == SymbolsSelector {
+		// the key map reads the saved setting, and spells out arrows in ascii
+		cmd = tea.Batch(cmd, ui.ReportError("load keybindings", ui.UpdateKeyMap()), func() tea.Msg { return ui.UIRefreshMsg{} })
 	}
 
 	// update the list item
@@ -239,11 +289,12 @@ func (m SettingsModel) handleSettingSelection() (SettingsModel, tea.Cmd) {
 		form := NewForm("YouTube API Key", fields, "save")
 		form.SetHandlers(
 			func(f FormModel) tea.Cmd {
-				apiKeyValue := f.Value(0)
-				Settings.APIKey = apiKeyValue
-				if err := models.SaveSettings(Settings); err != nil {
-					// TODO: handle error
+				updated := Settings
+				updated.APIKey = f.Value(0)
+				if err := models.SaveSettings(updated); err != nil {
+					return func() tea.Msg { return settingsFormErrMsg{err: err} }
 				}
+				Settings = updated
 
 				return func() tea.Msg {
 					return ClearSettingsFormMsg{}
@@ -273,35 +324,29 @@ func (m SettingsModel) View() string {
 	return ui.CenterHorizontally(content, m.list.Width())
 }
 
-// load and apply all settings at startup
-func LoadAndApplySettings() {
+// load and apply all settings at startup, returning keybinding and theme
+// errors
+func LoadAndApplySettings() error {
 	Settings = models.LoadSettings()
-	ui.UpdateKeyMap()
-	ApplyTheme(Settings.Theme)
+	ui.SetAccessibility(Settings.Symbols, Settings.Layout)
+	return errors.Join(ui.UpdateKeyMap(), ApplyTheme(Settings.Theme))
 }
 
-// update theme color given basic color
-func ApplyTheme(theme string) {
-	switch theme {
-	case "blue":
-		ui.SetThemeColors(ui.Blue, ui.BlueBg)
-	case "green":
-		ui.SetThemeColors(ui.Green, ui.GreenBg)
-	case "purple":
-		ui.SetThemeColors(ui.Purple, ui.PurpleBg)
-	case "orange":
-		ui.SetThemeColors(ui.Orange, ui.OrangeBg)
-	case "teal":
-		ui.SetThemeColors(ui.Teal, ui.TealBg)
-	case "pink":
-		ui.SetThemeColors(ui.Pink, ui.PinkBg)
-	default: // red
-		ui.SetThemeColors(ui.Red, ui.RedBg)
-	}
+// ApplyTheme switches to a built-in or user theme for the configured
+// background, falling back to the default theme on error
+func ApplyTheme(theme string) error {
+	t, err := ui.FindTheme(theme)
+	ui.SetTheme(t, ui.DarkBackground(Settings.Background))
+	return err
 }
 
 type ClearSettingsFormMsg struct{}
 
+// settingsFormErrMsg reports a failed save from the API key form
+type settingsFormErrMsg struct {
+	err error
+}
+
 func renderAPIKey() (apiKey string) {
 	apiKey = Settings.APIKey
 	if apiKey != "" {
@@ -3,7 +3,6 @@ package views
 import (
 	"fmt"
 	"io"
-	"sort"
 	"strings"
 	"time"
 
@@ -13,13 +12,16 @@ import (
 	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/mamuzad/vidlogd/internal/analytics"
 	"github.com/mamuzad/vidlogd/internal/models"
 	"github.com/mamuzad/vidlogd/internal/ui"
+	"github.com/mamuzad/vidlogd/internal/ui/chart"
 	"github.com/sahilm/fuzzy"
 )
 
 type ChannelItem struct {
-	channel string
+	channel  string
+	favorite bool
 }
 
 func (i ChannelItem) FilterValue() string { return i.channel }
@@ -27,6 +29,9 @@ func (i ChannelItem) Title() string {
 	if i.channel == "" {
 		return "all channels"
 	}
+	if i.favorite {
+		return i.channel + " " + ui.Symbols.Favorite
+	}
 	return i.channel
 }
 func (i ChannelItem) Description() string { return "" }
@@ -46,29 +51,48 @@ type StatsModel struct {
 	channelSelect     list.Model
 	videoList         list.Model
 	availableChannels []string
+	channels          []models.ChannelInfo
 	filtered          []models.Video
 	isFiltered        bool
 	focusedSearch     int // 0 = none, 1 = title, 2 = channel, 3 = video list
 	lastFocused       int // 0 = none, 1 = title, 2 = channel, 3 = video list
-	viewMode          int // 0 = rating, 1 = monthly, 2 = video list, 3 = video details
-}
-
-type ChannelStats struct {
-	Channel    string
-	Count      int
-	AvgRating  float64
-	TotalRated int
+	viewMode          int // 0 = rating, 1 = monthly, 2 = video list, 3 = heatmap, 4-7 = viewing patterns
+
+	tabs filterTabs // saved filters scoping the dashboard
+
+	// calendar heatmap
+	heatmapDay    time.Time // selected day, local midnight
+	heatmapActive bool      // arrow keys move between days
+	dayList       list.Model
+	dayListOpen   bool
+
+	// year in review
+	reportOpen   bool
+	reportYear   int
+	reportForm   *FormModel
+	reportStatus string
+
+	// rating trends
+	ratingsOpen   bool
+	ratingsOffset int
+
+	// viewing goals
+	goals      []models.Goal
+	goalsOpen  bool
+	goalCursor int
+	goalForm   *FormModel
+	goalsErr   string
+
+	clock analytics.Clock
+	width int
+
+	// activity chart window
+	granularity analytics.Granularity
+	periodEnd   time.Time // zero for a window ending now
 }
 
-type MonthStats struct {
-	Month string
-	Count int
-}
-
-type StreakInfo struct {
-	VideoCount int
-	DaySpan    int
-}
+// number of chart views cycled with left and right
+const statsViewModes = 8
 
 type VideoListDelegate struct{}
 
@@ -83,23 +107,26 @@ func (d VideoListDelegate) Render(w io.Writer, m list.Model, index int, item lis
 	title := truncateString(videoItem.video.Title, 43)
 	stars := renderStars(videoItem.video.Rating)
 	style := lipgloss.NewStyle().Margin(0).Padding(0, 2)
+	// use fixed-width format to ensure perfect alignment
+	line := fmt.Sprintf("%-43.43s%6s", title, stars)
 	if index == m.Index() {
 		style = ui.TableSelectedRowStyle.Margin(0).Padding(0, 2)
+		if ui.Linear {
+			style, line = style.PaddingLeft(0), "> "+line
+		}
 	}
-
-	// use fixed-width format to ensure perfect alignment
-	line := fmt.Sprintf("%-43.43s%6s", title, stars)
 	fmt.Fprint(w, style.Render(line))
 }
 
 func NewStatsModel() StatsModel {
 	titleSearch := textinput.New()
 	titleSearch.Placeholder = "search videos..."
-	titleSearch.Prompt = "  "
+	titleSearch.Prompt = ui.Icon(ui.Symbols.SearchIcon, "  ")
 	titleSearch.CharLimit = 50
 	titleSearch.Width = 50
 
 	channelSelect := list.New([]list.Item{}, list.NewDefaultDelegate(), 50, 1)
+	ui.SetupList(&channelSelect)
 	channelSelect.SetShowStatusBar(false)
 	channelSelect.SetFilteringEnabled(false)
 	channelSelect.SetShowTitle(false)
@@ -113,17 +140,26 @@ func NewStatsModel() StatsModel {
 	delegate.Styles.NormalDesc = delegate.Styles.NormalDesc.Margin(0, 0).Padding(0, 0)
 
 	videoList := list.New([]list.Item{}, VideoListDelegate{}, 50, 12)
+	ui.SetupList(&videoList)
 	videoList.SetShowStatusBar(false)
 	videoList.SetFilteringEnabled(false)
 	videoList.SetShowTitle(false)
 	videoList.SetShowHelp(false)
 	videoList.SetShowPagination(false)
-	videoList.KeyMap.Quit.SetKeys()
-	videoList.KeyMap.Quit.SetHelp("", "")
 
-	h := help.New()
+	dayList := list.New([]list.Item{}, VideoListDelegate{}, 50, 5)
+	ui.SetupList(&dayList)
+	dayList.SetShowStatusBar(false)
+	dayList.SetFilteringEnabled(false)
+	dayList.SetShowTitle(false)
+	dayList.SetShowHelp(false)
+	dayList.SetShowPagination(false)
+
+	h := ui.NewHelp()
 	h.ShowAll = false
 
+	clock := analytics.SystemClock
+
 	return StatsModel{
 		help:          h,
 		titleSearch:   titleSearch,
@@ -134,58 +170,98 @@ func NewStatsModel() StatsModel {
 		focusedSearch: 0,
 		lastFocused:   0,
 		viewMode:      0,
+		heatmapDay:    analytics.DayOf(clock()),
+		reportYear:    clock().Year(),
+		clock:         clock,
+		granularity:   analytics.Month,
+		dayList:       dayList,
 	}
 }
 
+// RefreshStyles applies a changed key map or symbols mode to the lists
+func (m *StatsModel) RefreshStyles() {
+	ui.SetupList(&m.channelSelect)
+	ui.SetupList(&m.videoList)
+	ui.SetupList(&m.dayList)
+}
+
+// currentVideos returns the videos the dashboard is showing
+func (m StatsModel) currentVideos() []models.Video {
+	if m.isFiltered {
+		return m.filtered
+	}
+	return m.videos
+}
+
+// SetSize fits the charts to the window
+func (m *StatsModel) SetSize(width, height int) {
+	m.width = width
+}
+
+// Status describes the dashboard's filters for the status line
+func (m StatsModel) Status() ui.ViewStatus {
+	if !m.isFiltered {
+		return ui.ViewStatus{}
+	}
+	var parts []string
+	if f, ok := m.tabs.current(); ok {
+		parts = append(parts, f.Name)
+	}
+	if q := strings.TrimSpace(m.titleSearch.Value()); q != "" {
+		parts = append(parts, fmt.Sprintf("%q", q))
+	}
+	if c := m.getSelectedChannel(); c != "" {
+		parts = append(parts, "channel "+c)
+	}
+	return ui.ViewStatus{Filter: strings.Join(parts, " + "), Shown: len(m.filtered)}
+}
+
 func (m StatsModel) Init() tea.Cmd {
 	return tea.Batch(
 		textinput.Blink,
 		func() tea.Msg {
-			videos, err := models.LoadVideos()
+			videos, err := models.LoadMergedVideos()
 			if err != nil {
-				return err
+				return ui.ErrorMsg{Context: "load videos", Err: err}
 			}
 			return LoadVideosMsg{videos: videos}
 		},
+		loadFilters,
+		loadGoals,
+		loadChannelInfo,
 	)
 }
 
-func (m *StatsModel) updateChannelList() {
-	// get unique channels with their counts
-	channelMap := make(map[string]int)
-	for _, video := range m.videos {
-		channel := getVideoChannel(video)
-		channelMap[channel]++
-	}
+// channelInfoMsg carries the saved channel notes and favorites
+type channelInfoMsg struct {
+	channels []models.ChannelInfo
+	err      error
+}
 
-	// convert to slice and sort by count (most logged first)
-	type channelCount struct {
-		name  string
-		count int
-	}
+func loadChannelInfo() tea.Msg {
+	channels, err := models.LoadChannels()
+	return channelInfoMsg{channels: channels, err: err}
+}
 
-	var channelCounts []channelCount
-	for channel, count := range channelMap {
-		channelCounts = append(channelCounts, channelCount{name: channel, count: count})
+// openChannel shows the channel page for channel, or for the channel with
+// the given YouTube ID when known
+func openChannel(channel, channelID string) tea.Cmd {
+	return func() tea.Msg {
+		return ui.NavigateMsg{View: ui.ChannelView, State: ui.ChannelRouteState{Channel: channel, ChannelID: channelID}}
 	}
+}
 
-	// sort by count, then by name for ties
-	sort.Slice(channelCounts, func(i, j int) bool {
-		if channelCounts[i].count == channelCounts[j].count {
-			return channelCounts[i].name < channelCounts[j].name
-		}
-		return channelCounts[i].count > channelCounts[j].count
-	})
-
+func (m *StatsModel) updateChannelList() {
+	// most logged first
 	channels := []string{""}
-	for _, cc := range channelCounts {
-		channels = append(channels, cc.name)
+	for _, stats := range analytics.Channels(m.videos) {
+		channels = append(channels, stats.Channel)
 	}
 
 	// update list items
 	items := make([]list.Item, len(channels))
 	for i, channel := range channels {
-		items[i] = ChannelItem{channel: channel}
+		items[i] = ChannelItem{channel: channel, favorite: models.FindChannel(m.channels, channel).Favorite}
 	}
 
 	m.channelSelect.SetItems(items)
@@ -204,10 +280,12 @@ func (m *StatsModel) getSelectedChannel() string {
 func (m *StatsModel) filterStats() {
 	titleQuery := strings.TrimSpace(m.titleSearch.Value())
 	selectedChannel := m.getSelectedChannel()
+	_, hasSavedFilter := m.tabs.current()
+	base := m.tabs.apply(m.videos)
 
 	if titleQuery == "" && selectedChannel == "" {
-		m.isFiltered = false
-		m.filtered = m.videos
+		m.isFiltered = hasSavedFilter
+		m.filtered = base
 		m.updateVideoList()
 		return
 	}
@@ -215,7 +293,7 @@ func (m *StatsModel) filterStats() {
 	m.isFiltered = true
 	m.filtered = []models.Video{}
 
-	for _, video := range m.videos {
+	for _, video := range base {
 		matchesTitle := true
 		matchesChannel := true
 
@@ -228,7 +306,7 @@ func (m *StatsModel) filterStats() {
 
 		// make sure log is from selected channel
 		if selectedChannel != "" {
-			videoChannel := getVideoChannel(video)
+			videoChannel := analytics.ChannelName(video)
 			matchesChannel = videoChannel == selectedChannel
 		}
 
@@ -239,233 +317,34 @@ func (m *StatsModel) filterStats() {
 	m.updateVideoList()
 }
 
-func (m *StatsModel) calculateStats() (
-	totalVideos int, avgRating float64, totalRated int,
-	rewatchCount int, channelStats []ChannelStats,
-	monthStats []MonthStats, ratingDist map[float64]int,
-) {
-	videosToUse := m.videos
-	if m.isFiltered {
-		videosToUse = m.filtered
-	}
-
-	totalVideos = len(videosToUse)
-	if totalVideos == 0 {
-		return
-	}
-
-	var totalRatingSum float64
-	channelMap := make(map[string]*ChannelStats)
-	monthMap := make(map[string]int)
-	ratingDist = make(map[float64]int)
-
-	// init rating distribution
-	for i := 1.0; i <= 5.0; i += 0.5 {
-		ratingDist[i] = 0
-	}
-
-	for _, video := range videosToUse {
-		if video.Rating > 0 {
-			totalRatingSum += video.Rating
-			totalRated++
-			ratingDist[video.Rating]++
-		}
-
-		if video.Rewatched {
-			rewatchCount++
-		}
-
-		// channel stats
-		channel := getVideoChannel(video)
-		if _, exists := channelMap[channel]; !exists {
-			channelMap[channel] = &ChannelStats{Channel: channel}
-		}
-		stats := channelMap[channel]
-		stats.Count++
-		if video.Rating > 0 {
-			stats.TotalRated++
-			currentSum := stats.AvgRating * float64(stats.TotalRated-1)
-			stats.AvgRating = (currentSum + video.Rating) / float64(stats.TotalRated)
-		}
-
-		// month stats
-		if !video.LogDate.IsZero() {
-			monthKey := video.LogDate.Format(models.MonthFormat)
-			monthMap[monthKey]++
-		}
-	}
-
-	if totalRated > 0 {
-		avgRating = totalRatingSum / float64(totalRated)
-	}
-
-	// convert and sort channel stats
-	for _, stats := range channelMap {
-		channelStats = append(channelStats, *stats)
-	}
-	// most logged first
-	sort.Slice(channelStats, func(i, j int) bool {
-		if channelStats[i].Count == channelStats[j].Count {
-			if channelStats[i].AvgRating == channelStats[j].AvgRating {
-				return channelStats[i].Channel < channelStats[j].Channel
-			}
-			return channelStats[i].AvgRating > channelStats[j].AvgRating
-		}
-		return channelStats[i].Count > channelStats[j].Count
-	})
-
-	// convert and sort month stats
-	for month, count := range monthMap {
-		monthStats = append(monthStats, MonthStats{Month: month, Count: count})
-	}
-	// most recent first
-	sort.Slice(monthStats, func(i, j int) bool {
-		return monthStats[i].Month > monthStats[j].Month
-	})
-
-	return
-}
-
-func (m *StatsModel) renderStars(rating float64) string {
-	ratingStr := ""
-	if rating > 0 {
-		for j := 1; j <= 5; j++ {
-			starValue := float64(j)
-			if rating >= starValue {
-				ratingStr += "★" // filled star
-			} else if rating >= starValue-0.5 {
-				ratingStr += "⯨" // half star
-			}
-		}
-	}
-
-	return ratingStr
-}
-
-func (m *StatsModel) getStreaks() (StreakInfo, StreakInfo) {
-	videosToUse := m.videos
-	if m.isFiltered {
-		videosToUse = m.filtered
-	}
-
-	if len(videosToUse) == 0 {
-		return StreakInfo{0, 0}, StreakInfo{0, 0}
-	}
-
-	var dateGroups []struct {
-		date  time.Time
-		count int
-	}
-
-	for _, video := range videosToUse {
-		if !video.LogDate.IsZero() {
-			continue
-		}
-		// truncate to date for comparison
-		logDate := video.LogDate.Truncate(24 * time.Hour)
-
-		// date exists already
-		if len(dateGroups) > 0 && dateGroups[len(dateGroups)-1].date.Equal(logDate) {
-			dateGroups[len(dateGroups)-1].count++
-		} else {
-			dateGroups = append(dateGroups, struct {
-				date  time.Time
-				count int
-			}{logDate, 1})
-		}
-	}
-
-	if len(dateGroups) == 0 {
-		return StreakInfo{0, 0}, StreakInfo{0, 0}
-	}
-
-	// calculate current streak
-	var currentStreak StreakInfo
-	today := time.Now().Truncate(24 * time.Hour)
-	mostRecentDate := dateGroups[0].date
-
-	// check if recent date is today or yesterday
-	daysSinceLastWatch := int(today.Sub(mostRecentDate).Hours() / 24)
-	if daysSinceLastWatch <= 1 {
-		currentStreak.VideoCount = dateGroups[0].count
-		currentStreak.DaySpan = 1
-
-		for i := 1; i < len(dateGroups); i++ {
-			prevDate := dateGroups[i-1].date
-			currDate := dateGroups[i].date
-
-			// check if dates are consecutive
-			daysDiff := int(prevDate.Sub(currDate).Hours() / 24)
-			if daysDiff == 1 {
-				currentStreak.VideoCount += dateGroups[i].count
-				currentStreak.DaySpan++
-			} else {
-				break
-			}
-		}
-	}
-
-	// calculate best streak
-	var longestStreak StreakInfo
-	var tempStreak StreakInfo
-
-	for i := range dateGroups {
-		if i == 0 {
-			tempStreak = StreakInfo{VideoCount: dateGroups[i].count, DaySpan: 1}
-		} else {
-			prevDate := dateGroups[i-1].date
-			currDate := dateGroups[i].date
-
-			// check if dates are consecutive
-			daysDiff := int(prevDate.Sub(currDate).Hours() / 24)
-			if daysDiff == 1 {
-				tempStreak.VideoCount += dateGroups[i].count
-				tempStreak.DaySpan++
-			} else {
-				// streak broken
-				if tempStreak.VideoCount > longestStreak.VideoCount {
-					longestStreak = tempStreak
-				}
-				tempStreak = StreakInfo{VideoCount: dateGroups[i].count, DaySpan: 1}
-			}
-		}
-	}
-	if tempStreak.VideoCount > longestStreak.VideoCount {
-		longestStreak = tempStreak
-	}
-
-	return currentStreak, longestStreak
-}
-
-func (m *StatsModel) getDasboardStrings(totalVideos int, avgRating float64,
-	totalRated int, rewatchCount int, channelStats []ChannelStats,
-) (string, string, string, string) {
-	totalCard := fmt.Sprintf(" Videos\n%d total", totalVideos)
+func (m *StatsModel) getDasboardStrings(summary analytics.Summary) (string, string, string, string) {
+	totalCard := fmt.Sprintf("%sVideos\n%d total", ui.Icon(ui.Symbols.VideoIcon, " "), summary.Total)
 	avgCard := ""
-	if totalRated > 0 {
-		avgCard = fmt.Sprintf(" Rating\n%.1f/5", avgRating)
+	if summary.Rated > 0 {
+		avgCard = fmt.Sprintf("%sRating\n%.1f/5", ui.Icon(ui.Symbols.StarIcon, " "), summary.AvgRating)
 	} else {
-		avgCard = " Rating\n"
+		avgCard = ui.Icon(ui.Symbols.StarIcon, " ") + "Rating\n"
 	}
-	rewatchCard := fmt.Sprintf(" Rewatch\n%.0f%%", float64(rewatchCount)/float64(totalVideos)*100)
+	rewatchCard := fmt.Sprintf("%sRewatch\n%.0f%%", ui.Icon(ui.Symbols.RewatchIcon, " "), summary.RewatchShare()*100)
 	channelCountCard := ""
 	if m.getSelectedChannel() != "" {
 		selectedChannel := m.getSelectedChannel()
 		// find the selected channel's stats
 		var channelInfo string
-		for _, stats := range channelStats {
+		for _, stats := range summary.Channels {
 			if stats.Channel == selectedChannel {
 				if stats.TotalRated > 0 {
-					channelInfo = fmt.Sprintf(" %d (%.1f )", stats.Count, stats.AvgRating)
+					channelInfo = fmt.Sprintf("%s%d (%.1f%s)", ui.Icon(ui.Symbols.VideoIcon, " "), stats.Count, stats.AvgRating,
+						ui.Icon(ui.Symbols.StarIcon, " "))
 				} else {
-					channelInfo = fmt.Sprintf(" %d", stats.Count)
+					channelInfo = fmt.Sprintf("%s%d", ui.Icon(ui.Symbols.VideoIcon, " "), stats.Count)
 				}
 				break
 			}
 		}
-		channelCountCard = fmt.Sprintf(" Channel\n%s", channelInfo)
+		channelCountCard = fmt.Sprintf("%sChannel\n%s", ui.Icon(ui.Symbols.ChannelIcon, " "), channelInfo)
 	} else {
-		channelCountCard = fmt.Sprintf(" Channels\n%d unique", len(channelStats))
+		channelCountCard = fmt.Sprintf("%sChannels\n%d unique", ui.Icon(ui.Symbols.ChannelIcon, " "), len(summary.Channels))
 	}
 
 	return totalCard, avgCard, rewatchCard, channelCountCard
@@ -473,24 +352,33 @@ func (m *StatsModel) getDasboardStrings(totalVideos int, avgRating float64,
 
 func (m *StatsModel) renderDashboardCards(str1 string, str2 string, str3 *string, str4 *string) string {
 	cardStyle := lipgloss.NewStyle().
-		Border(lipgloss.RoundedBorder()).
+		Border(ui.Border()).
 		Padding(0, 1).
 		Height(2)
 
+	// cards share the width of the boxes below, less their own borders
 	if str3 == nil || str4 == nil {
-		card1 := cardStyle.Width(26).Render(str1)
-		card2 := cardStyle.Width(28).Render(str2)
+		first, second := m.halves()
+		card1 := cardStyle.Width(first).Render(str1)
+		card2 := cardStyle.Width(second).Render(str2)
 		return lipgloss.JoinHorizontal(lipgloss.Top, card1, card2)
 	}
 
-	card1 := cardStyle.Width(12).Render(str1)
-	card2 := cardStyle.Width(12).Render(str2)
-	card3 := cardStyle.Width(12).Render(*str3)
-	card4 := cardStyle.Width(14).Render(*str4)
+	quarter := (m.boxWidth() - 6) / 4
+	card1 := cardStyle.Width(quarter).Render(str1)
+	card2 := cardStyle.Width(quarter).Render(str2)
+	card3 := cardStyle.Width(quarter).Render(*str3)
+	card4 := cardStyle.Width(m.boxWidth() - 6 - 3*quarter).Render(*str4)
 
 	return lipgloss.JoinHorizontal(lipgloss.Top, card1, card2, card3, card4)
 }
 
+// halves splits the box width between two bordered boxes side by side
+func (m StatsModel) halves() (int, int) {
+	first := (m.boxWidth()-2)/2 - 1
+	return first, m.boxWidth() - 2 - first
+}
+
 func (m *StatsModel) updateVideoList() {
 	videosToUse := m.videos
 	if m.isFiltered {
@@ -503,6 +391,11 @@ func (m *StatsModel) updateVideoList() {
 	}
 
 	m.videoList.SetItems(items)
+
+	// keep the heatmap drill down in step with the filters
+	if m.dayListOpen {
+		m.refreshDayList()
+	}
 }
 
 func (m *StatsModel) setFocus(target int) {
@@ -565,7 +458,80 @@ func (m StatsModel) Update(msg tea.Msg) (StatsModel, tea.Cmd) {
 		}
 
 		m.filterStats()
+	case FiltersLoadedMsg:
+		if msg.err != nil {
+			return m, ui.ReportError("load filters", msg.err)
+		}
+		m.tabs.set(msg.filters, "")
+		m.filterStats()
+	case channelInfoMsg:
+		if msg.err != nil {
+			return m, ui.ReportError("load channels", msg.err)
+		}
+		m.channels = msg.channels
+		selected := m.channelSelect.Index()
+		m.updateChannelList()
+		m.channelSelect.Select(selected)
+	case reportDoneMsg:
+		if msg.err != nil {
+			if m.reportForm != nil {
+				m.reportForm.SetError(msg.err.Error())
+			}
+			return m, nil
+		}
+		m.reportForm = nil
+		m.reportStatus = msg.status
+		return m, nil
+	case reportFormClosedMsg:
+		m.reportForm = nil
+		return m, nil
+	case goalsLoadedMsg:
+		if msg.err != nil {
+			if m.goalForm != nil {
+				m.goalForm.SetError(msg.err.Error())
+			} else {
+				m.goalsErr = msg.err.Error()
+			}
+			return m, nil
+		}
+		m.goalForm = nil
+		m.goalsErr = ""
+		m.goals = msg.goals
+		m.goalCursor = max(0, min(m.goalCursor, len(m.goals)-1))
+		return m, nil
+	case goalFormErrMsg:
+		if m.goalForm != nil {
+			m.goalForm.SetError(msg.err.Error())
+		}
+		return m, nil
+	case goalFormClosedMsg:
+		m.goalForm = nil
+		return m, nil
+	}
+
+	if m.reportForm != nil {
+		form, cmd := m.reportForm.Update(msg)
+		m.reportForm = &form
+		return m, cmd
+	}
+	if m.goalForm != nil {
+		form, cmd := m.goalForm.Update(msg)
+		m.goalForm = &form
+		return m, cmd
+	}
+
+	switch msg := msg.(type) {
 	case tea.KeyMsg:
+		if m.reportOpen {
+			return m.updateReport(msg)
+		}
+		if m.ratingsOpen {
+			return m.updateRatings(msg)
+		}
+		if m.goalsOpen {
+			return m.updateGoals(msg)
+		}
+
 		switch {
 		case key.Matches(msg, ui.GlobalKeyMap.Help):
 			m.help.ShowAll = !m.help.ShowAll
@@ -577,6 +543,8 @@ func (m StatsModel) Update(msg tea.Msg) (StatsModel, tea.Cmd) {
 		case key.Matches(msg, ui.GlobalKeyMap.CycleBack):
 			next := m.cycleField(&m.focusedSearch, false, 3)
 			m.setFocus(next)
+		case m.focusedSearch == 0 && m.viewMode == 3 && m.heatmapActive:
+			return m.updateHeatmap(msg)
 		case m.focusedSearch == 1: // title search
 
 			if key.Matches(msg, ui.GlobalKeyMap.SearchBack) {
@@ -599,6 +567,12 @@ func (m StatsModel) Update(msg tea.Msg) (StatsModel, tea.Cmd) {
 			if key.Matches(msg, ui.GlobalKeyMap.Left) || key.Matches(msg, ui.GlobalKeyMap.Right) {
 				return m, nil
 			}
+			if key.Matches(msg, ui.GlobalKeyMap.Select, ui.GlobalKeyMap.Channel) {
+				if channel := m.getSelectedChannel(); channel != "" {
+					return m, openChannel(channel, "")
+				}
+				return m, nil
+			}
 			m.channelSelect, channelCmd = m.channelSelect.Update(msg)
 			m.filterStats()
 			m.updateVideoList()
@@ -608,9 +582,39 @@ func (m StatsModel) Update(msg tea.Msg) (StatsModel, tea.Cmd) {
 			case key.Matches(msg, ui.GlobalKeyMap.Back, ui.GlobalKeyMap.Cancel):
 				return m, func() tea.Msg { return ui.BackMsg{} }
 			case key.Matches(msg, ui.GlobalKeyMap.Left): // switch between chart views
-				m.viewMode = m.cycleField(&m.viewMode, false, 3)
+				m.viewMode = m.cycleField(&m.viewMode, false, statsViewModes)
 			case key.Matches(msg, ui.GlobalKeyMap.Right):
-				m.viewMode = m.cycleField(&m.viewMode, true, 3)
+				m.viewMode = m.cycleField(&m.viewMode, true, statsViewModes)
+			case key.Matches(msg, ui.GlobalKeyMap.NextFilter), key.Matches(msg, ui.GlobalKeyMap.PrevFilter):
+				m.tabs.cycle(key.Matches(msg, ui.GlobalKeyMap.NextFilter))
+				m.filterStats()
+				m.videoList.Select(0)
+			case key.Matches(msg, ui.GlobalKeyMap.Report):
+				m.reportOpen = true
+			case key.Matches(msg, ui.GlobalKeyMap.Ratings):
+				m.ratingsOpen = true
+			case key.Matches(msg, ui.GlobalKeyMap.Goals):
+				m.goalsOpen = true
+			case key.Matches(msg, ui.GlobalKeyMap.Channel) && m.getSelectedChannel() != "":
+				return m, openChannel(m.getSelectedChannel(), "")
+			case m.viewMode == 1: // activity
+				switch {
+				case key.Matches(msg, ui.GlobalKeyMap.Period):
+					m.cycleGranularity()
+				case key.Matches(msg, ui.GlobalKeyMap.PrevPeriod):
+					m.scrollActivity(-1)
+				case key.Matches(msg, ui.GlobalKeyMap.NextPeriod):
+					m.scrollActivity(1)
+				}
+			case m.viewMode == 3: // heatmap
+				switch {
+				case key.Matches(msg, ui.GlobalKeyMap.Select):
+					m.heatmapActive = true
+				case key.Matches(msg, ui.GlobalKeyMap.PrevPeriod):
+					m.moveHeatmapYear(-1)
+				case key.Matches(msg, ui.GlobalKeyMap.NextPeriod):
+					m.moveHeatmapYear(1)
+				}
 			case m.viewMode == 2: // video list
 				switch {
 				case key.Matches(msg, ui.GlobalKeyMap.Up), key.Matches(msg, ui.GlobalKeyMap.Down):
@@ -643,12 +647,23 @@ func (m StatsModel) Update(msg tea.Msg) (StatsModel, tea.Cmd) {
 }
 
 func (m StatsModel) View() string {
+	if m.reportForm != nil {
+		return m.reportForm.View()
+	}
+	if m.goalForm != nil {
+		return m.goalForm.View()
+	}
+
 	var s strings.Builder
 
 	s.WriteString(ui.HeaderStyle.Render("video stats") + "\n")
+	if tabs := m.tabs.View(); tabs != "" {
+		s.WriteString("\n" + tabs + "\n")
+	}
 
-	searchBoxStyle := ui.SearchStyle.Width(26)
-	channelSelectStyle := ui.SearchStyle.Width(28)
+	searchWidth, channelWidth := m.halves()
+	searchBoxStyle := ui.SearchStyle.Width(searchWidth)
+	channelSelectStyle := ui.SearchStyle.Width(channelWidth)
 	// apply focus styling
 	if m.focusedSearch == 1 {
 		searchBoxStyle = searchBoxStyle.BorderForeground(ui.PrimaryColor)
@@ -660,10 +675,10 @@ func (m StatsModel) View() string {
 	// search box content
 	searchBox := searchBoxStyle.Render(m.titleSearch.View())
 	// channel select content
-	channelSelectContent := " all channels"
+	channelSelectContent := ui.Icon(ui.Symbols.ChannelIcon, " ") + "all channels"
 	if selectedItem := m.channelSelect.SelectedItem(); selectedItem != nil {
 		if channelItem, ok := selectedItem.(ChannelItem); ok {
-			channelSelectContent = " " + channelItem.Title()
+			channelSelectContent = ui.Icon(ui.Symbols.ChannelIcon, " ") + channelItem.Title()
 		}
 	}
 	channelSelectBox := channelSelectStyle.Render(channelSelectContent)
@@ -680,39 +695,72 @@ func (m StatsModel) View() string {
 		s.WriteString(ui.DescriptionStyle.Render("Filtered: All videos") + "\n")
 	}
 
-	// NOTE :: calculate stats
-	totalVideos, avgRating, totalRated, rewatchCount, channelStats, monthStats, ratingDist := m.calculateStats()
-
-	if totalVideos == 0 {
+	if len(m.currentVideos()) == 0 {
 		s.WriteString(ui.CenterHorizontally("\n no videos logged yet \n", 60))
 		s.WriteString("\n" + m.help.View(StatsKeyMap{}))
 		return s.String()
 	}
 
+	if m.reportOpen {
+		s.WriteString(m.renderReport())
+		s.WriteString("\n" + m.help.View(StatsKeyMap{}))
+		return s.String()
+	}
+
+	if m.ratingsOpen {
+		s.WriteString(m.renderRatings())
+		s.WriteString("\n" + m.help.View(StatsKeyMap{}))
+		return s.String()
+	}
+
+	if m.goalsOpen {
+		s.WriteString(m.renderGoals())
+		s.WriteString("\n" + m.help.View(GoalsKeyMap{}))
+		return s.String()
+	}
+
+	// cards follow the activity chart's window while it is shown
+	summary := analytics.Summarize(m.visibleVideos())
+
 	// streak cards
-	currentStreak, longestStreak := m.getStreaks()
-	currenStreakCard := fmt.Sprintf("Current streak: \n%d videos in %d days", currentStreak.VideoCount, currentStreak.DaySpan)
-	longestStreakCard := fmt.Sprintf("Best streak: \n%d videos in %d days", longestStreak.VideoCount, longestStreak.DaySpan)
+	currentStreak, longestStreak := analytics.Streaks(m.visibleVideos(), m.visibleClock())
+	currenStreakCard := fmt.Sprintf("Current streak: \n%d videos in %d days", currentStreak.Videos, currentStreak.Days)
+	longestStreakCard := fmt.Sprintf("Best streak: \n%d videos in %d days", longestStreak.Videos, longestStreak.Days)
 	streakRow := m.renderDashboardCards(longestStreakCard, currenStreakCard, nil, nil)
 	s.WriteString("\n" + streakRow + "\n")
 
+	// goal cards
+	if goalRow := m.renderGoalCards(m.trackGoals()); goalRow != "" {
+		s.WriteString(goalRow + "\n")
+	}
+
 	// dashboard cards
-	totalCard, avgCard, rewatchCard, channelCountCard := m.getDasboardStrings(totalVideos, avgRating, totalRated, rewatchCount, channelStats)
+	totalCard, avgCard, rewatchCard, channelCountCard := m.getDasboardStrings(summary)
 	row := m.renderDashboardCards(totalCard, avgCard, &rewatchCard, &channelCountCard)
 	s.WriteString("\n" + row + "\n")
 
 	// show selected chart
 	if m.viewMode == 0 {
-		s.WriteString(m.renderChart(m.prepareRatingChartData(ratingDist), m.focusedSearch == 0))
+		s.WriteString(m.renderChart(m.prepareRatingChartData(summary.Ratings), m.focusedSearch == 0))
 	} else if m.viewMode == 1 {
-		s.WriteString(m.renderChart(m.prepareMonthlyChartData(monthStats), m.focusedSearch == 0))
+		s.WriteString(m.renderChart(m.prepareActivityChartData(m.activityBuckets()), m.focusedSearch == 0))
+	} else if m.viewMode == 3 {
+		s.WriteString(m.renderHeatmap(m.focusedSearch == 0))
+	} else if m.viewMode == 4 {
+		s.WriteString(m.renderChart(m.prepareHourChartData(m.currentVideos()), m.focusedSearch == 0))
+	} else if m.viewMode == 5 {
+		s.WriteString(m.renderChart(m.prepareWeekdayChartData(m.currentVideos()), m.focusedSearch == 0))
+	} else if m.viewMode == 6 {
+		s.WriteString(m.renderChart(m.prepareHourRatingChartData(m.currentVideos()), m.focusedSearch == 0))
+	} else if m.viewMode == 7 {
+		s.WriteString(m.renderChart(m.prepareWeekdayRatingChartData(m.currentVideos()), m.focusedSearch == 0))
 	} else {
 		s.WriteString(m.renderVideoList())
 	}
 
 	// show compact channels if `all channels`
-	if len(channelStats) > 0 && m.getSelectedChannel() == "" {
-		s.WriteString(m.renderCompactChannels(channelStats))
+	if len(summary.Channels) > 0 && m.getSelectedChannel() == "" {
+		s.WriteString(m.renderCompactChannels(summary.Channels))
 	} else {
 		if !m.help.ShowAll {
 			s.WriteString("\n\n\n\n\n") // more padding when compact
@@ -730,10 +778,10 @@ func (m StatsModel) View() string {
 
 func (m StatsModel) renderVideoList() string {
 	listStyle := lipgloss.NewStyle().
-		Border(lipgloss.RoundedBorder()).
+		Border(ui.Border()).
 		Padding(0, 1).
 		Margin(1, 0).
-		Width(56)
+		Width(m.boxWidth())
 
 	if m.focusedSearch == 0 && m.viewMode == 2 {
 		listStyle = listStyle.BorderForeground(ui.PrimaryColor)
@@ -744,7 +792,7 @@ func (m StatsModel) renderVideoList() string {
 	if m.isFiltered {
 		videosToUse = m.filtered
 	}
-	content.WriteString(fmt.Sprintf("   Videos (%d/%d)\n", m.videoList.Index()+1, len(videosToUse)))
+	content.WriteString(fmt.Sprintf(" %sVideos (%d/%d)\n", ui.Icon(ui.Symbols.FilmIcon, "  "), m.videoList.Index()+1, len(videosToUse)))
 
 	if len(m.filtered) == 0 {
 		content.WriteString("No videos to display")
@@ -774,39 +822,41 @@ func (m StatsModel) handleVideoSelection() (StatsModel, tea.Cmd) {
 	return m, nil
 }
 
-func (m StatsModel) renderCompactChannels(channelStats []ChannelStats) string {
+func (m StatsModel) renderCompactChannels(channelStats []analytics.ChannelStats) string {
 	listStyle := lipgloss.NewStyle().
-		Border(lipgloss.RoundedBorder()).
+		Border(ui.Border()).
 		Padding(0, 1).
-		Width(56)
-
-	var list strings.Builder
-
-	list.WriteString(" Top Channels: ")
-
-	limit := min(len(channelStats), 3)
-
-	for i, stats := range channelStats[:limit] {
-		if i > 0 {
-			list.WriteString("\n\t\t\t\t• ")
-		} else {
-			list.WriteString("• ")
-		}
-		avgStr := ""
+		Width(m.boxWidth())
+
+	top := channelStats[:min(len(channelStats), 3)]
+	series := chart.Series{
+		Labels:      make([]string, len(top)),
+		Values:      make([]float64, len(top)),
+		ValueLabels: make([]string, len(top)),
+	}
+	for i, stats := range top {
+		series.Labels[i] = stats.Channel
+		series.Values[i] = float64(stats.Count)
+		series.ValueLabels[i] = fmt.Sprintf("%d", stats.Count)
 		if stats.TotalRated > 0 {
-			avgStr = fmt.Sprintf("(%.1f)", stats.AvgRating)
+			series.ValueLabels[i] += fmt.Sprintf(" (%.1f)", stats.AvgRating)
 		}
-		list.WriteString(fmt.Sprintf("%-15s  %-3d%-6s", stats.Channel, stats.Count, avgStr))
 	}
 
-	return listStyle.Render(list.String()) + "\n"
+	bars := chart.HBars(series, chart.Options{Width: m.contentWidth(), Style: lipgloss.NewStyle().Foreground(ui.ChartColor)})
+	return listStyle.Render(bars) + "\n"
 }
 
 func truncateString(s string, maxLen int) string {
 	if len(s) <= maxLen {
 		return s
 	}
-	return s[:maxLen] + "…"
+	return s[:maxLen] + ui.Symbols.Ellipsis
+}
+
+func init() {
+	ui.RegisterKeyScope("stats", "Up", "Down", "Left", "Right", "Select", "Edit", "Search", "Cycle", "CycleBack",
+		"PrevPeriod", "NextPeriod", "Report", "Ratings", "Period", "Goals", "Channel", "NextFilter", "PrevFilter", "Back")
 }
 
 type StatsKeyMap struct{}
@@ -817,6 +867,7 @@ func (k StatsKeyMap) ShortHelp() []key.Binding {
 		ui.GlobalKeyMap.Search,
 		ui.GlobalKeyMap.Left,
 		ui.GlobalKeyMap.Right,
+		ui.GlobalKeyMap.NextFilter,
 		ui.GlobalKeyMap.Help,
 	}
 }
@@ -834,6 +885,17 @@ func (k StatsKeyMap) FullHelp() [][]key.Binding {
 			ui.GlobalKeyMap.Up,
 			ui.GlobalKeyMap.Down,
 		},
+		{
+			ui.GlobalKeyMap.NextFilter,
+			ui.GlobalKeyMap.PrevFilter,
+			ui.GlobalKeyMap.PrevPeriod,
+			ui.GlobalKeyMap.NextPeriod,
+			ui.GlobalKeyMap.Period,
+			ui.GlobalKeyMap.Report,
+			ui.GlobalKeyMap.Ratings,
+			ui.GlobalKeyMap.Goals,
+			ui.GlobalKeyMap.Channel,
+		},
 		{
 			ui.GlobalKeyMap.Help,
 			ui.GlobalKeyMap.Select,
@@ -843,10 +905,3 @@ func (k StatsKeyMap) FullHelp() [][]key.Binding {
 		},
 	}
 }
-
-func getVideoChannel(video models.Video) string {
-	if video.Channel == "" {
-		return "Unknown Channel"
-	}
-	return video.Channel
-}
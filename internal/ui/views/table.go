@@ -0,0 +1,140 @@
+package views
+
+import (
+	"strings"
+	"unicode"
+
+	"github.com/charmbracelet/bubbles/table"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/mamuzad/vidlogd/internal/ui"
+	"github.com/mattn/go-runewidth"
+)
+
+// renderTable draws t like table.Model.View, highlighting terms in the
+// given columns and styling marked rows. bubbles truncates cells with
+// runewidth, which counts ANSI escapes as text, so highlighted cells are
+// styled after truncation here.
+func renderTable(t table.Model, offset int, terms []string, highlightCols, markedRows map[int]bool) string {
+	cols := t.Columns()
+	rows := t.Rows()
+	height := t.Height()
+
+	headers := make([]string, len(cols))
+	for i, col := range cols {
+		cell := lipgloss.NewStyle().Width(col.Width).MaxWidth(col.Width).Inline(true).
+			Render(runewidth.Truncate(col.Title, col.Width, ui.Symbols.Ellipsis))
+		headers[i] = ui.TableHeaderStyle.Render(cell)
+	}
+
+	lines := []string{lipgloss.JoinHorizontal(lipgloss.Top, headers...)}
+
+	offset = clampOffset(offset, t.Cursor(), height, len(rows))
+	for i := offset; i < min(offset+height, len(rows)); i++ {
+		base := lipgloss.NewStyle()
+		match := ui.TableMatchStyle
+		if markedRows[i] {
+			base = ui.TableMarkedStyle
+		}
+		if i == t.Cursor() {
+			base = ui.TableSelectedRowStyle
+			match = ui.TableSelectedRowStyle.Underline(true)
+		}
+
+		var row strings.Builder
+		for j, col := range cols {
+			value := ""
+			if j < len(rows[i]) {
+				value = rows[i][j]
+			}
+			var cellTerms []string
+			if highlightCols[j] {
+				cellTerms = terms
+			}
+			lead := " "
+			switch {
+			case j == 0 && markedRows[i]:
+				lead = ui.Symbols.Bullet // marks stay visible under the cursor too
+			case j == 0 && i == t.Cursor() && ui.Linear:
+				lead = ">"
+			}
+			row.WriteString(base.Render(lead))
+			row.WriteString(highlightCell(value, col.Width, cellTerms, base, match))
+			row.WriteString(base.Render(" "))
+		}
+		lines = append(lines, row.String())
+	}
+
+	// keep the table a fixed height while filtering
+	for len(lines) < height+1 {
+		lines = append(lines, "")
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// clampOffset scrolls the minimum needed to keep the cursor visible
+func clampOffset(offset, cursor, height, total int) int {
+	if height <= 0 {
+		return 0
+	}
+	if cursor < offset {
+		offset = cursor
+	}
+	if cursor >= offset+height {
+		offset = cursor - height + 1
+	}
+	return max(0, min(offset, total-height, total))
+}
+
+// highlightCell truncates value to width, styles matches of terms and pads
+// the rest of the cell
+func highlightCell(value string, width int, terms []string, base, match lipgloss.Style) string {
+	value = runewidth.Truncate(value, width, ui.Symbols.Ellipsis)
+	padding := strings.Repeat(" ", max(0, width-runewidth.StringWidth(value)))
+
+	marked := matchMask(value, terms)
+	runes := []rune(value)
+
+	var b strings.Builder
+	for start := 0; start < len(runes); {
+		end := start
+		for end < len(runes) && marked[end] == marked[start] {
+			end++
+		}
+		style := base
+		if marked[start] {
+			style = match
+		}
+		b.WriteString(style.Render(string(runes[start:end])))
+		start = end
+	}
+	if padding != "" {
+		b.WriteString(base.Render(padding))
+	}
+	return b.String()
+}
+
+// matchMask marks the runes of s covered by a case-insensitive match of any term
+func matchMask(s string, terms []string) []bool {
+	runes := []rune(s)
+	lower := make([]rune, len(runes))
+	for i, r := range runes {
+		lower[i] = unicode.ToLower(r)
+	}
+
+	marked := make([]bool, len(runes))
+	for _, term := range terms {
+		needle := []rune(strings.ToLower(term))
+		if len(needle) == 0 {
+			continue
+		}
+		for i := 0; i+len(needle) <= len(lower); i++ {
+			if string(lower[i:i+len(needle)]) == string(needle) {
+				for k := i; k < i+len(needle); k++ {
+					marked[k] = true
+				}
+			}
+		}
+	}
+	return marked
+}
@@ -0,0 +1,661 @@
+package views
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/help"
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/mamuzad/vidlogd/internal/journal"
+	"github.com/mamuzad/vidlogd/internal/models"
+	"github.com/mamuzad/vidlogd/internal/storage"
+	"github.com/mamuzad/vidlogd/internal/transfer"
+	"github.com/mamuzad/vidlogd/internal/ui"
+)
+
+type transferStage int
+
+const (
+	transferMenu transferStage = iota
+	transferForm
+	transferMapping
+	transferPreview
+	transferTakeoutPreview
+)
+
+// number of problem rows listed in the import preview
+const previewProblemLimit = 8
+
+type TransferKeyMap struct {
+	stage transferStage
+}
+
+func (k TransferKeyMap) ShortHelp() []key.Binding {
+	switch k.stage {
+	case transferMapping:
+		return []key.Binding{
+			ui.GlobalKeyMap.Up,
+			ui.GlobalKeyMap.Down,
+			ui.GlobalKeyMap.Left,
+			ui.GlobalKeyMap.Right,
+			ui.GlobalKeyMap.Select,
+			ui.GlobalKeyMap.Back,
+		}
+	case transferPreview, transferTakeoutPreview:
+		return []key.Binding{
+			ui.GlobalKeyMap.Select,
+			ui.GlobalKeyMap.Back,
+		}
+	default:
+		return []key.Binding{
+			ui.GlobalKeyMap.Up,
+			ui.GlobalKeyMap.Down,
+			ui.GlobalKeyMap.Select,
+			ui.GlobalKeyMap.Back,
+		}
+	}
+}
+
+func (k TransferKeyMap) FullHelp() [][]key.Binding {
+	return [][]key.Binding{k.ShortHelp()}
+}
+
+type TransferModel struct {
+	stage   transferStage
+	actions list.Model
+	form    *FormModel
+	help    help.Model
+
+	// import state
+	dateFormat    string
+	header        []string
+	rows          [][]string
+	mapping       transfer.ColumnMapping
+	mappingCursor int
+	plan          transfer.ImportResult
+	takeoutPlan   transfer.TakeoutResult
+
+	status    string
+	statusErr bool
+}
+
+type transferDoneMsg struct {
+	status   string
+	err      error
+	imported bool // videos were added to the log
+}
+
+type takeoutPlannedMsg struct {
+	plan transfer.TakeoutResult
+	err  error
+}
+
+type importLoadedMsg struct {
+	dateFormat string
+	header     []string
+	rows       [][]string
+	err        error
+}
+
+func NewTransferModel() TransferModel {
+	items := []list.Item{
+		ActionItem{title: "export csv"},
+		ActionItem{title: "import csv"},
+		ActionItem{title: "import takeout history"},
+		ActionItem{title: "export markdown journal"},
+		ActionItem{title: "export html journal"},
+		ActionItem{title: "find duplicates"},
+		ActionItem{title: "check library"},
+		ActionItem{title: "back"},
+	}
+
+	l := list.New(items, ActionItemDelegate{}, 40, 10)
+	ui.SetupList(&l)
+	l.SetShowStatusBar(false)
+	l.SetFilteringEnabled(false)
+	l.SetShowTitle(false)
+	l.SetShowHelp(false)
+
+	h := ui.NewHelp()
+	h.ShowAll = false
+
+	return TransferModel{
+		actions: l,
+		help:    h,
+	}
+}
+
+// RefreshStyles applies a changed key map or symbols mode to the list
+func (m *TransferModel) RefreshStyles() {
+	ui.SetupList(&m.actions)
+}
+
+func (m TransferModel) Init() tea.Cmd {
+	return nil
+}
+
+func (m TransferModel) Update(msg tea.Msg) (TransferModel, tea.Cmd) {
+	switch msg := msg.(type) {
+	case transferDoneMsg:
+		m.stage = transferMenu
+		m.form = nil
+		m.setStatus(msg.status, msg.err)
+		if msg.imported {
+			return m, ui.LibraryChanged
+		}
+		return m, nil
+
+	case importLoadedMsg:
+		m.form = nil
+		if msg.err != nil {
+			m.stage = transferMenu
+			m.setStatus("", msg.err)
+			return m, nil
+		}
+		m.dateFormat = msg.dateFormat
+		m.header = msg.header
+		m.rows = msg.rows
+		m.mapping = transfer.GuessMapping(msg.header)
+		m.mappingCursor = 0
+		m.stage = transferMapping
+		return m, nil
+
+	case takeoutPlannedMsg:
+		m.form = nil
+		if msg.err != nil {
+			m.stage = transferMenu
+			m.setStatus("", msg.err)
+			return m, nil
+		}
+		m.takeoutPlan = msg.plan
+		m.stage = transferTakeoutPreview
+		return m, nil
+	}
+
+	switch m.stage {
+	case transferForm:
+		if m.form != nil {
+			form, cmd := m.form.Update(msg)
+			m.form = &form
+			return m, cmd
+		}
+	case transferMapping:
+		return m.updateMapping(msg)
+	case transferPreview:
+		return m.updatePreview(msg)
+	case transferTakeoutPreview:
+		return m.updateTakeoutPreview(msg)
+	}
+
+	if msg, ok := msg.(tea.KeyMsg); ok {
+		switch {
+		case key.Matches(msg, ui.GlobalKeyMap.Back, ui.GlobalKeyMap.Cancel):
+			return m, func() tea.Msg { return ui.BackMsg{} }
+		case key.Matches(msg, ui.GlobalKeyMap.Select):
+			return m.handleAction()
+		}
+	}
+
+	var cmd tea.Cmd
+	m.actions, cmd = m.actions.Update(msg)
+	return m, cmd
+}
+
+func (m *TransferModel) setStatus(status string, err error) {
+	if err != nil {
+		m.status = err.Error()
+		m.statusErr = true
+		return
+	}
+	m.status = status
+	m.statusErr = false
+}
+
+func (m TransferModel) handleAction() (TransferModel, tea.Cmd) {
+	selectedItem, ok := m.actions.SelectedItem().(ActionItem)
+	if !ok {
+		return m, nil
+	}
+
+	cancel := func() tea.Cmd {
+		return func() tea.Msg { return transferDoneMsg{} }
+	}
+
+	switch selectedItem.title {
+	case "export csv":
+		form := NewForm("export csv", transferFields(defaultExportPath()), "export")
+		form.SetHandlers(
+			func(f FormModel) tea.Cmd {
+				path, dateFormat := f.Value(0), f.Value(1)
+				return func() tea.Msg { return exportCSV(path, dateFormat) }
+			},
+			cancel,
+		)
+		m.form = &form
+		m.stage = transferForm
+	case "import csv":
+		form := NewForm("import csv", transferFields(""), "next")
+		form.SetHandlers(
+			func(f FormModel) tea.Cmd {
+				path, dateFormat := f.Value(0), f.Value(1)
+				return func() tea.Msg { return loadImportFile(path, dateFormat) }
+			},
+			cancel,
+		)
+		m.form = &form
+		m.stage = transferForm
+	case "import takeout history":
+		form := NewForm("import takeout history", takeoutFields(), "preview")
+		form.SetHandlers(
+			func(f FormModel) tea.Cmd {
+				values := f.AllValues()
+				return func() tea.Msg { return planTakeout(values) }
+			},
+			cancel,
+		)
+		m.form = &form
+		m.stage = transferForm
+	case "export markdown journal", "export html journal":
+		asHTML := selectedItem.title == "export html journal"
+		path := defaultJournalPath(asHTML)
+		form := NewForm(selectedItem.title, journalFields(path), "export")
+		form.SetHandlers(
+			func(f FormModel) tea.Cmd {
+				values := f.AllValues()
+				return func() tea.Msg { return exportJournal(values, asHTML) }
+			},
+			cancel,
+		)
+		m.form = &form
+		m.stage = transferForm
+	case "find duplicates":
+		m.status = ""
+		return m, func() tea.Msg { return ui.NavigateMsg{View: ui.DedupeView} }
+	case "check library":
+		m.status = ""
+		return m, func() tea.Msg { return ui.NavigateMsg{View: ui.DoctorView} }
+	case "back":
+		return m, func() tea.Msg { return ui.BackMsg{} }
+	}
+
+	m.status = ""
+	return m, nil
+}
+
+func transferFields(path string) []FormField {
+	return []FormField{
+		{Placeholder: "~/videos.csv", Label: "File Path:", Required: true, CharLimit: 200, Width: 60, Type: FormFieldText, Value: path},
+		{Placeholder: "datetime, iso, rfc3339 or a Go layout", Label: "Date Format:", Required: true, CharLimit: 40, Width: 40, Type: FormFieldText, Value: "datetime"},
+	}
+}
+
+// takeout form field order, see planTakeout
+func takeoutFields() []FormField {
+	return []FormField{
+		{Placeholder: "~/Takeout/YouTube and YouTube Music/history/watch-history.json", Label: "Watch History File (.json or .html):", Required: true, CharLimit: 300, Width: 60, Type: FormFieldText},
+		{Placeholder: "YYYY-MM-DD", Label: "From:", Required: false, CharLimit: 10, Width: 17, Type: FormFieldDate, SideBySide: true},
+		{Placeholder: "YYYY-MM-DD", Label: "To:", Required: false, CharLimit: 10, Width: 17, Type: FormFieldDate, SideBySide: true},
+		{Placeholder: "1", Label: "Minimum Watches:", Required: false, CharLimit: 3, Width: 10, Type: FormFieldText, Value: "1"},
+		{Label: "Exclude Ads:", Type: FormFieldCheckbox, Width: 10, Value: "true", SideBySide: true},
+		{Label: "Exclude Shorts:", Type: FormFieldCheckbox, Width: 10, Value: "false", SideBySide: true},
+	}
+}
+
+// journal form field order, see exportJournal
+func journalFields(path string) []FormField {
+	return []FormField{
+		{Placeholder: "~/journal", Label: "Output Path:", Required: true, CharLimit: 200, Width: 60, Type: FormFieldText, Value: path},
+		{Placeholder: "what we watched this quarter", Label: "Title:", Required: false, CharLimit: 100, Width: 60, Type: FormFieldText},
+		{Placeholder: "YYYY-MM-DD", Label: "From:", Required: false, CharLimit: 10, Width: 17, Type: FormFieldDate, SideBySide: true},
+		{Placeholder: "YYYY-MM-DD", Label: "To:", Required: false, CharLimit: 10, Width: 17, Type: FormFieldDate, SideBySide: true},
+	}
+}
+
+func (m TransferModel) updateMapping(msg tea.Msg) (TransferModel, tea.Cmd) {
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return m, nil
+	}
+
+	columns := transfer.ImportColumns
+	switch {
+	case key.Matches(keyMsg, ui.GlobalKeyMap.Back, ui.GlobalKeyMap.Cancel):
+		m.stage = transferMenu
+	case key.Matches(keyMsg, ui.GlobalKeyMap.Up):
+		m.mappingCursor = (m.mappingCursor + len(columns) - 1) % len(columns)
+	case key.Matches(keyMsg, ui.GlobalKeyMap.Down):
+		m.mappingCursor = (m.mappingCursor + 1) % len(columns)
+	case key.Matches(keyMsg, ui.GlobalKeyMap.Left):
+		m.cycleMapping(columns[m.mappingCursor], -1)
+	case key.Matches(keyMsg, ui.GlobalKeyMap.Right):
+		m.cycleMapping(columns[m.mappingCursor], 1)
+	case key.Matches(keyMsg, ui.GlobalKeyMap.Select):
+		existing, err := models.LoadVideos()
+		if err != nil {
+			m.setStatus("", err)
+			return m, nil
+		}
+		m.plan = transfer.PlanImport(m.rows, existing, transfer.ImportOptions{
+			Mapping:    m.mapping,
+			DateFormat: m.dateFormat,
+		})
+		m.stage = transferPreview
+	}
+	return m, nil
+}
+
+// cycleMapping moves a column to the next/previous CSV header, -1 is unmapped
+func (m *TransferModel) cycleMapping(column string, step int) {
+	current, ok := m.mapping[column]
+	if !ok {
+		current = -1
+	}
+
+	total := len(m.header) + 1 // headers plus unmapped
+	next := (current+1+step+total)%total - 1
+
+	if next == -1 {
+		delete(m.mapping, column)
+	} else {
+		m.mapping[column] = next
+	}
+}
+
+func (m TransferModel) updatePreview(msg tea.Msg) (TransferModel, tea.Cmd) {
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return m, nil
+	}
+
+	switch {
+	case key.Matches(keyMsg, ui.GlobalKeyMap.Back, ui.GlobalKeyMap.Cancel):
+		m.stage = transferMapping
+	case key.Matches(keyMsg, ui.GlobalKeyMap.Select):
+		videos := m.plan.Videos()
+		return m, func() tea.Msg {
+			if err := models.SaveVideos(videos); err != nil {
+				return transferDoneMsg{err: err}
+			}
+			return transferDoneMsg{status: fmt.Sprintf("imported %d videos", len(videos)), imported: true}
+		}
+	}
+	return m, nil
+}
+
+func (m TransferModel) updateTakeoutPreview(msg tea.Msg) (TransferModel, tea.Cmd) {
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return m, nil
+	}
+
+	switch {
+	case key.Matches(keyMsg, ui.GlobalKeyMap.Back, ui.GlobalKeyMap.Cancel):
+		m.stage = transferMenu
+	case key.Matches(keyMsg, ui.GlobalKeyMap.Select):
+		videos := m.takeoutPlan.Videos
+		return m, func() tea.Msg {
+			if err := models.SaveVideos(videos); err != nil {
+				return transferDoneMsg{err: err}
+			}
+			return transferDoneMsg{status: fmt.Sprintf("imported %d videos from takeout", len(videos)), imported: true}
+		}
+	}
+	return m, nil
+}
+
+func (m TransferModel) View() string {
+	switch m.stage {
+	case transferForm:
+		if m.form != nil {
+			return m.form.View()
+		}
+	case transferMapping:
+		return m.viewMapping()
+	case transferPreview:
+		return m.viewPreview()
+	case transferTakeoutPreview:
+		return m.viewTakeoutPreview()
+	}
+
+	var s strings.Builder
+	s.WriteString(ui.HeaderStyle.Render("import / export") + "\n\n")
+	s.WriteString(m.actions.View() + "\n")
+
+	if m.status != "" {
+		if m.statusErr {
+			s.WriteString(ui.DangerStyle.Render(m.status) + "\n")
+		} else {
+			s.WriteString(ui.DescriptionStyle.Render(m.status) + "\n")
+		}
+	}
+
+	s.WriteString("\n" + m.help.View(TransferKeyMap{stage: m.stage}))
+	return s.String()
+}
+
+func (m TransferModel) viewMapping() string {
+	var s strings.Builder
+	s.WriteString(ui.HeaderStyle.Render("map columns") + "\n\n")
+	s.WriteString(ui.DescriptionStyle.Render(fmt.Sprintf("%d rows found, pick the csv column for each field", len(m.rows))) + "\n\n")
+
+	for i, column := range transfer.ImportColumns {
+		source := "(unmapped)"
+		if index, ok := m.mapping[column]; ok {
+			source = m.header[index]
+		}
+
+		line := fmt.Sprintf("%-13s %s %s", column, ui.Symbols.ArrowBack, source)
+		s.WriteString(ui.RenderItem(line, i == m.mappingCursor))
+		s.WriteString("\n")
+	}
+
+	s.WriteString("\n" + m.help.View(TransferKeyMap{stage: m.stage}))
+	return s.String()
+}
+
+func (m TransferModel) viewPreview() string {
+	var s strings.Builder
+	s.WriteString(ui.HeaderStyle.Render("dry run") + "\n\n")
+	s.WriteString(fmt.Sprintf("%d valid   %d invalid   %d duplicates\n\n", m.plan.Valid, m.plan.Invalid, m.plan.Duplicates))
+
+	shown := 0
+	for _, row := range m.plan.Rows {
+		if shown >= previewProblemLimit {
+			s.WriteString(ui.DescriptionStyle.Render(ui.Symbols.Ellipsis) + "\n")
+			break
+		}
+		switch {
+		case len(row.Errors) > 0:
+			s.WriteString(ui.DangerStyle.Render(fmt.Sprintf("line %d", row.Line)) + "  " + truncateString(strings.Join(row.Errors, "; "), 60) + "\n")
+			shown++
+		case row.Duplicate:
+			s.WriteString(ui.DescriptionStyle.Render(fmt.Sprintf("line %d  duplicate: %s", row.Line, truncateString(row.Video.Title, 40))) + "\n")
+			shown++
+		}
+	}
+
+	s.WriteString("\n" + ui.ButtonStyleFocused.Render(fmt.Sprintf("import %d videos", m.plan.Valid)))
+	s.WriteString("\n\n" + m.help.View(TransferKeyMap{stage: m.stage}))
+	return s.String()
+}
+
+func (m TransferModel) viewTakeoutPreview() string {
+	plan := m.takeoutPlan
+
+	var s strings.Builder
+	s.WriteString(ui.HeaderStyle.Render("dry run") + "\n\n")
+	s.WriteString(fmt.Sprintf("%d watches in history\n", plan.Watches))
+	s.WriteString(ui.DescriptionStyle.Render(fmt.Sprintf(
+		"skipped %d ads, %d shorts, %d out of range,\n%d below repeat count, %d already logged, %d incomplete",
+		plan.Ads, plan.Shorts, plan.OutOfRange, plan.BelowRepeat, plan.AlreadyLogged, plan.Invalid,
+	)) + "\n\n")
+
+	for i, video := range plan.Videos {
+		if i >= previewProblemLimit {
+			s.WriteString(ui.DescriptionStyle.Render(fmt.Sprintf("%s and %d more", ui.Symbols.Ellipsis, len(plan.Videos)-i)) + "\n")
+			break
+		}
+		s.WriteString(fmt.Sprintf("%s  %s\n", video.LogDate.Format(models.ISODateFormat), truncateString(video.Title, 50)))
+	}
+
+	s.WriteString("\n" + ui.ButtonStyleFocused.Render(fmt.Sprintf("import %d videos", len(plan.Videos))))
+	s.WriteString("\n\n" + m.help.View(TransferKeyMap{stage: m.stage}))
+	return s.String()
+}
+
+func defaultExportPath() string {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "vidlogd-export.csv"
+	}
+	return filepath.Join(home, "vidlogd-export.csv")
+}
+
+// expandPath resolves a leading ~ to the user's home directory
+func expandPath(path string) string {
+	path = strings.TrimSpace(path)
+	if path == "~" || strings.HasPrefix(path, "~/") {
+		if home, err := os.UserHomeDir(); err == nil {
+			return filepath.Join(home, strings.TrimPrefix(path, "~"))
+		}
+	}
+	return path
+}
+
+func exportCSV(path, dateFormat string) tea.Msg {
+	videos, err := models.LoadVideos()
+	if err != nil {
+		return transferDoneMsg{err: err}
+	}
+	models.SortVideosByLogDate(videos)
+
+	status, err := writeCSV(path, dateFormat, videos)
+	return transferDoneMsg{status: status, err: err}
+}
+
+// writeCSV exports videos to path, returning a status line
+func writeCSV(path, dateFormat string, videos []models.Video) (string, error) {
+	path = expandPath(path)
+
+	f, err := os.Create(path)
+	if err != nil {
+		return "", err
+	}
+	if err := transfer.ExportCSV(f, videos, dateFormat); err != nil {
+		f.Close()
+		return "", err
+	}
+	if err := f.Close(); err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("exported %d videos to %s", len(videos), path), nil
+}
+
+func defaultJournalPath(asHTML bool) string {
+	name := "vidlogd-journal.md"
+	if asHTML {
+		name = "vidlogd-journal"
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return name
+	}
+	return filepath.Join(home, name)
+}
+
+func exportJournal(values []string, asHTML bool) tea.Msg {
+	path := expandPath(values[0])
+
+	videos, err := models.LoadVideos()
+	if err != nil {
+		return transferDoneMsg{err: err}
+	}
+
+	opts := journal.Options{Title: strings.TrimSpace(values[1])}
+	if values[2] != "" {
+		opts.From, _ = time.ParseInLocation(models.ISODateFormat, values[2], time.Local)
+	}
+	if values[3] != "" {
+		opts.To, _ = time.ParseInLocation(models.ISODateFormat, values[3], time.Local)
+	}
+	j := journal.New(videos, opts)
+
+	if asHTML {
+		if err := journal.WriteSite(path, j); err != nil {
+			return transferDoneMsg{err: err}
+		}
+	} else {
+		var buf bytes.Buffer
+		if err := journal.WriteMarkdown(&buf, j); err != nil {
+			return transferDoneMsg{err: err}
+		}
+		if err := storage.WriteFileAtomic(path, buf.Bytes(), 0o644); err != nil {
+			return transferDoneMsg{err: err}
+		}
+	}
+
+	return transferDoneMsg{status: fmt.Sprintf("wrote journal for %d videos to %s", len(j.Videos), path)}
+}
+
+func loadImportFile(path, dateFormat string) tea.Msg {
+	f, err := os.Open(expandPath(path))
+	if err != nil {
+		return importLoadedMsg{err: err}
+	}
+	defer f.Close()
+
+	header, rows, err := transfer.ReadCSV(f)
+	if err != nil {
+		return importLoadedMsg{err: err}
+	}
+
+	return importLoadedMsg{dateFormat: dateFormat, header: header, rows: rows}
+}
+
+// planTakeout parses the watch history named in the takeout form values
+func planTakeout(values []string) tea.Msg {
+	filter := transfer.TakeoutFilter{
+		ExcludeAds:    values[4] == "true",
+		ExcludeShorts: values[5] == "true",
+		MinRepeat:     1,
+	}
+	if values[1] != "" {
+		filter.From, _ = time.ParseInLocation(models.ISODateFormat, values[1], time.Local)
+	}
+	if values[2] != "" {
+		filter.To, _ = time.ParseInLocation(models.ISODateFormat, values[2], time.Local)
+	}
+	if repeat := strings.TrimSpace(values[3]); repeat != "" {
+		n, err := strconv.Atoi(repeat)
+		if err != nil || n < 1 {
+			return takeoutPlannedMsg{err: fmt.Errorf("minimum watches must be a positive number")}
+		}
+		filter.MinRepeat = n
+	}
+
+	f, err := os.Open(expandPath(values[0]))
+	if err != nil {
+		return takeoutPlannedMsg{err: err}
+	}
+	defer f.Close()
+
+	entries, err := transfer.ParseTakeout(f)
+	if err != nil {
+		return takeoutPlannedMsg{err: err}
+	}
+
+	existing, err := models.LoadVideos()
+	if err != nil {
+		return takeoutPlannedMsg{err: err}
+	}
+
+	return takeoutPlannedMsg{plan: transfer.PlanTakeout(entries, existing, filter)}
+}